@@ -0,0 +1,278 @@
+package address
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type controller struct {
+	addressService AddressService
+}
+
+func NewController(addressService AddressService) *controller {
+	return &controller{addressService}
+}
+
+func (cn *controller) GetAddressesByUser(c *gin.Context) {
+	userIdString := c.Param("userId")
+	userId, err := strconv.Atoi(userIdString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid user ID",
+		})
+		return
+	}
+
+	addresses, err := cn.addressService.FindAddressesByUser(userId)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	addressesResponse := []AddressResponse{}
+
+	for _, address := range addresses {
+		addressesResponse = append(addressesResponse, convertToAddressResponse(address))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  addressesResponse,
+	})
+}
+
+func (cn *controller) GetDefaultAddress(c *gin.Context) {
+	userIdString := c.Param("userId")
+	userId, err := strconv.Atoi(userIdString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid user ID",
+		})
+		return
+	}
+
+	address, err := cn.addressService.FindDefaultAddress(userId)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "Address not found" {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToAddressResponse(address),
+	})
+}
+
+func (cn *controller) GetAddress(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid address ID",
+		})
+		return
+	}
+
+	address, err := cn.addressService.FindAddressByID(id)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "Address not found" {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToAddressResponse(address),
+	})
+}
+
+func (cn *controller) CreateAddress(c *gin.Context) {
+	var addressRequest AddressCreateRequest
+
+	err := c.ShouldBindJSON(&addressRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+				errorMessages = append(errorMessages, errorMessage)
+			}
+		} else {
+			errorMessages = append(errorMessages, "Invalid request body")
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	address, err := cn.addressService.CreateAddress(addressRequest)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToAddressResponse(address),
+	})
+}
+
+func (cn *controller) UpdateAddress(c *gin.Context) {
+	var addressRequest AddressUpdateRequest
+
+	err := c.ShouldBindJSON(&addressRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+				errorMessages = append(errorMessages, errorMessage)
+			}
+		} else {
+			errorMessages = append(errorMessages, "Invalid request body")
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid address ID",
+		})
+		return
+	}
+
+	address, err := cn.addressService.UpdateAddress(id, addressRequest)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "Address not found" {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToAddressResponse(address),
+	})
+}
+
+func (cn *controller) DeleteAddress(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid address ID",
+		})
+		return
+	}
+
+	address, err := cn.addressService.DeleteAddress(id)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "Address not found" {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToAddressResponse(address),
+	})
+}
+
+func convertToAddressResponse(address Address) AddressResponse {
+	return AddressResponse{
+		ID:          address.ID,
+		UserID:      address.UserID,
+		Label:       address.Label,
+		Recipient:   address.Recipient,
+		Phone:       address.Phone,
+		Province:    address.Province,
+		City:        address.City,
+		CityID:      address.CityID,
+		District:    address.District,
+		SubDistrict: address.SubDistrict,
+		PostalCode:  address.PostalCode,
+		Details:     address.Details,
+		Latitude:    address.Latitude,
+		Longitude:   address.Longitude,
+		IsDefault:   address.IsDefault,
+	}
+}
@@ -0,0 +1,18 @@
+package address
+
+type AddressCreateRequest struct {
+	UserID      int      `json:"user_id" binding:"required"`
+	Label       string   `json:"label"`
+	Recipient   string   `json:"recipient" binding:"required"`
+	Phone       string   `json:"phone" binding:"required"`
+	Province    string   `json:"province" binding:"required"`
+	City        string   `json:"city" binding:"required"`
+	CityID      string   `json:"city_id"`
+	District    string   `json:"district" binding:"required"`
+	SubDistrict string   `json:"sub_district" binding:"required"`
+	PostalCode  string   `json:"postal_code" binding:"required,numeric,len=5"`
+	Details     string   `json:"details" binding:"required"`
+	Latitude    *float64 `json:"latitude" binding:"omitempty,latitude"`
+	Longitude   *float64 `json:"longitude" binding:"omitempty,longitude"`
+	IsDefault   bool     `json:"is_default"`
+}
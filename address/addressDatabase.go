@@ -0,0 +1,45 @@
+package address
+
+import (
+	"strings"
+	"time"
+)
+
+type Address struct {
+	ID          uint64     `gorm:"column:id;primaryKey;autoIncrement"`
+	UserID      int        `gorm:"column:user_id;index"`
+	Label       string     `gorm:"column:label;type:varchar(255)"`
+	Recipient   string     `gorm:"column:recipient;type:varchar(255)"`
+	Phone       string     `gorm:"column:phone;type:varchar(255)"`
+	Province    string     `gorm:"column:province;type:varchar(255)"`
+	City        string     `gorm:"column:city;type:varchar(255)"`
+	CityID      string     `gorm:"column:city_id;type:varchar(255)"`
+	District    string     `gorm:"column:district;type:varchar(255)"`
+	SubDistrict string     `gorm:"column:sub_district;type:varchar(255)"`
+	PostalCode  string     `gorm:"column:postal_code;type:varchar(10)"`
+	Details     string     `gorm:"column:details;type:text"`
+	Latitude    *float64   `gorm:"column:latitude"`
+	Longitude   *float64   `gorm:"column:longitude"`
+	IsDefault   bool       `gorm:"column:is_default"`
+	CreatedAt   *time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   *time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (a Address) Destination() string {
+	if a.CityID != "" {
+		return a.CityID
+	}
+	return a.City
+}
+
+func (a Address) String() string {
+	parts := []string{}
+
+	for _, part := range []string{a.Details, a.SubDistrict, a.District, a.City, a.Province, a.PostalCode} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
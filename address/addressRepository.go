@@ -0,0 +1,68 @@
+package address
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type AddressRepository interface {
+	FindAddressesByUser(userID int) ([]Address, error)
+	FindAddressByID(ID int) (Address, error)
+	FindDefaultAddress(userID int) (Address, error)
+	ClearDefaultAddress(userID int, exceptID uint64) error
+	CreateAddress(address Address) (Address, error)
+	UpdateAddress(address Address) (Address, error)
+	DeleteAddress(address Address) (Address, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) FindAddressesByUser(userID int) ([]Address, error) {
+	var addresses []Address
+	err := r.db.Where("user_id = ?", userID).Order("is_default DESC, id").Find(&addresses).Error
+	return addresses, err
+}
+
+func (r *repository) FindAddressByID(ID int) (Address, error) {
+	var address Address
+	err := r.db.First(&address, ID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Address{}, errors.New("Address not found")
+	}
+	return address, err
+}
+
+func (r *repository) FindDefaultAddress(userID int) (Address, error) {
+	var address Address
+	err := r.db.Where("user_id = ?", userID).Order("is_default DESC, id").First(&address).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Address{}, errors.New("Address not found")
+	}
+	return address, err
+}
+
+func (r *repository) ClearDefaultAddress(userID int, exceptID uint64) error {
+	return r.db.Model(&Address{}).Where("user_id = ? AND id <> ?", userID, exceptID).Update("is_default", false).Error
+}
+
+func (r *repository) CreateAddress(address Address) (Address, error) {
+	err := r.db.Create(&address).Error
+	return address, err
+}
+
+func (r *repository) UpdateAddress(address Address) (Address, error) {
+	err := r.db.Save(&address).Error
+	return address, err
+}
+
+func (r *repository) DeleteAddress(address Address) (Address, error) {
+	err := r.db.Delete(&address).Error
+	return address, err
+}
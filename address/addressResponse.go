@@ -0,0 +1,19 @@
+package address
+
+type AddressResponse struct {
+	ID          uint64   `json:"id"`
+	UserID      int      `json:"user_id"`
+	Label       string   `json:"label"`
+	Recipient   string   `json:"recipient"`
+	Phone       string   `json:"phone"`
+	Province    string   `json:"province"`
+	City        string   `json:"city"`
+	CityID      string   `json:"city_id"`
+	District    string   `json:"district"`
+	SubDistrict string   `json:"sub_district"`
+	PostalCode  string   `json:"postal_code"`
+	Details     string   `json:"details"`
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+	IsDefault   bool     `json:"is_default"`
+}
@@ -0,0 +1,143 @@
+package address
+
+type AddressService interface {
+	FindAddressesByUser(userID int) ([]Address, error)
+	FindAddressByID(ID int) (Address, error)
+	FindDefaultAddress(userID int) (Address, error)
+	CreateAddress(address AddressCreateRequest) (Address, error)
+	UpdateAddress(ID int, address AddressUpdateRequest) (Address, error)
+	DeleteAddress(ID int) (Address, error)
+}
+
+type service struct {
+	addressRepository AddressRepository
+}
+
+func NewService(addressRepository AddressRepository) *service {
+	return &service{addressRepository}
+}
+
+func (s *service) FindAddressesByUser(userID int) ([]Address, error) {
+	return s.addressRepository.FindAddressesByUser(userID)
+}
+
+func (s *service) FindAddressByID(ID int) (Address, error) {
+	return s.addressRepository.FindAddressByID(ID)
+}
+
+func (s *service) FindDefaultAddress(userID int) (Address, error) {
+	return s.addressRepository.FindDefaultAddress(userID)
+}
+
+func (s *service) CreateAddress(addressRequest AddressCreateRequest) (Address, error) {
+	addressData := Address{
+		UserID:      addressRequest.UserID,
+		Label:       addressRequest.Label,
+		Recipient:   addressRequest.Recipient,
+		Phone:       addressRequest.Phone,
+		Province:    addressRequest.Province,
+		City:        addressRequest.City,
+		CityID:      addressRequest.CityID,
+		District:    addressRequest.District,
+		SubDistrict: addressRequest.SubDistrict,
+		PostalCode:  addressRequest.PostalCode,
+		Details:     addressRequest.Details,
+		Latitude:    addressRequest.Latitude,
+		Longitude:   addressRequest.Longitude,
+		IsDefault:   addressRequest.IsDefault,
+	}
+
+	if _, err := s.addressRepository.FindDefaultAddress(addressData.UserID); err != nil {
+		addressData.IsDefault = true
+	}
+
+	address, err := s.addressRepository.CreateAddress(addressData)
+
+	if err != nil || !address.IsDefault {
+		return address, err
+	}
+
+	return address, s.addressRepository.ClearDefaultAddress(address.UserID, address.ID)
+}
+
+func (s *service) UpdateAddress(ID int, addressRequest AddressUpdateRequest) (Address, error) {
+	address, err := s.addressRepository.FindAddressByID(ID)
+
+	if err != nil {
+		return Address{}, err
+	}
+
+	if addressRequest.Label != "" {
+		address.Label = addressRequest.Label
+	}
+	if addressRequest.Recipient != "" {
+		address.Recipient = addressRequest.Recipient
+	}
+	if addressRequest.Phone != "" {
+		address.Phone = addressRequest.Phone
+	}
+	if addressRequest.Province != "" {
+		address.Province = addressRequest.Province
+	}
+	if addressRequest.City != "" {
+		address.City = addressRequest.City
+	}
+	if addressRequest.CityID != "" {
+		address.CityID = addressRequest.CityID
+	}
+	if addressRequest.District != "" {
+		address.District = addressRequest.District
+	}
+	if addressRequest.SubDistrict != "" {
+		address.SubDistrict = addressRequest.SubDistrict
+	}
+	if addressRequest.PostalCode != "" {
+		address.PostalCode = addressRequest.PostalCode
+	}
+	if addressRequest.Details != "" {
+		address.Details = addressRequest.Details
+	}
+	if addressRequest.Latitude != nil {
+		address.Latitude = addressRequest.Latitude
+	}
+	if addressRequest.Longitude != nil {
+		address.Longitude = addressRequest.Longitude
+	}
+	if addressRequest.IsDefault != nil {
+		address.IsDefault = *addressRequest.IsDefault
+	}
+
+	address, err = s.addressRepository.UpdateAddress(address)
+
+	if err != nil || !address.IsDefault {
+		return address, err
+	}
+
+	return address, s.addressRepository.ClearDefaultAddress(address.UserID, address.ID)
+}
+
+func (s *service) DeleteAddress(ID int) (Address, error) {
+	address, err := s.addressRepository.FindAddressByID(ID)
+
+	if err != nil {
+		return Address{}, err
+	}
+
+	address, err = s.addressRepository.DeleteAddress(address)
+
+	if err != nil || !address.IsDefault {
+		return address, err
+	}
+
+	next, err := s.addressRepository.FindDefaultAddress(address.UserID)
+
+	if err != nil {
+		return address, nil
+	}
+
+	next.IsDefault = true
+
+	_, err = s.addressRepository.UpdateAddress(next)
+
+	return address, err
+}
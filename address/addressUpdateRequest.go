@@ -0,0 +1,17 @@
+package address
+
+type AddressUpdateRequest struct {
+	Label       string   `json:"label,omitempty"`
+	Recipient   string   `json:"recipient,omitempty"`
+	Phone       string   `json:"phone,omitempty"`
+	Province    string   `json:"province,omitempty"`
+	City        string   `json:"city,omitempty"`
+	CityID      string   `json:"city_id,omitempty"`
+	District    string   `json:"district,omitempty"`
+	SubDistrict string   `json:"sub_district,omitempty"`
+	PostalCode  string   `json:"postal_code,omitempty" binding:"omitempty,numeric,len=5"`
+	Details     string   `json:"details,omitempty"`
+	Latitude    *float64 `json:"latitude,omitempty" binding:"omitempty,latitude"`
+	Longitude   *float64 `json:"longitude,omitempty" binding:"omitempty,longitude"`
+	IsDefault   *bool    `json:"is_default,omitempty"`
+}
@@ -276,10 +276,11 @@ func (ch *controller) DeleteBank(c *gin.Context) {
 
 func convertToBankResponse(bank Bank) BankResponse {
 	return BankResponse{
-		ID:     bank.ID,
-		UserID: bank.UserID,
-		Type:   bank.Type,
-		Name:   bank.Name,
-		Number: bank.Number,
+		ID:        bank.ID,
+		UserID:    bank.UserID,
+		Type:      bank.Type,
+		Name:      bank.Name,
+		Number:    bank.Number,
+		IsDefault: bank.IsDefault,
 	}
 }
@@ -8,6 +8,7 @@ type Bank struct {
 	Type      string     `gorm:"column:type;type:varchar(255)"`
 	Name      string     `gorm:"column:name;type:varchar(255)"`
 	Number    string     `gorm:"column:number;type:varchar(255)"`
+	IsDefault bool       `gorm:"column:is_default"`
 	CreatedAt *time.Time `gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt *time.Time `gorm:"column:updated_at;autoUpdateTime"`
 }
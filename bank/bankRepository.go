@@ -11,6 +11,8 @@ type BankRepository interface {
 	FindAdminBanks() ([]Bank, error)
 	FindBanksByUser(userID int) ([]Bank, error)
 	FindBankByID(ID int) (Bank, error)
+	FindDefaultBank(userID int) (Bank, error)
+	ClearDefaultBank(userID int, exceptID uint64) error
 	CreateBank(bank Bank) (Bank, error)
 	UpdateBank(bank Bank) (Bank, error)
 	DeleteBank(bank Bank) (Bank, error)
@@ -58,6 +60,19 @@ func (r *repository) FindBankByID(ID int) (Bank, error) {
 	return bank, err
 }
 
+func (r *repository) FindDefaultBank(userID int) (Bank, error) {
+	var bank Bank
+	err := r.db.Where("user_id = ?", userID).Order("is_default DESC, id").First(&bank).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Bank{}, errors.New("Bank not found")
+	}
+	return bank, err
+}
+
+func (r *repository) ClearDefaultBank(userID int, exceptID uint64) error {
+	return r.db.Model(&Bank{}).Where("user_id = ? AND id <> ?", userID, exceptID).Update("is_default", false).Error
+}
+
 func (r *repository) CreateBank(bank Bank) (Bank, error) {
 	err := r.db.Create(&bank).Error
 	return bank, err
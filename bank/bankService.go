@@ -11,6 +11,7 @@ type BankService interface {
 	FindAdminBanks() ([]Bank, error)
 	FindBanksByUser(userID int) ([]Bank, error)
 	FindBankByID(ID int) (Bank, error)
+	FindDefaultBank(userID int) (Bank, error)
 	CreateBank(bank BankCreateRequest) (Bank, error)
 	UpdateBank(ID int, bank BankUpdateRequest) (Bank, error)
 	DeleteBank(ID int) (Bank, error)
@@ -40,17 +41,26 @@ func (s *service) FindBankByID(ID int) (Bank, error) {
 	return s.bankRepository.FindBankByID(ID)
 }
 
+func (s *service) FindDefaultBank(userID int) (Bank, error) {
+	return s.bankRepository.FindDefaultBank(userID)
+}
+
 func (s *service) CreateBank(bankRequest BankCreateRequest) (Bank, error) {
 	bankData := Bank{
-		UserID: bankRequest.UserID,
-		Type:   bankRequest.Type,
-		Name:   bankRequest.Name,
-		Number: bankRequest.Number,
+		UserID:    bankRequest.UserID,
+		Type:      bankRequest.Type,
+		Name:      bankRequest.Name,
+		Number:    bankRequest.Number,
+		IsDefault: bankRequest.IsDefault,
 	}
 
 	bank, err := s.bankRepository.CreateBank(bankData)
 
-	return bank, err
+	if err != nil || !bank.IsDefault {
+		return bank, err
+	}
+
+	return bank, s.bankRepository.ClearDefaultBank(bank.UserID, bank.ID)
 }
 
 func (s *service) UpdateBank(ID int, bankRequest BankUpdateRequest) (Bank, error) {
@@ -73,8 +83,17 @@ func (s *service) UpdateBank(ID int, bankRequest BankUpdateRequest) (Bank, error
 	if bankRequest.Number != "" {
 		bank.Number = bankRequest.Number
 	}
+	if bankRequest.IsDefault != nil {
+		bank.IsDefault = *bankRequest.IsDefault
+	}
+
+	bank, err = s.bankRepository.UpdateBank(bank)
+
+	if err != nil || !bank.IsDefault {
+		return bank, err
+	}
 
-	return s.bankRepository.UpdateBank(bank)
+	return bank, s.bankRepository.ClearDefaultBank(bank.UserID, bank.ID)
 }
 
 func (s *service) DeleteBank(ID int) (Bank, error) {
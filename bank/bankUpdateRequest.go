@@ -1,7 +1,8 @@
 package bank
 
 type BankUpdateRequest struct {
-	Type   string `json:"type,omitempty"`
-	Name   string `json:"name,omitempty"`
-	Number string `json:"number,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Number    string `json:"number,omitempty"`
+	IsDefault *bool  `json:"is_default,omitempty"`
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -306,6 +307,9 @@ func (cn *controller) UpdateCart(c *gin.Context) {
 		if err.Error() == "Cart not found" {
 			statusCode = http.StatusNotFound
 		}
+		if strings.HasPrefix(err.Error(), "Cannot") {
+			statusCode = http.StatusConflict
+		}
 		c.JSON(statusCode, gin.H{
 			"error": true,
 			"data":  nil,
@@ -341,6 +345,9 @@ func (ch *controller) DeleteCart(c *gin.Context) {
 		if err.Error() == "Cart not found" {
 			statusCode = http.StatusNotFound
 		}
+		if strings.HasPrefix(err.Error(), "Cannot") {
+			statusCode = http.StatusConflict
+		}
 		c.JSON(statusCode, gin.H{
 			"error": true,
 			"data":  nil,
@@ -361,9 +368,14 @@ func convertToCartResponse(cart Cart) CartResponse {
 		ID:         cart.ID,
 		UserID:     cart.UserID,
 		ProductID:  cart.ProductID,
+		VariantID:  cart.VariantID,
+		OptionIDs:  cart.OptionIDList(),
+		Label:      cart.Label,
+		UnitPrice:  cart.UnitPrice,
 		PaymentID:  cart.PaymentID,
 		Quantity:   cart.Quantity,
 		TotalPrice: cart.TotalPrice,
 		IsActived:  cart.IsActived,
+		ShipmentID: cart.ShipmentID,
 	}
 }
@@ -3,10 +3,11 @@ package cart
 import "encoding/json"
 
 type CartCreateRequest struct {
-	UserID     int         `json:"user_id" binding:"required,number"`
-	ProductID  json.Number `json:"product_id" binding:"required,number"`
-	PaymentID  json.Number `json:"payment_id"`
-	Quantity   json.Number `json:"quantity" binding:"required,number"`
-	TotalPrice json.Number `json:"total_price" binding:"required,number"`
-	IsActived  string      `json:"isActived" binding:"required"`
+	UserID    int         `json:"user_id" binding:"required,number"`
+	ProductID json.Number `json:"product_id" binding:"required,number"`
+	VariantID uint64      `json:"variant_id"`
+	OptionIDs []uint64    `json:"option_ids"`
+	PaymentID json.Number `json:"payment_id"`
+	Quantity  json.Number `json:"quantity" binding:"required,number"`
+	IsActived string      `json:"isActived" binding:"omitempty,eq=true"`
 }
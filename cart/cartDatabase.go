@@ -2,17 +2,56 @@ package cart
 
 import (
 	"encoding/json"
+	"strconv"
+	"strings"
 	"time"
 )
 
+const (
+	Active   = "true"
+	Inactive = "false"
+	Archived = "archived"
+)
+
 type Cart struct {
 	ID         uint64      `gorm:"column:id;primaryKey;autoIncrement"`
 	UserID     int         `gorm:"column:user_id;type:varchar(255)"`
 	ProductID  json.Number `gorm:"column:product_id;type:varchar(255)"`
+	VariantID  uint64      `gorm:"column:variant_id;index"`
+	OptionIDs  string      `gorm:"column:option_ids;type:varchar(255)"`
+	Label      string      `gorm:"column:label;type:varchar(255)"`
+	UnitPrice  int         `gorm:"column:unit_price"`
 	PaymentID  json.Number `gorm:"column:payment_id;type:varchar(255)"`
 	Quantity   json.Number `gorm:"column:quantity;type:varchar(255)"`
 	TotalPrice json.Number `gorm:"column:total_price;type:varchar(255)"`
 	IsActived  string      `gorm:"column:isActived;type:varchar(255)"`
+	ShipmentID uint64      `gorm:"column:shipment_id;index"`
 	CreatedAt  time.Time   `gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt  time.Time   `gorm:"column:updated_at;autoUpdateTime"`
 }
+
+func (c Cart) IsOrdered() bool {
+	return (c.PaymentID != "" && c.PaymentID != "0") || c.ShipmentID != 0
+}
+
+func (c Cart) OptionIDList() []uint64 {
+	IDs := []uint64{}
+
+	for _, part := range strings.Split(c.OptionIDs, ",") {
+		if ID, err := strconv.ParseUint(part, 10, 64); err == nil {
+			IDs = append(IDs, ID)
+		}
+	}
+
+	return IDs
+}
+
+func JoinOptionIDs(IDs []uint64) string {
+	parts := []string{}
+
+	for _, ID := range IDs {
+		parts = append(parts, strconv.FormatUint(ID, 10))
+	}
+
+	return strings.Join(parts, ",")
+}
@@ -4,15 +4,18 @@ import (
 	"errors"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CartRepository interface {
 	FindAll() ([]Cart, error)
 	FindCartByID(ID int) (Cart, error)
 	FindCartsByPaymentID(paymentID int) ([]Cart, error)
+	FindCartsByShipmentID(shipmentID uint64) ([]Cart, error)
 	FindCartsByProductID(productID int) ([]Cart, error)
 	FindStatusCardByUser(userID int, isActived string) ([]Cart, error)
 	SumTotalPriceByUser(userID int, isActived string) (int, error)
+	LockActiveCartsByUser(userID int) ([]Cart, error)
 	CreateCart(cart Cart) (Cart, error)
 	UpdateCart(cart Cart) (Cart, error)
 	DeleteCart(cart Cart) (Cart, error)
@@ -46,6 +49,12 @@ func (r *repository) FindCartsByPaymentID(paymentID int) ([]Cart, error) {
 	return carts, err
 }
 
+func (r *repository) FindCartsByShipmentID(shipmentID uint64) ([]Cart, error) {
+	var carts []Cart
+	err := r.db.Where("shipment_id = ?", shipmentID).Find(&carts).Error
+	return carts, err
+}
+
 func (r *repository) FindCartsByProductID(productID int) ([]Cart, error) {
 	var carts []Cart
 	err := r.db.Where("product_id = ?", productID).Find(&carts).Error
@@ -76,6 +85,14 @@ func (r *repository) SumTotalPriceByUser(userID int, isActived string) (int, err
 	return total, nil
 }
 
+func (r *repository) LockActiveCartsByUser(userID int) ([]Cart, error) {
+	var carts []Cart
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND isActived = ? AND (payment_id IS NULL OR payment_id = '' OR payment_id = '0')", userID, Active).
+		Find(&carts).Error
+	return carts, err
+}
+
 func NewRepository(db *gorm.DB) *repository {
 	return &repository{db}
 }
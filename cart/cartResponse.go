@@ -6,8 +6,13 @@ type CartResponse struct {
 	ID         uint64      `json:"id"`
 	UserID     int         `json:"user_id"`
 	ProductID  json.Number `json:"product_id"`
+	VariantID  uint64      `json:"variant_id"`
+	OptionIDs  []uint64    `json:"option_ids"`
+	Label      string      `json:"label"`
+	UnitPrice  int         `json:"unit_price"`
 	PaymentID  json.Number `json:"payment_id"`
 	Quantity   json.Number `json:"quantity"`
 	TotalPrice json.Number `json:"total_price"`
 	IsActived  string      `json:"isActived"`
+	ShipmentID uint64      `json:"shipment_id"`
 }
@@ -1,7 +1,10 @@
 package cart
 
 import (
+	"encoding/json"
 	"errors"
+	"strconv"
+	"taman-pempek/product"
 
 	"gorm.io/gorm"
 )
@@ -20,10 +23,11 @@ type CartService interface {
 
 type service struct {
 	cartRepository CartRepository
+	productService product.ProductService
 }
 
-func NewService(cartRepository CartRepository) *service {
-	return &service{cartRepository}
+func NewService(cartRepository CartRepository, productService product.ProductService) *service {
+	return &service{cartRepository, productService}
 }
 
 func (s *service) FindAll() ([]Cart, error) {
@@ -51,13 +55,21 @@ func (s *service) SumTotalPriceByUser(userID int, isActived string) (int, error)
 }
 
 func (s *service) CreateCart(cartRequest CartCreateRequest) (Cart, error) {
+	if cartRequest.PaymentID != "" {
+		return Cart{}, errors.New("Cannot attach a cart to a payment outside checkout")
+	}
+
 	cartData := Cart{
-		UserID:     cartRequest.UserID,
-		ProductID:  cartRequest.ProductID,
-		PaymentID:  cartRequest.PaymentID,
-		Quantity:   cartRequest.Quantity,
-		TotalPrice: cartRequest.TotalPrice,
-		IsActived:  cartRequest.IsActived,
+		UserID:    cartRequest.UserID,
+		ProductID: cartRequest.ProductID,
+		VariantID: cartRequest.VariantID,
+		OptionIDs: JoinOptionIDs(cartRequest.OptionIDs),
+		Quantity:  cartRequest.Quantity,
+		IsActived: Active,
+	}
+
+	if err := s.price(&cartData); err != nil {
+		return Cart{}, err
 	}
 
 	cart, err := s.cartRepository.CreateCart(cartData)
@@ -76,20 +88,38 @@ func (s *service) UpdateCart(ID int, cartRequest CartUpdateRequest) (Cart, error
 		return Cart{}, err
 	}
 
-	if cartRequest.ProductID != "" {
-		cart.ProductID = cartRequest.ProductID
+	if cart.IsOrdered() {
+		return Cart{}, errors.New("Cannot change a cart that has been ordered")
 	}
+
 	if cartRequest.PaymentID != "" {
-		cart.PaymentID = cartRequest.PaymentID
+		return Cart{}, errors.New("Cannot attach a cart to a payment outside checkout")
+	}
+
+	repriced := cartRequest.ProductID != "" || cartRequest.VariantID != 0 || cartRequest.OptionIDs != nil || cartRequest.Quantity != ""
+
+	if cartRequest.ProductID != "" && cartRequest.ProductID != cart.ProductID {
+		cart.ProductID = cartRequest.ProductID
+		cart.VariantID = 0
+		cart.OptionIDs = ""
+	}
+	if cartRequest.VariantID != 0 {
+		cart.VariantID = cartRequest.VariantID
+	}
+	if cartRequest.OptionIDs != nil {
+		cart.OptionIDs = JoinOptionIDs(cartRequest.OptionIDs)
 	}
 	if cartRequest.Quantity != "" {
 		cart.Quantity = cartRequest.Quantity
 	}
-	if cartRequest.TotalPrice != "" {
-		cart.TotalPrice = cartRequest.TotalPrice
+	if cartRequest.IsActived == Active {
+		cart.IsActived = Active
 	}
-	if cartRequest.IsActived != "" {
-		cart.IsActived = cartRequest.IsActived
+
+	if repriced {
+		if err := s.price(&cart); err != nil {
+			return Cart{}, err
+		}
 	}
 
 	return s.cartRepository.UpdateCart(cart)
@@ -106,5 +136,35 @@ func (s *service) DeleteCart(ID int) (Cart, error) {
 		return Cart{}, err
 	}
 
+	if cart.IsOrdered() {
+		return Cart{}, errors.New("Cannot delete a cart that has been ordered")
+	}
+
 	return s.cartRepository.DeleteCart(cart)
 }
+
+func (s *service) price(cart *Cart) error {
+	productID, err := strconv.Atoi(cart.ProductID.String())
+
+	if err != nil {
+		return errors.New("Invalid product ID")
+	}
+
+	quantity, err := strconv.Atoi(cart.Quantity.String())
+
+	if err != nil || quantity <= 0 {
+		return errors.New("Quantity must be at least 1")
+	}
+
+	pricing, err := s.productService.PriceItem(productID, cart.VariantID, cart.OptionIDList())
+
+	if err != nil {
+		return err
+	}
+
+	cart.Label = pricing.Label()
+	cart.UnitPrice = pricing.UnitPrice
+	cart.TotalPrice = json.Number(strconv.Itoa(pricing.UnitPrice * quantity))
+
+	return nil
+}
@@ -3,9 +3,10 @@ package cart
 import "encoding/json"
 
 type CartUpdateRequest struct {
-	ProductID  json.Number `json:"product_id,omitempty"`
-	PaymentID  json.Number `json:"payment_id,omitempty"`
-	Quantity   json.Number `json:"quantity,omitempty"`
-	TotalPrice json.Number `json:"total_price,omitempty"`
-	IsActived  string      `json:"isActived,omitempty"`
+	ProductID json.Number `json:"product_id,omitempty"`
+	VariantID uint64      `json:"variant_id,omitempty"`
+	OptionIDs []uint64    `json:"option_ids,omitempty"`
+	PaymentID json.Number `json:"payment_id,omitempty"`
+	Quantity  json.Number `json:"quantity,omitempty"`
+	IsActived string      `json:"isActived,omitempty" binding:"omitempty,eq=true"`
 }
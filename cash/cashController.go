@@ -0,0 +1,297 @@
+package cash
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"taman-pempek/payment"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type controller struct {
+	cashService CashService
+}
+
+func NewController(cashService CashService) *controller {
+	return &controller{cashService}
+}
+
+func (cn *controller) CollectCash(c *gin.Context) {
+	var collectRequest CashCollectRequest
+
+	err := c.ShouldBindJSON(&collectRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+				errorMessages = append(errorMessages, errorMessage)
+			}
+		} else {
+			errorMessages = append(errorMessages, "Invalid request body")
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid payment ID",
+		})
+		return
+	}
+
+	collection, err := cn.cashService.CollectCash(id, collectRequest, actorFromContext(c))
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		if strings.HasPrefix(err.Error(), "You are not allowed") {
+			statusCode = http.StatusForbidden
+		}
+		if strings.HasPrefix(err.Error(), "Cannot") || strings.HasSuffix(err.Error(), "changed by another request") {
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToCollectionResponse(collection),
+	})
+}
+
+func (cn *controller) GetPaymentCash(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid payment ID",
+		})
+		return
+	}
+
+	collection, err := cn.cashService.FindCollectionByPayment(id)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "Cash collection not found" {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToCollectionResponse(collection),
+	})
+}
+
+func (cn *controller) GetCourierCash(c *gin.Context) {
+	deliveryIDString := c.Param("deliveryId")
+	deliveryID, err := strconv.Atoi(deliveryIDString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid delivery ID",
+		})
+		return
+	}
+
+	summary, err := cn.cashService.FindDailySummary(deliveryID, c.DefaultQuery("date", time.Now().Format(dateLayout)))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToDailySummaryResponse(summary),
+	})
+}
+
+func (cn *controller) GetDailyCash(c *gin.Context) {
+	summaries, err := cn.cashService.FindDailySummaries(c.DefaultQuery("date", time.Now().Format(dateLayout)))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	summariesResponse := []DailySummaryResponse{}
+
+	for _, summary := range summaries {
+		summariesResponse = append(summariesResponse, convertToDailySummaryResponse(summary))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  summariesResponse,
+	})
+}
+
+func (cn *controller) ReconcileCourierCash(c *gin.Context) {
+	var reconcileRequest CashReconcileRequest
+
+	err := c.ShouldBindJSON(&reconcileRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+				errorMessages = append(errorMessages, errorMessage)
+			}
+		} else {
+			errorMessages = append(errorMessages, "Invalid request body")
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	deliveryIDString := c.Param("deliveryId")
+	deliveryID, err := strconv.Atoi(deliveryIDString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid delivery ID",
+		})
+		return
+	}
+
+	reconciliation, err := cn.cashService.Reconcile(deliveryID, reconcileRequest, actorFromContext(c))
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		if strings.HasPrefix(err.Error(), "No unreconciled") {
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToReconciliationResponse(reconciliation),
+	})
+}
+
+func actorFromContext(c *gin.Context) payment.Actor {
+	return payment.Actor{
+		ID:   c.GetUint64("UserID"),
+		Role: c.GetString("UserRole"),
+	}
+}
+
+func convertToCollectionResponse(collection Collection) CollectionResponse {
+	return CollectionResponse{
+		ID:               collection.ID,
+		PaymentID:        collection.PaymentID,
+		Method:           collection.Method,
+		DeliveryID:       collection.DeliveryID,
+		CollectorID:      collection.CollectorID,
+		CollectorRole:    collection.CollectorRole,
+		Amount:           collection.Amount,
+		Note:             collection.Note,
+		ReconciliationID: collection.ReconciliationID,
+		CollectedAt:      collection.CollectedAt,
+	}
+}
+
+func convertToReconciliationResponse(reconciliation Reconciliation) ReconciliationResponse {
+	return ReconciliationResponse{
+		ID:              reconciliation.ID,
+		DeliveryID:      reconciliation.DeliveryID,
+		Date:            reconciliation.Date,
+		Collections:     reconciliation.Collections,
+		ExpectedAmount:  reconciliation.ExpectedAmount,
+		DepositedAmount: reconciliation.DepositedAmount,
+		Difference:      reconciliation.Difference,
+		Status:          reconciliation.Status,
+		ReconciledBy:    reconciliation.ReconciledBy,
+		Note:            reconciliation.Note,
+		CreatedAt:       reconciliation.CreatedAt,
+	}
+}
+
+func convertToDailySummaryResponse(summary DailySummary) DailySummaryResponse {
+	collectionsResponse := []CollectionResponse{}
+
+	for _, collection := range summary.Collections {
+		collectionsResponse = append(collectionsResponse, convertToCollectionResponse(collection))
+	}
+
+	reconciliationsResponse := []ReconciliationResponse{}
+
+	for _, reconciliation := range summary.Reconciliations {
+		reconciliationsResponse = append(reconciliationsResponse, convertToReconciliationResponse(reconciliation))
+	}
+
+	return DailySummaryResponse{
+		DeliveryID:      summary.DeliveryID,
+		Date:            summary.Date,
+		Count:           len(summary.Collections),
+		Total:           summary.Total,
+		Unreconciled:    summary.Unreconciled,
+		Collections:     collectionsResponse,
+		Reconciliations: reconciliationsResponse,
+	}
+}
@@ -0,0 +1,48 @@
+package cash
+
+import "time"
+
+const (
+	ReconciliationBalanced = "balanced"
+	ReconciliationShort    = "short"
+	ReconciliationOver     = "over"
+)
+
+type Collection struct {
+	ID               uint64    `gorm:"column:id;primaryKey;autoIncrement"`
+	PaymentID        uint64    `gorm:"column:payment_id;uniqueIndex"`
+	Method           string    `gorm:"column:method;type:varchar(255)"`
+	DeliveryID       int       `gorm:"column:delivery_id;index"`
+	CollectorID      uint64    `gorm:"column:collector_id;index"`
+	CollectorRole    string    `gorm:"column:collector_role;type:varchar(255)"`
+	Amount           int       `gorm:"column:amount"`
+	Note             string    `gorm:"column:note;type:varchar(255)"`
+	CollectedOn      string    `gorm:"column:collected_on;type:varchar(10);index"`
+	ReconciliationID *uint64   `gorm:"column:reconciliation_id;index"`
+	CollectedAt      time.Time `gorm:"column:collected_at"`
+	CreatedAt        time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt        time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (Collection) TableName() string {
+	return "cash_collections"
+}
+
+type Reconciliation struct {
+	ID              uint64    `gorm:"column:id;primaryKey;autoIncrement"`
+	DeliveryID      int       `gorm:"column:delivery_id;index"`
+	Date            string    `gorm:"column:date;type:varchar(10);index"`
+	Collections     int       `gorm:"column:collections"`
+	ExpectedAmount  int       `gorm:"column:expected_amount"`
+	DepositedAmount int       `gorm:"column:deposited_amount"`
+	Difference      int       `gorm:"column:difference"`
+	Status          string    `gorm:"column:status;type:varchar(255)"`
+	ReconciledBy    uint64    `gorm:"column:reconciled_by"`
+	Note            string    `gorm:"column:note;type:varchar(255)"`
+	CreatedAt       time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt       time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (Reconciliation) TableName() string {
+	return "cash_reconciliations"
+}
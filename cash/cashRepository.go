@@ -0,0 +1,98 @@
+package cash
+
+import (
+	"errors"
+	"taman-pempek/payment"
+	"taman-pempek/shipment"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repositories struct {
+	Cash     CashRepository
+	Payment  payment.PaymentRepository
+	Shipment shipment.ShipmentRepository
+}
+
+type CashRepository interface {
+	Transaction(fn func(repositories Repositories) error) error
+	FindCollectionByPayment(paymentID uint64) (Collection, error)
+	FindCollectionsByDelivery(deliveryID int, date string) ([]Collection, error)
+	FindCollectionsByDate(method string, date string) ([]Collection, error)
+	LockUnreconciledCollections(deliveryID int, date string) ([]Collection, error)
+	CreateCollection(collection Collection) (Collection, error)
+	MarkCollectionsReconciled(IDs []uint64, reconciliationID uint64) error
+	FindReconciliationsByDelivery(deliveryID int, date string) ([]Reconciliation, error)
+	CreateReconciliation(reconciliation Reconciliation) (Reconciliation, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) Transaction(fn func(repositories Repositories) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(Repositories{
+			Cash:     NewRepository(tx),
+			Payment:  payment.NewRepository(tx),
+			Shipment: shipment.NewRepository(tx),
+		})
+	})
+}
+
+func (r *repository) FindCollectionByPayment(paymentID uint64) (Collection, error) {
+	var collection Collection
+	err := r.db.Where("payment_id = ?", paymentID).First(&collection).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Collection{}, errors.New("Cash collection not found")
+	}
+	return collection, err
+}
+
+func (r *repository) FindCollectionsByDelivery(deliveryID int, date string) ([]Collection, error) {
+	var collections []Collection
+	err := r.db.Where("delivery_id = ? AND collected_on = ? AND method = ?", deliveryID, date, payment.MethodCOD).Order("id").Find(&collections).Error
+	return collections, err
+}
+
+func (r *repository) FindCollectionsByDate(method string, date string) ([]Collection, error) {
+	var collections []Collection
+	err := r.db.Where("method = ? AND collected_on = ?", method, date).Order("delivery_id, id").Find(&collections).Error
+	return collections, err
+}
+
+func (r *repository) LockUnreconciledCollections(deliveryID int, date string) ([]Collection, error) {
+	var collections []Collection
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("delivery_id = ? AND collected_on = ? AND method = ? AND reconciliation_id IS NULL", deliveryID, date, payment.MethodCOD).
+		Order("id").
+		Find(&collections).Error
+	return collections, err
+}
+
+func (r *repository) CreateCollection(collection Collection) (Collection, error) {
+	err := r.db.Create(&collection).Error
+	return collection, err
+}
+
+func (r *repository) MarkCollectionsReconciled(IDs []uint64, reconciliationID uint64) error {
+	return r.db.Model(&Collection{}).
+		Where("id IN ? AND reconciliation_id IS NULL", IDs).
+		Update("reconciliation_id", reconciliationID).Error
+}
+
+func (r *repository) FindReconciliationsByDelivery(deliveryID int, date string) ([]Reconciliation, error) {
+	var reconciliations []Reconciliation
+	err := r.db.Where("delivery_id = ? AND date = ?", deliveryID, date).Order("id").Find(&reconciliations).Error
+	return reconciliations, err
+}
+
+func (r *repository) CreateReconciliation(reconciliation Reconciliation) (Reconciliation, error) {
+	err := r.db.Create(&reconciliation).Error
+	return reconciliation, err
+}
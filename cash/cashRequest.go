@@ -0,0 +1,12 @@
+package cash
+
+type CashCollectRequest struct {
+	Amount int    `json:"amount" binding:"required,min=1"`
+	Note   string `json:"note"`
+}
+
+type CashReconcileRequest struct {
+	Date            string `json:"date" binding:"required"`
+	DepositedAmount int    `json:"deposited_amount" binding:"min=0"`
+	Note            string `json:"note"`
+}
@@ -0,0 +1,40 @@
+package cash
+
+import "time"
+
+type CollectionResponse struct {
+	ID               uint64    `json:"id"`
+	PaymentID        uint64    `json:"payment_id"`
+	Method           string    `json:"method"`
+	DeliveryID       int       `json:"delivery_id"`
+	CollectorID      uint64    `json:"collector_id"`
+	CollectorRole    string    `json:"collector_role"`
+	Amount           int       `json:"amount"`
+	Note             string    `json:"note"`
+	ReconciliationID *uint64   `json:"reconciliation_id"`
+	CollectedAt      time.Time `json:"collected_at"`
+}
+
+type ReconciliationResponse struct {
+	ID              uint64    `json:"id"`
+	DeliveryID      int       `json:"delivery_id"`
+	Date            string    `json:"date"`
+	Collections     int       `json:"collections"`
+	ExpectedAmount  int       `json:"expected_amount"`
+	DepositedAmount int       `json:"deposited_amount"`
+	Difference      int       `json:"difference"`
+	Status          string    `json:"status"`
+	ReconciledBy    uint64    `json:"reconciled_by"`
+	Note            string    `json:"note"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type DailySummaryResponse struct {
+	DeliveryID      int                      `json:"delivery_id"`
+	Date            string                   `json:"date"`
+	Count           int                      `json:"count"`
+	Total           int                      `json:"total"`
+	Unreconciled    int                      `json:"unreconciled"`
+	Collections     []CollectionResponse     `json:"collections"`
+	Reconciliations []ReconciliationResponse `json:"reconciliations"`
+}
@@ -0,0 +1,269 @@
+package cash
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"taman-pempek/delivery"
+	"taman-pempek/payment"
+	"taman-pempek/user"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+type DailySummary struct {
+	DeliveryID      int
+	Date            string
+	Collections     []Collection
+	Reconciliations []Reconciliation
+	Total           int
+	Unreconciled    int
+}
+
+type CashService interface {
+	CollectCash(paymentID int, request CashCollectRequest, actor payment.Actor) (Collection, error)
+	FindCollectionByPayment(paymentID int) (Collection, error)
+	FindDailySummary(deliveryID int, date string) (DailySummary, error)
+	FindDailySummaries(date string) ([]DailySummary, error)
+	Reconcile(deliveryID int, request CashReconcileRequest, actor payment.Actor) (Reconciliation, error)
+}
+
+type service struct {
+	cashRepository  CashRepository
+	deliveryService delivery.DeliveryService
+}
+
+func NewService(cashRepository CashRepository, deliveryService delivery.DeliveryService) *service {
+	return &service{cashRepository, deliveryService}
+}
+
+func (s *service) CollectCash(paymentID int, request CashCollectRequest, actor payment.Actor) (Collection, error) {
+	var collection Collection
+
+	err := s.cashRepository.Transaction(func(repositories Repositories) error {
+		p, err := repositories.Payment.LockPaymentByID(paymentID)
+
+		if err != nil {
+			return err
+		}
+
+		if !payment.IsCashMethod(p.PaymentMethod) {
+			return errors.New("Payment is not a cash payment")
+		}
+
+		if err := s.checkCollector(p, actor, repositories); err != nil {
+			return err
+		}
+
+		if request.Amount != p.TotalPrice {
+			return fmt.Errorf("Collected amount must be %d", p.TotalPrice)
+		}
+
+		if _, err := payment.NewService(repositories.Payment, nil).CollectCash(paymentID, actor, request.Note); err != nil {
+			return err
+		}
+
+		if err := repositories.Shipment.DeliverShipmentsByPayment(p.ID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+
+		collection, err = repositories.Cash.CreateCollection(Collection{
+			PaymentID:     p.ID,
+			Method:        p.PaymentMethod,
+			DeliveryID:    p.DeliveryID,
+			CollectorID:   actor.ID,
+			CollectorRole: actor.Role,
+			Amount:        request.Amount,
+			Note:          request.Note,
+			CollectedOn:   now.Format(dateLayout),
+			CollectedAt:   now,
+		})
+
+		return err
+	})
+
+	return collection, err
+}
+
+func (s *service) checkCollector(p payment.Payment, actor payment.Actor, repositories Repositories) error {
+	if strings.EqualFold(actor.Role, user.RoleAdmin) {
+		return nil
+	}
+
+	if p.PaymentMethod == payment.MethodCOD && actor.Role == user.RoleCourier {
+		courier, err := s.deliveryService.FindDeliveryByID(p.DeliveryID)
+
+		if err != nil {
+			return err
+		}
+
+		if courier.CourierID != 0 && uint64(courier.CourierID) == actor.ID {
+			return nil
+		}
+	}
+
+	if p.PaymentMethod == payment.MethodPickup && actor.Role == user.RoleSeller {
+		shipments, err := repositories.Shipment.FindShipmentsByPayment(int(p.ID))
+
+		if err != nil {
+			return err
+		}
+
+		owned := len(shipments) > 0
+
+		for _, shipment := range shipments {
+			if uint64(shipment.SellerID) != actor.ID {
+				owned = false
+			}
+		}
+
+		if owned {
+			return nil
+		}
+	}
+
+	return errors.New("You are not allowed to collect cash for this payment")
+}
+
+func (s *service) FindCollectionByPayment(paymentID int) (Collection, error) {
+	return s.cashRepository.FindCollectionByPayment(uint64(paymentID))
+}
+
+func (s *service) FindDailySummary(deliveryID int, date string) (DailySummary, error) {
+	if _, err := time.Parse(dateLayout, date); err != nil {
+		return DailySummary{}, errors.New("Invalid date, expected YYYY-MM-DD")
+	}
+
+	collections, err := s.cashRepository.FindCollectionsByDelivery(deliveryID, date)
+
+	if err != nil {
+		return DailySummary{}, err
+	}
+
+	reconciliations, err := s.cashRepository.FindReconciliationsByDelivery(deliveryID, date)
+
+	if err != nil {
+		return DailySummary{}, err
+	}
+
+	return summarize(deliveryID, date, collections, reconciliations), nil
+}
+
+func (s *service) FindDailySummaries(date string) ([]DailySummary, error) {
+	if _, err := time.Parse(dateLayout, date); err != nil {
+		return nil, errors.New("Invalid date, expected YYYY-MM-DD")
+	}
+
+	collections, err := s.cashRepository.FindCollectionsByDate(payment.MethodCOD, date)
+
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := []DailySummary{}
+	grouped := map[int][]Collection{}
+	order := []int{}
+
+	for _, collection := range collections {
+		if _, ok := grouped[collection.DeliveryID]; !ok {
+			order = append(order, collection.DeliveryID)
+		}
+		grouped[collection.DeliveryID] = append(grouped[collection.DeliveryID], collection)
+	}
+
+	for _, deliveryID := range order {
+		reconciliations, err := s.cashRepository.FindReconciliationsByDelivery(deliveryID, date)
+
+		if err != nil {
+			return nil, err
+		}
+
+		summaries = append(summaries, summarize(deliveryID, date, grouped[deliveryID], reconciliations))
+	}
+
+	return summaries, nil
+}
+
+func (s *service) Reconcile(deliveryID int, request CashReconcileRequest, actor payment.Actor) (Reconciliation, error) {
+	if _, err := time.Parse(dateLayout, request.Date); err != nil {
+		return Reconciliation{}, errors.New("Invalid date, expected YYYY-MM-DD")
+	}
+
+	if _, err := s.deliveryService.FindDeliveryByID(deliveryID); err != nil {
+		return Reconciliation{}, err
+	}
+
+	var reconciliation Reconciliation
+
+	err := s.cashRepository.Transaction(func(repositories Repositories) error {
+		collections, err := repositories.Cash.LockUnreconciledCollections(deliveryID, request.Date)
+
+		if err != nil {
+			return err
+		}
+
+		if len(collections) == 0 {
+			return fmt.Errorf("No unreconciled cash collections on %s", request.Date)
+		}
+
+		IDs := []uint64{}
+		expected := 0
+
+		for _, collection := range collections {
+			IDs = append(IDs, collection.ID)
+			expected += collection.Amount
+		}
+
+		status := ReconciliationBalanced
+		difference := request.DepositedAmount - expected
+
+		if difference < 0 {
+			status = ReconciliationShort
+		}
+		if difference > 0 {
+			status = ReconciliationOver
+		}
+
+		reconciliation, err = repositories.Cash.CreateReconciliation(Reconciliation{
+			DeliveryID:      deliveryID,
+			Date:            request.Date,
+			Collections:     len(collections),
+			ExpectedAmount:  expected,
+			DepositedAmount: request.DepositedAmount,
+			Difference:      difference,
+			Status:          status,
+			ReconciledBy:    actor.ID,
+			Note:            request.Note,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		return repositories.Cash.MarkCollectionsReconciled(IDs, reconciliation.ID)
+	})
+
+	return reconciliation, err
+}
+
+func summarize(deliveryID int, date string, collections []Collection, reconciliations []Reconciliation) DailySummary {
+	summary := DailySummary{
+		DeliveryID:      deliveryID,
+		Date:            date,
+		Collections:     collections,
+		Reconciliations: reconciliations,
+	}
+
+	for _, collection := range collections {
+		summary.Total += collection.Amount
+
+		if collection.ReconciliationID == nil {
+			summary.Unreconciled += collection.Amount
+		}
+	}
+
+	return summary
+}
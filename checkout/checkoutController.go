@@ -0,0 +1,156 @@
+package checkout
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"taman-pempek/payment"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type controller struct {
+	checkoutService CheckoutService
+}
+
+func NewController(checkoutService CheckoutService) *controller {
+	return &controller{checkoutService}
+}
+
+func (cn *controller) Checkout(c *gin.Context) {
+	var checkoutRequest CheckoutRequest
+
+	err := c.ShouldBindJSON(&checkoutRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		for _, e := range err.(validator.ValidationErrors) {
+			errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+			errorMessages = append(errorMessages, errorMessage)
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	order, err := cn.checkoutService.Checkout(int(c.GetUint64("UserID")), checkoutRequest)
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if strings.HasPrefix(err.Error(), "Insufficient stock") || strings.HasSuffix(err.Error(), "fully booked") {
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToCheckoutResponse(order),
+	})
+}
+
+func (cn *controller) ChangeAddress(c *gin.Context) {
+	var addressRequest ChangeAddressRequest
+
+	err := c.ShouldBindJSON(&addressRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		for _, e := range err.(validator.ValidationErrors) {
+			errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+			errorMessages = append(errorMessages, errorMessage)
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid payment ID",
+		})
+		return
+	}
+
+	changed, err := cn.checkoutService.ChangeAddress(int(c.GetUint64("UserID")), id, addressRequest)
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if err.Error() == "Payment not found" {
+			statusCode = http.StatusNotFound
+		}
+		if strings.HasPrefix(err.Error(), "Cannot") {
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToAddressResponse(changed),
+	})
+}
+
+func convertToCheckoutResponse(order Order) CheckoutResponse {
+	shipments := []CheckoutShipmentResponse{}
+
+	for _, s := range order.Shipments {
+		shipments = append(shipments, CheckoutShipmentResponse{
+			ID:       s.ID,
+			SellerID: s.SellerID,
+			Status:   s.Status,
+			Subtotal: s.Subtotal,
+		})
+	}
+
+	return CheckoutResponse{
+		PaymentID:       order.Payment.ID,
+		UserID:          order.Payment.UserID,
+		TotalPrice:      order.Payment.TotalPrice,
+		UniqueCode:      order.Payment.UniqueCode,
+		TransferAmount:  order.Payment.TransferAmount,
+		PaymentStatus:   order.Payment.PaymentStatus,
+		PaymentMethod:   order.Payment.PaymentMethod,
+		ScheduledDate:   order.Payment.ScheduledDate,
+		ScheduledWindow: order.Payment.ScheduledWindow,
+		ShippingFee:     order.Payment.ShippingFee,
+		Shipments:       shipments,
+		Items:           order.Items,
+	}
+}
+
+func convertToAddressResponse(p payment.Payment) AddressResponse {
+	return AddressResponse{
+		PaymentID:       p.ID,
+		AddressID:       p.AddressID,
+		Address:         p.Address,
+		Whatsapp:        p.Whatsapp,
+		ShippingFee:     p.ShippingFee,
+		ShippingCourier: p.ShippingCourier,
+		ShippingService: p.ShippingService,
+	}
+}
@@ -0,0 +1,52 @@
+package checkout
+
+import (
+	"taman-pempek/address"
+	"taman-pempek/cart"
+	"taman-pempek/payment"
+	"taman-pempek/product"
+	"taman-pempek/shipment"
+	"taman-pempek/slot"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Repositories struct {
+	Address  address.AddressRepository
+	Cart     cart.CartRepository
+	Product  product.ProductRepository
+	Payment  payment.PaymentRepository
+	Shipment shipment.ShipmentRepository
+	Slot     slot.SlotRepository
+}
+
+type CheckoutRepository interface {
+	Transaction(fn func(repositories Repositories) error) error
+	FindOverduePayments(before time.Time) ([]payment.Payment, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) Transaction(fn func(repositories Repositories) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(Repositories{
+			Address:  address.NewRepository(tx),
+			Cart:     cart.NewRepository(tx),
+			Product:  product.NewRepository(tx),
+			Payment:  payment.NewRepository(tx),
+			Shipment: shipment.NewRepository(tx),
+			Slot:     slot.NewRepository(tx),
+		})
+	})
+}
+
+func (r *repository) FindOverduePayments(before time.Time) ([]payment.Payment, error) {
+	return payment.NewRepository(r.db).FindPaymentsByStatusBefore(payment.StatusAwaitingPayment, before)
+}
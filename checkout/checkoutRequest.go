@@ -0,0 +1,18 @@
+package checkout
+
+type CheckoutRequest struct {
+	DeliveryID    int    `json:"delivery_id"`
+	DeliveryName  string `json:"delivery_name" binding:"required"`
+	AddressID     int    `json:"address_id"`
+	Address       string `json:"address" binding:"required_without=AddressID"`
+	Whatsapp      string `json:"whatsapp" binding:"required_without=AddressID"`
+	PaymentMethod string `json:"payment_method" binding:"omitempty,oneof=transfer cod pickup"`
+	ShippingToken string `json:"shipping_token"`
+	SlotID        int    `json:"slot_id"`
+	ScheduledDate string `json:"scheduled_date" binding:"required_with=SlotID"`
+}
+
+type ChangeAddressRequest struct {
+	AddressID     int    `json:"address_id" binding:"required"`
+	ShippingToken string `json:"shipping_token"`
+}
@@ -0,0 +1,47 @@
+package checkout
+
+type CheckoutItemResponse struct {
+	CartID      uint64 `json:"cart_id"`
+	ShipmentID  uint64 `json:"shipment_id"`
+	SellerID    int    `json:"seller_id"`
+	ProductID   int    `json:"product_id"`
+	VariantID   uint64 `json:"variant_id"`
+	Name        string `json:"name"`
+	Label       string `json:"label"`
+	Price       int    `json:"price"`
+	Quantity    int    `json:"quantity"`
+	TotalPrice  int    `json:"total_price"`
+	ShippingFee int    `json:"shipping_fee"`
+}
+
+type CheckoutResponse struct {
+	PaymentID       uint64                     `json:"payment_id"`
+	UserID          int                        `json:"user_id"`
+	TotalPrice      int                        `json:"total_price"`
+	ShippingFee     int                        `json:"shipping_fee"`
+	UniqueCode      int                        `json:"unique_code"`
+	TransferAmount  int                        `json:"transfer_amount"`
+	PaymentStatus   string                     `json:"payment_status"`
+	PaymentMethod   string                     `json:"payment_method"`
+	ScheduledDate   string                     `json:"scheduled_date"`
+	ScheduledWindow string                     `json:"scheduled_window"`
+	Shipments       []CheckoutShipmentResponse `json:"shipments"`
+	Items           []CheckoutItemResponse     `json:"items"`
+}
+
+type CheckoutShipmentResponse struct {
+	ID       uint64 `json:"id"`
+	SellerID int    `json:"seller_id"`
+	Status   string `json:"status"`
+	Subtotal int    `json:"subtotal"`
+}
+
+type AddressResponse struct {
+	PaymentID       uint64 `json:"payment_id"`
+	AddressID       uint64 `json:"address_id"`
+	Address         string `json:"address"`
+	Whatsapp        string `json:"whatsapp"`
+	ShippingFee     int    `json:"shipping_fee"`
+	ShippingCourier string `json:"shipping_courier"`
+	ShippingService string `json:"shipping_service"`
+}
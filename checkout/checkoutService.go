@@ -0,0 +1,492 @@
+package checkout
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"taman-pempek/cart"
+	"taman-pempek/payment"
+	"taman-pempek/product"
+	"taman-pempek/shipment"
+	"taman-pempek/shipping"
+	"taman-pempek/slot"
+	"taman-pempek/user"
+	"time"
+)
+
+type Order struct {
+	Payment   payment.Payment
+	Shipments []shipment.Shipment
+	Items     []CheckoutItemResponse
+}
+
+type CheckoutService interface {
+	Checkout(userID int, request CheckoutRequest) (Order, error)
+	ExpireOverdueOrders(deadline time.Duration) (int, error)
+	CancelOrder(p payment.Payment, history payment.PaymentStatusHistory) (bool, error)
+	ChangeAddress(userID int, paymentID int, request ChangeAddressRequest) (payment.Payment, error)
+}
+
+var addressChangeStatuses = []string{payment.StatusAwaitingPayment, payment.StatusProofUploaded, payment.StatusVerified, payment.StatusAwaitingCash}
+
+type service struct {
+	checkoutRepository CheckoutRepository
+}
+
+func NewService(checkoutRepository CheckoutRepository) *service {
+	return &service{checkoutRepository}
+}
+
+func (s *service) Checkout(userID int, request CheckoutRequest) (Order, error) {
+	var order Order
+
+	err := s.checkoutRepository.Transaction(func(repositories Repositories) error {
+		carts, err := repositories.Cart.LockActiveCartsByUser(userID)
+
+		if err != nil {
+			return err
+		}
+
+		if len(carts) == 0 {
+			return errors.New("Cart is empty")
+		}
+
+		method := request.PaymentMethod
+
+		if method == "" {
+			method = payment.MethodTransfer
+		}
+
+		if method == payment.MethodCOD && request.DeliveryID == 0 {
+			return errors.New("Cash on delivery requires a delivery courier")
+		}
+
+		items := []CheckoutItemResponse{}
+		totalPrice := 0
+		weight := 0
+
+		for i, c := range carts {
+			productID, err := strconv.Atoi(c.ProductID.String())
+
+			if err != nil {
+				return fmt.Errorf("Invalid product on cart %d", c.ID)
+			}
+
+			quantity, err := strconv.Atoi(c.Quantity.String())
+
+			if err != nil || quantity <= 0 {
+				return fmt.Errorf("Invalid quantity on cart %d", c.ID)
+			}
+
+			pricing, err := product.PriceItem(repositories.Product, productID, c.VariantID, c.OptionIDList())
+
+			if err != nil {
+				return err
+			}
+
+			decremented, err := product.ReserveStock(repositories.Product, productID, c.VariantID, quantity)
+
+			if err != nil {
+				return err
+			}
+
+			if !decremented {
+				return fmt.Errorf("Insufficient stock for %s", itemName(pricing))
+			}
+
+			lineTotal := pricing.UnitPrice * quantity
+			totalPrice += lineTotal
+			weight += pricing.Weight * quantity
+
+			c.Label = pricing.Label()
+			c.UnitPrice = pricing.UnitPrice
+			carts[i] = c
+
+			items = append(items, CheckoutItemResponse{
+				CartID:     c.ID,
+				SellerID:   pricing.Product.UserID,
+				ProductID:  productID,
+				VariantID:  c.VariantID,
+				Name:       pricing.Product.Name,
+				Label:      c.Label,
+				Price:      pricing.UnitPrice,
+				Quantity:   quantity,
+				TotalPrice: lineTotal,
+			})
+		}
+
+		paymentData := payment.Payment{
+			StockReserved: true,
+			UserID:        userID,
+			DeliveryID:    request.DeliveryID,
+			TotalPrice:    totalPrice,
+			Address:       request.Address,
+			Whatsapp:      request.Whatsapp,
+			PaymentStatus: payment.InitialStatus(method),
+			PaymentMethod: method,
+			DeliveryName:  request.DeliveryName,
+		}
+
+		if err := applyAddress(repositories, &paymentData, request.AddressID, request.ShippingToken, weight); err != nil {
+			return err
+		}
+
+		paymentData.TotalPrice += paymentData.ShippingFee
+
+		if request.SlotID != 0 {
+			deliverySlot, err := repositories.Slot.LockSlotByID(request.SlotID)
+
+			if err != nil {
+				return err
+			}
+
+			if err := slot.Check(deliverySlot, request.ScheduledDate, slot.KindFor(method), time.Now()); err != nil {
+				return err
+			}
+
+			booked, err := repositories.Slot.CountBookings(deliverySlot.ID, request.ScheduledDate)
+
+			if err != nil {
+				return err
+			}
+
+			if booked >= deliverySlot.Capacity {
+				return fmt.Errorf("Slot %s on %s is fully booked", deliverySlot.Name, request.ScheduledDate)
+			}
+
+			paymentData.SlotID = deliverySlot.ID
+			paymentData.ScheduledDate = request.ScheduledDate
+			paymentData.ScheduledWindow = deliverySlot.Window()
+		}
+
+		createPayment := repositories.Payment.CreatePayment
+
+		if method == payment.MethodTransfer {
+			createPayment = repositories.Payment.CreateTransferPayment
+		}
+
+		createdPayment, err := createPayment(paymentData)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = repositories.Payment.CreateStatusHistory(payment.PaymentStatusHistory{
+			PaymentID: createdPayment.ID,
+			ToStatus:  createdPayment.PaymentStatus,
+			ActorID:   uint64(userID),
+			ActorRole: user.RoleBuyer,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		shipments := []shipment.Shipment{}
+		shipmentIndex := map[int]int{}
+
+		for _, item := range items {
+			i, ok := shipmentIndex[item.SellerID]
+
+			if !ok {
+				i = len(shipments)
+				shipmentIndex[item.SellerID] = i
+				shipments = append(shipments, shipment.Shipment{
+					PaymentID:    createdPayment.ID,
+					SellerID:     item.SellerID,
+					BuyerID:      userID,
+					Status:       shipment.StatusPending,
+					DeliveryID:   request.DeliveryID,
+					DeliveryName: request.DeliveryName,
+				})
+			}
+
+			shipments[i].Subtotal += item.TotalPrice
+		}
+
+		for i := range shipments {
+			if shipments[i], err = repositories.Shipment.CreateShipment(shipments[i]); err != nil {
+				return err
+			}
+		}
+
+		for i, c := range carts {
+			items[i].ShipmentID = shipments[shipmentIndex[items[i].SellerID]].ID
+			c.ShipmentID = items[i].ShipmentID
+			c.PaymentID = json.Number(strconv.FormatUint(createdPayment.ID, 10))
+			c.TotalPrice = json.Number(strconv.Itoa(items[i].TotalPrice))
+			c.IsActived = cart.Inactive
+
+			if _, err := repositories.Cart.UpdateCart(c); err != nil {
+				return err
+			}
+		}
+
+		order = Order{Payment: createdPayment, Shipments: shipments, Items: items}
+
+		return nil
+	})
+
+	return order, err
+}
+
+func (s *service) ExpireOverdueOrders(deadline time.Duration) (int, error) {
+	payments, err := s.checkoutRepository.FindOverduePayments(time.Now().Add(-deadline))
+
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	errs := []error{}
+
+	for _, p := range payments {
+		ok, err := s.expireOrder(p)
+
+		if err != nil {
+			log.Printf("Failed to expire payment %d: %v", p.ID, err)
+			errs = append(errs, fmt.Errorf("payment %d: %w", p.ID, err))
+			continue
+		}
+
+		if ok {
+			expired++
+		}
+	}
+
+	return expired, errors.Join(errs...)
+}
+
+func (s *service) expireOrder(p payment.Payment) (bool, error) {
+	return s.CancelOrder(p, payment.PaymentStatusHistory{
+		PaymentID:  p.ID,
+		FromStatus: payment.StatusAwaitingPayment,
+		ToStatus:   payment.StatusExpired,
+		ActorRole:  payment.RoleSystem,
+		Note:       "Payment deadline has passed",
+	})
+}
+
+func (s *service) CancelOrder(p payment.Payment, history payment.PaymentStatusHistory) (bool, error) {
+	transitioned := false
+
+	err := s.checkoutRepository.Transaction(func(repositories Repositories) error {
+		ok, err := repositories.Payment.TransitionStatus(p.ID, history.FromStatus, history)
+
+		if err != nil || !ok {
+			return err
+		}
+
+		transitioned = true
+
+		return releaseOrder(repositories, p)
+	})
+
+	return transitioned, err
+}
+
+func releaseOrder(repositories Repositories, p payment.Payment) error {
+	if err := repositories.Shipment.CancelShipmentsByPayment(p.ID); err != nil {
+		return err
+	}
+
+	if !p.StockReserved {
+		return nil
+	}
+
+	carts, err := repositories.Cart.FindCartsByPaymentID(int(p.ID))
+
+	if err != nil {
+		return err
+	}
+
+	for _, c := range carts {
+		productID, err := strconv.Atoi(c.ProductID.String())
+
+		if err != nil {
+			continue
+		}
+
+		quantity, err := strconv.Atoi(c.Quantity.String())
+
+		if err != nil || quantity <= 0 {
+			continue
+		}
+
+		if err := product.RestoreStock(repositories.Product, productID, c.VariantID, quantity); err != nil {
+			return err
+		}
+
+		c.IsActived = cart.Archived
+
+		if _, err := repositories.Cart.UpdateCart(c); err != nil {
+			return err
+		}
+
+		_, err = repositories.Cart.CreateCart(cart.Cart{
+			UserID:     c.UserID,
+			ProductID:  c.ProductID,
+			VariantID:  c.VariantID,
+			OptionIDs:  c.OptionIDs,
+			Label:      c.Label,
+			UnitPrice:  c.UnitPrice,
+			Quantity:   c.Quantity,
+			TotalPrice: c.TotalPrice,
+			IsActived:  cart.Active,
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	p.StockReserved = false
+
+	_, err = repositories.Payment.UpdatePayment(p)
+
+	return err
+}
+
+func (s *service) ChangeAddress(userID int, paymentID int, request ChangeAddressRequest) (payment.Payment, error) {
+	var changed payment.Payment
+
+	err := s.checkoutRepository.Transaction(func(repositories Repositories) error {
+		p, err := repositories.Payment.LockPaymentByID(paymentID)
+
+		if err != nil || p.UserID != userID {
+			return errors.New("Payment not found")
+		}
+
+		if !canChangeAddress(p.PaymentStatus) {
+			return fmt.Errorf("Cannot change the address of a %s order", p.PaymentStatus)
+		}
+
+		carts, err := repositories.Cart.FindCartsByPaymentID(paymentID)
+
+		if err != nil {
+			return err
+		}
+
+		weight := 0
+
+		for _, c := range carts {
+			productID, err := strconv.Atoi(c.ProductID.String())
+
+			if err != nil {
+				return fmt.Errorf("Invalid product on cart %d", c.ID)
+			}
+
+			quantity, err := strconv.Atoi(c.Quantity.String())
+
+			if err != nil || quantity <= 0 {
+				return fmt.Errorf("Invalid quantity on cart %d", c.ID)
+			}
+
+			pricing, err := product.PriceItem(repositories.Product, productID, c.VariantID, c.OptionIDList())
+
+			if err != nil {
+				return err
+			}
+
+			weight += pricing.Weight * quantity
+		}
+
+		shippingFee, shippingCourier := p.ShippingFee, p.ShippingCourier
+
+		if err := applyAddress(repositories, &p, request.AddressID, request.ShippingToken, weight); err != nil {
+			return err
+		}
+
+		if p.ShippingFee != shippingFee || p.ShippingCourier != shippingCourier {
+			return errors.New("Cannot change to an address with a different courier or shipping fee, please cancel and check out again")
+		}
+
+		changed, err = repositories.Payment.UpdatePayment(p)
+
+		return err
+	})
+
+	return changed, err
+}
+
+func canChangeAddress(status string) bool {
+	for _, s := range addressChangeStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func applyAddress(repositories Repositories, p *payment.Payment, addressID int, shippingToken string, weight int) error {
+	destination := ""
+
+	if addressID != 0 {
+		addr, err := repositories.Address.FindAddressByID(addressID)
+
+		if err != nil {
+			return err
+		}
+
+		if addr.UserID != p.UserID {
+			return errors.New("Address not found")
+		}
+
+		destination = addr.Destination()
+		p.Address = addr.String()
+		p.Whatsapp = addr.Phone
+		p.AddressSnapshot = payment.AddressSnapshot{
+			AddressID:   addr.ID,
+			Recipient:   addr.Recipient,
+			Province:    addr.Province,
+			City:        addr.City,
+			District:    addr.District,
+			SubDistrict: addr.SubDistrict,
+			PostalCode:  addr.PostalCode,
+			Latitude:    addr.Latitude,
+			Longitude:   addr.Longitude,
+		}
+	}
+
+	if p.PaymentMethod != payment.MethodPickup && shippingToken == "" {
+		return errors.New("Shipping quote is required for delivery orders")
+	}
+
+	if shippingToken == "" {
+		return nil
+	}
+
+	if destination == "" {
+		return errors.New("Shipping quote requires a saved address")
+	}
+
+	quote, err := shipping.VerifyQuote(shippingToken)
+
+	if err != nil {
+		return err
+	}
+
+	if quote.UserID != p.UserID || quote.Weight != weight {
+		return errors.New("Cart has changed since the shipping quote, please request a new quote")
+	}
+
+	if !strings.EqualFold(quote.Destination, destination) {
+		return errors.New("Shipping quote was made for a different address, please request a new quote")
+	}
+
+	p.ShippingFee = quote.Fee
+	p.ShippingCourier = quote.Courier
+	p.ShippingService = quote.Service
+
+	return nil
+}
+
+func itemName(pricing product.Pricing) string {
+	if label := pricing.Label(); label != "" {
+		return pricing.Product.Name + " (" + label + ")"
+	}
+	return pricing.Product.Name
+}
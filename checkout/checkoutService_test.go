@@ -0,0 +1,486 @@
+package checkout
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"taman-pempek/address"
+	"taman-pempek/cart"
+	"taman-pempek/payment"
+	"taman-pempek/product"
+	"taman-pempek/shipment"
+	"taman-pempek/shipping"
+	"taman-pempek/slot"
+	"testing"
+	"time"
+)
+
+type store struct {
+	products  map[int]product.Product
+	addresses map[int]address.Address
+	slots     map[int]slot.Slot
+	carts     []cart.Cart
+	payments  []payment.Payment
+	history   []payment.PaymentStatusHistory
+	shipments []shipment.Shipment
+}
+
+func (s *store) clone() store {
+	copied := *s
+	copied.products = map[int]product.Product{}
+	for ID, p := range s.products {
+		copied.products[ID] = p
+	}
+	copied.carts = append([]cart.Cart{}, s.carts...)
+	copied.payments = append([]payment.Payment{}, s.payments...)
+	copied.history = append([]payment.PaymentStatusHistory{}, s.history...)
+	copied.shipments = append([]shipment.Shipment{}, s.shipments...)
+	return copied
+}
+
+type memoryProducts struct {
+	product.ProductRepository
+	*store
+}
+
+func (r memoryProducts) FindProductByID(ID int) (product.Product, error) {
+	p, ok := r.products[ID]
+	if !ok {
+		return product.Product{}, errors.New("Product not found")
+	}
+	return p, nil
+}
+
+func (r memoryProducts) CountVariants(productID int) (int, error) {
+	return 0, nil
+}
+
+func (r memoryProducts) FindOptionGroupsByProduct(productID int) ([]product.OptionGroup, error) {
+	return []product.OptionGroup{}, nil
+}
+
+func (r memoryProducts) DecrementStock(ID int, quantity int) (bool, error) {
+	p := r.products[ID]
+	if p.Stock < quantity {
+		return false, nil
+	}
+	p.Stock -= quantity
+	r.products[ID] = p
+	return true, nil
+}
+
+func (r memoryProducts) IncrementStock(ID int, quantity int) (bool, error) {
+	p, ok := r.products[ID]
+	if !ok {
+		return false, nil
+	}
+	p.Stock += quantity
+	r.products[ID] = p
+	return true, nil
+}
+
+type memoryCarts struct {
+	cart.CartRepository
+	*store
+}
+
+func (r memoryCarts) LockActiveCartsByUser(userID int) ([]cart.Cart, error) {
+	carts := []cart.Cart{}
+	for _, c := range r.carts {
+		if c.UserID == userID && c.IsActived == cart.Active && !c.IsOrdered() {
+			carts = append(carts, c)
+		}
+	}
+	return carts, nil
+}
+
+func (r memoryCarts) FindCartsByPaymentID(paymentID int) ([]cart.Cart, error) {
+	carts := []cart.Cart{}
+	for _, c := range r.carts {
+		if c.PaymentID.String() == strconv.Itoa(paymentID) {
+			carts = append(carts, c)
+		}
+	}
+	return carts, nil
+}
+
+func (r memoryCarts) CreateCart(c cart.Cart) (cart.Cart, error) {
+	c.ID = uint64(len(r.carts) + 1)
+	r.carts = append(r.carts, c)
+	return c, nil
+}
+
+func (r memoryCarts) UpdateCart(c cart.Cart) (cart.Cart, error) {
+	r.carts[c.ID-1] = c
+	return c, nil
+}
+
+type memoryPayments struct {
+	payment.PaymentRepository
+	*store
+}
+
+func (r memoryPayments) CreatePayment(p payment.Payment) (payment.Payment, error) {
+	p.ID = uint64(len(r.payments) + 1)
+	r.payments = append(r.payments, p)
+	return p, nil
+}
+
+func (r memoryPayments) CreateTransferPayment(p payment.Payment) (payment.Payment, error) {
+	if err := payment.AssignUniqueCode(&p, nil); err != nil {
+		return payment.Payment{}, err
+	}
+	return r.CreatePayment(p)
+}
+
+func (r memoryPayments) UpdatePayment(p payment.Payment) (payment.Payment, error) {
+	stored := r.payments[p.ID-1]
+	p.PaymentStatus = stored.PaymentStatus
+	p.OpenTransfer = stored.OpenTransfer
+	p.RefundedAmount = stored.RefundedAmount
+	p.SettledAt = stored.SettledAt
+	r.payments[p.ID-1] = p
+	return p, nil
+}
+
+func (r memoryPayments) LockPaymentByID(ID int) (payment.Payment, error) {
+	if ID < 1 || ID > len(r.payments) {
+		return payment.Payment{}, errors.New("Payment not found")
+	}
+	return r.payments[ID-1], nil
+}
+
+func (r memoryPayments) TransitionStatus(ID uint64, from string, history payment.PaymentStatusHistory) (bool, error) {
+	p := &r.payments[ID-1]
+	if p.PaymentStatus != from {
+		return false, nil
+	}
+	p.PaymentStatus = history.ToStatus
+	r.history = append(r.history, history)
+	return true, nil
+}
+
+func (r memoryPayments) CreateStatusHistory(history payment.PaymentStatusHistory) (payment.PaymentStatusHistory, error) {
+	r.history = append(r.history, history)
+	return history, nil
+}
+
+type memoryShipments struct {
+	shipment.ShipmentRepository
+	*store
+}
+
+func (r memoryShipments) CreateShipment(s shipment.Shipment) (shipment.Shipment, error) {
+	s.ID = uint64(len(r.shipments) + 1)
+	r.shipments = append(r.shipments, s)
+	return s, nil
+}
+
+func (r memoryShipments) CancelShipmentsByPayment(paymentID uint64) error {
+	for i, s := range r.shipments {
+		if s.PaymentID == paymentID && (s.Status == shipment.StatusPending || s.Status == shipment.StatusProcessing) {
+			r.shipments[i].Status = shipment.StatusCancelled
+		}
+	}
+	return nil
+}
+
+type memorySlots struct {
+	slot.SlotRepository
+	*store
+}
+
+func (r memorySlots) LockSlotByID(ID int) (slot.Slot, error) {
+	s, ok := r.slots[ID]
+	if !ok {
+		return slot.Slot{}, errors.New("Slot not found")
+	}
+	return s, nil
+}
+
+func (r memorySlots) CountBookings(slotID uint64, date string) (int, error) {
+	count := 0
+	for _, p := range r.payments {
+		if p.SlotID == slotID && p.ScheduledDate == date && p.PaymentStatus != payment.StatusCancelled && p.PaymentStatus != payment.StatusExpired {
+			count++
+		}
+	}
+	return count, nil
+}
+
+type memoryAddresses struct {
+	address.AddressRepository
+	*store
+}
+
+func (r memoryAddresses) FindAddressByID(ID int) (address.Address, error) {
+	a, ok := r.addresses[ID]
+	if !ok {
+		return address.Address{}, errors.New("Address not found")
+	}
+	return a, nil
+}
+
+type memoryRepository struct {
+	CheckoutRepository
+	store *store
+}
+
+func (r *memoryRepository) Transaction(fn func(repositories Repositories) error) error {
+	snapshot := r.store.clone()
+
+	err := fn(Repositories{
+		Address:  memoryAddresses{store: r.store},
+		Cart:     memoryCarts{store: r.store},
+		Product:  memoryProducts{store: r.store},
+		Payment:  memoryPayments{store: r.store},
+		Shipment: memoryShipments{store: r.store},
+		Slot:     memorySlots{store: r.store},
+	})
+
+	if err != nil {
+		*r.store = snapshot
+	}
+
+	return err
+}
+
+const buyerID = 7
+
+func newTestService() (*service, *store) {
+	s := &store{
+		products: map[int]product.Product{
+			1: {ID: 1, UserID: 11, Name: "Kapal Selam", Price: 25000, Stock: 5, Weight: 200},
+			2: {ID: 2, UserID: 12, Name: "Lenjer", Price: 10000, Stock: 1, Weight: 100},
+		},
+		addresses: map[int]address.Address{
+			1: {ID: 1, UserID: buyerID, Recipient: "Sari", Phone: "0811", City: "Palembang", CityID: "327"},
+		},
+		slots: map[int]slot.Slot{
+			1: {ID: 1, Name: "Pagi", Kind: slot.KindPickup, StartTime: "09:00", EndTime: "11:00", Days: "sun,mon,tue,wed,thu,fri,sat", Capacity: 1, Active: true},
+		},
+	}
+	return NewService(&memoryRepository{store: s}), s
+}
+
+func addCart(s *store, productID int, quantity int) {
+	s.carts = append(s.carts, cart.Cart{
+		ID:         uint64(len(s.carts) + 1),
+		UserID:     buyerID,
+		ProductID:  json.Number(strconv.Itoa(productID)),
+		Quantity:   json.Number(strconv.Itoa(quantity)),
+		TotalPrice: "1",
+		IsActived:  cart.Active,
+	})
+}
+
+func TestCheckoutPricesServerSideAndSplitsShipments(t *testing.T) {
+	s, store := newTestService()
+	addCart(store, 1, 2)
+	addCart(store, 2, 1)
+
+	order, err := s.Checkout(buyerID, CheckoutRequest{DeliveryName: "Ambil sendiri", Address: "Toko", Whatsapp: "0811", PaymentMethod: payment.MethodPickup})
+	if err != nil {
+		t.Fatalf("Checkout: %v", err)
+	}
+
+	if order.Payment.TotalPrice != 60000 || order.Payment.PaymentStatus != payment.StatusAwaitingCash || !order.Payment.StockReserved {
+		t.Errorf("payment = %+v", order.Payment)
+	}
+
+	if len(order.Shipments) != 2 || order.Shipments[0].Subtotal != 50000 || order.Shipments[1].Subtotal != 10000 {
+		t.Errorf("shipments = %+v", order.Shipments)
+	}
+
+	if store.products[1].Stock != 3 || store.products[2].Stock != 0 {
+		t.Errorf("stock = %d, %d", store.products[1].Stock, store.products[2].Stock)
+	}
+
+	for _, c := range store.carts {
+		if c.IsActived != cart.Inactive || c.PaymentID.String() != "1" || c.ShipmentID == 0 {
+			t.Errorf("cart = %+v", c)
+		}
+	}
+
+	if c := store.carts[0]; c.TotalPrice != "50000" || c.UnitPrice != 25000 {
+		t.Errorf("cart price = %s at %d", c.TotalPrice, c.UnitPrice)
+	}
+}
+
+func TestCheckoutRollsBackWhenOutOfStock(t *testing.T) {
+	s, store := newTestService()
+	addCart(store, 1, 2)
+	addCart(store, 2, 3)
+
+	_, err := s.Checkout(buyerID, CheckoutRequest{DeliveryName: "Ambil sendiri", Address: "Toko", Whatsapp: "0811", PaymentMethod: payment.MethodPickup})
+	if err == nil || !strings.HasPrefix(err.Error(), "Insufficient stock for Lenjer") {
+		t.Fatalf("Checkout error = %v", err)
+	}
+
+	if store.products[1].Stock != 5 || store.products[2].Stock != 1 {
+		t.Errorf("stock = %d, %d", store.products[1].Stock, store.products[2].Stock)
+	}
+	if len(store.payments) != 0 || len(store.shipments) != 0 {
+		t.Errorf("payments = %d, shipments = %d", len(store.payments), len(store.shipments))
+	}
+	for _, c := range store.carts {
+		if c.IsOrdered() || c.IsActived != cart.Active {
+			t.Errorf("cart = %+v", c)
+		}
+	}
+}
+
+func TestCheckoutRejectsFullSlot(t *testing.T) {
+	s, store := newTestService()
+	date := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	store.payments = append(store.payments, payment.Payment{ID: 1, SlotID: 1, ScheduledDate: date, PaymentStatus: payment.StatusAwaitingCash})
+	addCart(store, 1, 1)
+
+	_, err := s.Checkout(buyerID, CheckoutRequest{DeliveryName: "Ambil sendiri", Address: "Toko", Whatsapp: "0811", PaymentMethod: payment.MethodPickup, SlotID: 1, ScheduledDate: date})
+	if err == nil || !strings.HasSuffix(err.Error(), "fully booked") {
+		t.Fatalf("Checkout error = %v", err)
+	}
+
+	if store.products[1].Stock != 5 || len(store.payments) != 1 {
+		t.Errorf("stock = %d, payments = %d", store.products[1].Stock, len(store.payments))
+	}
+}
+
+func TestCheckoutRequiresMatchingQuoteForDelivery(t *testing.T) {
+	s, store := newTestService()
+	addCart(store, 1, 2)
+
+	if _, err := s.Checkout(buyerID, CheckoutRequest{DeliveryName: "JNE", AddressID: 1}); err == nil || err.Error() != "Shipping quote is required for delivery orders" {
+		t.Fatalf("Checkout without a quote = %v", err)
+	}
+
+	quote := shipping.Quote{UserID: buyerID, Destination: "151", Weight: 400, Courier: "jne", Service: "REG", Fee: 18000, ExpiresAt: time.Now().Add(time.Hour).Unix()}
+
+	if _, err := s.Checkout(buyerID, CheckoutRequest{DeliveryName: "JNE", AddressID: 1, ShippingToken: shipping.SignQuote(quote)}); err == nil || !strings.Contains(err.Error(), "different address") {
+		t.Fatalf("Checkout with another destination = %v", err)
+	}
+
+	quote.Destination = "327"
+
+	order, err := s.Checkout(buyerID, CheckoutRequest{DeliveryName: "JNE", AddressID: 1, ShippingToken: shipping.SignQuote(quote)})
+	if err != nil {
+		t.Fatalf("Checkout: %v", err)
+	}
+
+	if order.Payment.TotalPrice != 68000 || order.Payment.ShippingFee != 18000 || order.Payment.TransferAmount <= order.Payment.TotalPrice {
+		t.Errorf("payment = %+v", order.Payment)
+	}
+	if order.Payment.Recipient != "Sari" || order.Payment.Whatsapp != "0811" {
+		t.Errorf("address snapshot = %+v", order.Payment.AddressSnapshot)
+	}
+}
+
+func TestCancelOrderRestoresStockOnce(t *testing.T) {
+	s, store := newTestService()
+	addCart(store, 1, 2)
+	addCart(store, 2, 1)
+
+	order, err := s.Checkout(buyerID, CheckoutRequest{DeliveryName: "Ambil sendiri", Address: "Toko", Whatsapp: "0811", PaymentMethod: payment.MethodPickup})
+	if err != nil {
+		t.Fatalf("Checkout: %v", err)
+	}
+
+	history := payment.PaymentStatusHistory{PaymentID: order.Payment.ID, FromStatus: payment.StatusAwaitingCash, ToStatus: payment.StatusCancelled}
+
+	for i := 0; i < 2; i++ {
+		cancelled, err := s.CancelOrder(order.Payment, history)
+		if err != nil {
+			t.Fatalf("CancelOrder: %v", err)
+		}
+		if cancelled != (i == 0) {
+			t.Errorf("cancel %d = %v", i, cancelled)
+		}
+	}
+
+	if store.products[1].Stock != 5 || store.products[2].Stock != 1 {
+		t.Errorf("stock = %d, %d", store.products[1].Stock, store.products[2].Stock)
+	}
+
+	if p := store.payments[0]; p.PaymentStatus != payment.StatusCancelled || p.StockReserved {
+		t.Errorf("payment = %+v", p)
+	}
+
+	for _, sh := range store.shipments {
+		if sh.Status != shipment.StatusCancelled {
+			t.Errorf("shipment %d is %s", sh.ID, sh.Status)
+		}
+	}
+
+	archived, active := 0, 0
+	for _, c := range store.carts {
+		switch c.IsActived {
+		case cart.Archived:
+			archived++
+		case cart.Active:
+			if c.IsOrdered() {
+				t.Errorf("restored cart is still ordered: %+v", c)
+			}
+			active++
+		}
+	}
+
+	if archived != 2 || active != 2 {
+		t.Errorf("archived = %d, active = %d", archived, active)
+	}
+}
+
+func TestChangeAddressRequiresSameFeeBeforeProcessing(t *testing.T) {
+	s, store := newTestService()
+	store.addresses[2] = address.Address{ID: 2, UserID: buyerID, Recipient: "Budi", Phone: "0822", City: "Palembang", CityID: "327", Details: "Jl. Merdeka 1"}
+	store.addresses[3] = address.Address{ID: 3, UserID: buyerID, Recipient: "Budi", Phone: "0822", City: "Jakarta", CityID: "151"}
+	store.addresses[4] = address.Address{ID: 4, UserID: 99, Recipient: "Other", City: "Palembang", CityID: "327"}
+	addCart(store, 1, 2)
+
+	quote := shipping.Quote{UserID: buyerID, Destination: "327", Weight: 400, Courier: "jne", Service: "REG", Fee: 18000, ExpiresAt: time.Now().Add(time.Hour).Unix()}
+
+	order, err := s.Checkout(buyerID, CheckoutRequest{DeliveryName: "JNE", AddressID: 1, ShippingToken: shipping.SignQuote(quote)})
+	if err != nil {
+		t.Fatalf("Checkout: %v", err)
+	}
+
+	paymentID := int(order.Payment.ID)
+
+	if _, err := s.ChangeAddress(buyerID+1, paymentID, ChangeAddressRequest{AddressID: 2, ShippingToken: shipping.SignQuote(quote)}); err == nil || err.Error() != "Payment not found" {
+		t.Errorf("other buyer = %v", err)
+	}
+
+	if _, err := s.ChangeAddress(buyerID, paymentID, ChangeAddressRequest{AddressID: 4, ShippingToken: shipping.SignQuote(quote)}); err == nil {
+		t.Error("address of another user was accepted")
+	}
+
+	if _, err := s.ChangeAddress(buyerID, paymentID, ChangeAddressRequest{AddressID: 2}); err == nil {
+		t.Error("address change without a quote was accepted")
+	}
+
+	farQuote := quote
+	farQuote.Destination = "151"
+	farQuote.Fee = 30000
+
+	if _, err := s.ChangeAddress(buyerID, paymentID, ChangeAddressRequest{AddressID: 3, ShippingToken: shipping.SignQuote(farQuote)}); err == nil || !strings.HasPrefix(err.Error(), "Cannot") {
+		t.Errorf("address with another fee = %v", err)
+	}
+
+	changed, err := s.ChangeAddress(buyerID, paymentID, ChangeAddressRequest{AddressID: 2, ShippingToken: shipping.SignQuote(quote)})
+	if err != nil {
+		t.Fatalf("ChangeAddress: %v", err)
+	}
+
+	if changed.AddressID != 2 || changed.Recipient != "Budi" || changed.Whatsapp != "0822" || !strings.HasPrefix(changed.Address, "Jl. Merdeka 1") {
+		t.Errorf("payment = %+v", changed)
+	}
+	if changed.TotalPrice != order.Payment.TotalPrice || changed.TransferAmount != order.Payment.TransferAmount {
+		t.Errorf("total changed from %d to %d", order.Payment.TotalPrice, changed.TotalPrice)
+	}
+
+	store.payments[paymentID-1].PaymentStatus = payment.StatusProcessing
+
+	if _, err := s.ChangeAddress(buyerID, paymentID, ChangeAddressRequest{AddressID: 1, ShippingToken: shipping.SignQuote(quote)}); err == nil || !strings.HasPrefix(err.Error(), "Cannot") {
+		t.Errorf("address change while processing = %v", err)
+	}
+}
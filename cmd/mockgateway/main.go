@@ -0,0 +1,26 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"taman-pempek/payment"
+)
+
+func main() {
+	port := envOrDefault("MOCKGATEWAY_PORT", "8899")
+	serverKey := envOrDefault("PAYMENTGATEWAY_SERVERKEY", "mock-server-key")
+	notifyURL := envOrDefault("MOCKGATEWAY_NOTIFYURL", "http://localhost:8888/v1/payment/webhook")
+
+	server := payment.NewMockGatewayServer(serverKey, notifyURL)
+
+	log.Printf("Mock payment gateway listening on :%s, notifying %s", port, notifyURL)
+	log.Fatal(http.ListenAndServe(":"+port, server))
+}
+
+func envOrDefault(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"taman-pempek/shipping"
+)
+
+func main() {
+	port := envOrDefault("MOCKRAJAONGKIR_PORT", "8898")
+	apiKey := envOrDefault("RAJAONGKIR_KEY", "mock-rajaongkir-key")
+
+	server := shipping.NewMockRajaOngkirServer(apiKey)
+
+	log.Printf("Mock rajaongkir listening on :%s", port)
+	log.Fatal(http.ListenAndServe(":"+port, server))
+}
+
+func envOrDefault(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
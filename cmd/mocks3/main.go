@@ -0,0 +1,27 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"taman-pempek/storage"
+)
+
+func main() {
+	port := envOrDefault("MOCKS3_PORT", "8897")
+	region := envOrDefault("S3_REGION", "us-east-1")
+	accessKey := envOrDefault("S3_ACCESSKEY", "mock-access-key")
+	secretKey := envOrDefault("S3_SECRETKEY", "mock-secret-key")
+
+	server := storage.NewMockS3Server(region, accessKey, secretKey)
+
+	log.Printf("Mock S3 storage listening on :%s", port)
+	log.Fatal(http.ListenAndServe(":"+port, server))
+}
+
+func envOrDefault(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
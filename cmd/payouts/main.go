@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"taman-pempek/ledger"
+	"taman-pempek/setting"
+
+	"github.com/joho/godotenv"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func main() {
+	create := flag.Bool("create", false, "create a payout batch from seller balances")
+	minimum := flag.Int("minimum", 0, "minimum balance to include a seller in the batch")
+	paid := flag.Int("paid", 0, "mark the payout batch with this ID as paid")
+	reference := flag.String("reference", "", "transfer reference recorded when marking a batch paid")
+	flag.Parse()
+
+	godotenv.Load(".env")
+
+	dsn := os.Getenv("MYSQLUSER") + ":" + os.Getenv("MYSQLPASSWORD") + "@tcp(" + os.Getenv("MYSQLHOST") + ":" + os.Getenv("MYSQLPORT") + ")/" + os.Getenv("MYSQLDATABASE") + "?charset=utf8mb4&parseTime=True&loc=Local"
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+
+	if err != nil {
+		log.Fatal("DB Connection error")
+	}
+
+	ledgerService := ledger.NewService(ledger.NewRepository(db), setting.NewService(setting.NewRepository(db)))
+
+	switch {
+	case *create:
+		batch, err := ledgerService.CreatePayoutBatch(*minimum)
+
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, payout := range batch.Payouts {
+			log.Printf("Seller %d: %d to %s %s", payout.SellerID, payout.Amount, payout.BankName, payout.BankNumber)
+		}
+
+		log.Printf("Created payout batch %d with %d transfers totalling %d", batch.ID, len(batch.Payouts), batch.Total)
+	case *paid != 0:
+		if *reference == "" {
+			log.Fatal("A transfer reference is required")
+		}
+
+		batch, err := ledgerService.MarkPayoutBatchPaid(*paid, *reference)
+
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		log.Printf("Marked payout batch %d as paid (%s)", batch.ID, batch.Reference)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
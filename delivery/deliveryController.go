@@ -206,8 +206,9 @@ func (ch *controller) DeleteDelivery(c *gin.Context) {
 
 func convertToDeliveryResponse(delivery Delivery) DeliveryResponse {
 	return DeliveryResponse{
-		ID:       delivery.ID,
-		Name:     delivery.Name,
-		Whatsapp: delivery.Whatsapp,
+		ID:        delivery.ID,
+		Name:      delivery.Name,
+		Whatsapp:  delivery.Whatsapp,
+		CourierID: delivery.CourierID,
 	}
 }
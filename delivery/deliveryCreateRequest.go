@@ -1,6 +1,7 @@
 package delivery
 
 type DeliveryCreateRequest struct {
-	Name     string `json:"name" binding:"required"`
-	Whatsapp string `json:"whatsapp" binding:"required"`
+	Name      string `json:"name" binding:"required"`
+	Whatsapp  string `json:"whatsapp" binding:"required"`
+	CourierID int    `json:"courier_id"`
 }
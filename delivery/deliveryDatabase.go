@@ -6,6 +6,7 @@ type Delivery struct {
 	ID        uint64    `gorm:"column:id;primaryKey;autoIncrement"`
 	Name      string    `gorm:"column:name;type:varchar(255)"`
 	Whatsapp  string    `gorm:"column:whatsapp;type:varchar(255)"`
+	CourierID int       `gorm:"column:courier_id;index"`
 	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
 }
@@ -1,7 +1,8 @@
 package delivery
 
 type DeliveryResponse struct {
-	ID       uint64 `json:"id"`
-	Name     string `json:"name"`
-	Whatsapp string `json:"whatsapp"`
+	ID        uint64 `json:"id"`
+	Name      string `json:"name"`
+	Whatsapp  string `json:"whatsapp"`
+	CourierID int    `json:"courier_id"`
 }
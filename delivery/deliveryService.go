@@ -32,8 +32,9 @@ func (s *service) FindDeliveryByID(ID int) (Delivery, error) {
 
 func (s *service) CreateDelivery(deliveryRequest DeliveryCreateRequest) (Delivery, error) {
 	deliveryData := Delivery{
-		Name:     deliveryRequest.Name,
-		Whatsapp: deliveryRequest.Whatsapp,
+		Name:      deliveryRequest.Name,
+		Whatsapp:  deliveryRequest.Whatsapp,
+		CourierID: deliveryRequest.CourierID,
 	}
 
 	delivery, err := s.deliveryRepository.CreateDelivery(deliveryData)
@@ -58,6 +59,9 @@ func (s *service) UpdateDelivery(ID int, deliveryRequest DeliveryUpdateRequest)
 	if deliveryRequest.Whatsapp != "" {
 		delivery.Whatsapp = deliveryRequest.Whatsapp
 	}
+	if deliveryRequest.CourierID != 0 {
+		delivery.CourierID = deliveryRequest.CourierID
+	}
 
 	return s.deliveryRepository.UpdateDelivery(delivery)
 }
@@ -1,6 +1,7 @@
 package delivery
 
 type DeliveryUpdateRequest struct {
-	Name     string `json:"name,omitempty"`
-	Whatsapp string `json:"whatsapp,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Whatsapp  string `json:"whatsapp,omitempty"`
+	CourierID int    `json:"courier_id,omitempty"`
 }
@@ -0,0 +1,457 @@
+package dispatch
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"taman-pempek/payment"
+	"taman-pempek/storage"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+const proofFolder = "dispatch/proof"
+
+type controller struct {
+	dispatchService DispatchService
+	mediaStore      storage.MediaStore
+}
+
+func NewController(dispatchService DispatchService, mediaStore storage.MediaStore) *controller {
+	return &controller{dispatchService, mediaStore}
+}
+
+func (cn *controller) AssignDelivery(c *gin.Context) {
+	var assignRequest AssignRequest
+
+	err := c.ShouldBindJSON(&assignRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+				errorMessages = append(errorMessages, errorMessage)
+			}
+		} else {
+			errorMessages = append(errorMessages, "Invalid request body")
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	assignment, err := cn.dispatchService.Assign(assignRequest, actorFromContext(c))
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		if strings.HasPrefix(err.Error(), "Cannot") {
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToAssignmentResponse(assignment),
+	})
+}
+
+func (cn *controller) UnassignDelivery(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid assignment ID",
+		})
+		return
+	}
+
+	assignment, err := cn.dispatchService.Unassign(id)
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		if strings.HasPrefix(err.Error(), "Cannot") || strings.HasSuffix(err.Error(), "changed by another request") {
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToAssignmentResponse(assignment),
+	})
+}
+
+func (cn *controller) GetAssignment(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid assignment ID",
+		})
+		return
+	}
+
+	assignment, err := cn.dispatchService.FindAssignmentByID(id)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "Assignment not found" {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToAssignmentResponse(assignment),
+	})
+}
+
+func (cn *controller) GetPaymentDispatch(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid payment ID",
+		})
+		return
+	}
+
+	assignment, err := cn.dispatchService.FindAssignmentByPayment(id)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "Assignment not found" {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToAssignmentResponse(assignment),
+	})
+}
+
+func (cn *controller) GetDriverRoute(c *gin.Context) {
+	deliveryIDString := c.Param("deliveryId")
+	deliveryID, err := strconv.Atoi(deliveryIDString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid delivery ID",
+		})
+		return
+	}
+
+	stops, err := cn.dispatchService.FindRoute(deliveryID)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToStopsResponse(stops),
+	})
+}
+
+func (cn *controller) ReorderDriverRoute(c *gin.Context) {
+	var routeRequest RouteRequest
+
+	err := c.ShouldBindJSON(&routeRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+				errorMessages = append(errorMessages, errorMessage)
+			}
+		} else {
+			errorMessages = append(errorMessages, "Invalid request body")
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	deliveryIDString := c.Param("deliveryId")
+	deliveryID, err := strconv.Atoi(deliveryIDString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid delivery ID",
+		})
+		return
+	}
+
+	stops, err := cn.dispatchService.ReorderRoute(deliveryID, routeRequest)
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToStopsResponse(stops),
+	})
+}
+
+func (cn *controller) UpdateDispatchStatus(c *gin.Context) {
+	var statusRequest StatusRequest
+
+	err := c.ShouldBindJSON(&statusRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+				errorMessages = append(errorMessages, errorMessage)
+			}
+		} else {
+			errorMessages = append(errorMessages, "Invalid request body")
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid assignment ID",
+		})
+		return
+	}
+
+	assignment, err := cn.dispatchService.UpdateStatus(id, statusRequest.Status)
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		if strings.HasPrefix(err.Error(), "Cannot") || strings.HasSuffix(err.Error(), "changed by another request") {
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToAssignmentResponse(assignment),
+	})
+}
+
+func (cn *controller) ConfirmDelivery(c *gin.Context) {
+	var deliverRequest DeliverRequest
+
+	err := c.ShouldBind(&deliverRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+				errorMessages = append(errorMessages, errorMessage)
+			}
+		} else {
+			errorMessages = append(errorMessages, "Invalid request body")
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid assignment ID",
+		})
+		return
+	}
+
+	media, err := storage.Upload(cn.mediaStore, proofFolder, &deliverRequest.Image, storage.DeliveryProof)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	assignment, err := cn.dispatchService.ConfirmDelivery(id, deliverRequest.RecipientName, deliverRequest.Note, media.URL)
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		if strings.HasPrefix(err.Error(), "Cannot") || strings.HasPrefix(err.Error(), "Collect the cash") || strings.HasSuffix(err.Error(), "changed by another request") {
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToAssignmentResponse(assignment),
+	})
+}
+
+func actorFromContext(c *gin.Context) payment.Actor {
+	return payment.Actor{
+		ID:   c.GetUint64("UserID"),
+		Role: c.GetString("UserRole"),
+	}
+}
+
+func convertToAssignmentResponse(assignment Assignment) AssignmentResponse {
+	return AssignmentResponse{
+		ID:            assignment.ID,
+		PaymentID:     assignment.PaymentID,
+		DeliveryID:    assignment.DeliveryID,
+		Reference:     assignment.Reference(),
+		Sequence:      assignment.Sequence,
+		Status:        assignment.Status,
+		RecipientName: assignment.RecipientName,
+		ProofImage:    assignment.ProofImage,
+		Note:          assignment.Note,
+		PickedUpAt:    assignment.PickedUpAt,
+		DepartedAt:    assignment.DepartedAt,
+		DeliveredAt:   assignment.DeliveredAt,
+		CreatedAt:     assignment.CreatedAt,
+	}
+}
+
+func convertToStopsResponse(stops []Stop) []StopResponse {
+	stopsResponse := []StopResponse{}
+
+	for _, stop := range stops {
+		cashToCollect := 0
+
+		if stop.Payment.PaymentMethod == payment.MethodCOD {
+			cashToCollect = stop.Payment.TotalPrice
+		}
+
+		stopsResponse = append(stopsResponse, StopResponse{
+			Sequence:      stop.Assignment.Sequence,
+			AssignmentID:  stop.Assignment.ID,
+			PaymentID:     stop.Assignment.PaymentID,
+			Reference:     stop.Assignment.Reference(),
+			Status:        stop.Assignment.Status,
+			Recipient:     stop.Payment.Recipient,
+			Address:       stop.Payment.Address,
+			Whatsapp:      stop.Payment.Whatsapp,
+			Latitude:      stop.Payment.Latitude,
+			Longitude:     stop.Payment.Longitude,
+			PaymentMethod: stop.Payment.PaymentMethod,
+			CashToCollect: cashToCollect,
+		})
+	}
+
+	return stopsResponse
+}
@@ -0,0 +1,41 @@
+package dispatch
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	StatusAssigned  = "assigned"
+	StatusPickedUp  = "picked_up"
+	StatusOnTheWay  = "on_the_way"
+	StatusDelivered = "delivered"
+	StatusCancelled = "cancelled"
+)
+
+const ReferencePrefix = "DSP"
+
+type Assignment struct {
+	ID            uint64     `gorm:"column:id;primaryKey;autoIncrement"`
+	PaymentID     uint64     `gorm:"column:payment_id;uniqueIndex"`
+	DeliveryID    int        `gorm:"column:delivery_id;index"`
+	Sequence      int        `gorm:"column:sequence"`
+	Status        string     `gorm:"column:status;type:varchar(255);index"`
+	AssignedBy    uint64     `gorm:"column:assigned_by"`
+	RecipientName string     `gorm:"column:recipient_name;type:varchar(255)"`
+	ProofImage    string     `gorm:"column:proof_image;type:varchar(255)"`
+	Note          string     `gorm:"column:note;type:varchar(255)"`
+	PickedUpAt    *time.Time `gorm:"column:picked_up_at"`
+	DepartedAt    *time.Time `gorm:"column:departed_at"`
+	DeliveredAt   *time.Time `gorm:"column:delivered_at"`
+	CreatedAt     time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (Assignment) TableName() string {
+	return "dispatch_assignments"
+}
+
+func (a Assignment) Reference() string {
+	return fmt.Sprintf("%s%06d", ReferencePrefix, a.ID)
+}
@@ -0,0 +1,84 @@
+package dispatch
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+var activeStatuses = []string{StatusAssigned, StatusPickedUp, StatusOnTheWay}
+
+type DispatchRepository interface {
+	FindAssignmentByID(ID int) (Assignment, error)
+	FindAssignmentByPayment(paymentID uint64) (Assignment, error)
+	FindActiveAssignmentsByDelivery(deliveryID int) ([]Assignment, error)
+	NextSequence(deliveryID int) (int, error)
+	SaveAssignment(assignment Assignment) (Assignment, error)
+	UpdateSequences(sequences map[uint64]int) error
+	TransitionAssignment(assignment Assignment, from string) (bool, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) FindAssignmentByID(ID int) (Assignment, error) {
+	var assignment Assignment
+	err := r.db.First(&assignment, ID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Assignment{}, errors.New("Assignment not found")
+	}
+	return assignment, err
+}
+
+func (r *repository) FindAssignmentByPayment(paymentID uint64) (Assignment, error) {
+	var assignment Assignment
+	err := r.db.Where("payment_id = ?", paymentID).First(&assignment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Assignment{}, errors.New("Assignment not found")
+	}
+	return assignment, err
+}
+
+func (r *repository) FindActiveAssignmentsByDelivery(deliveryID int) ([]Assignment, error) {
+	var assignments []Assignment
+	err := r.db.Where("delivery_id = ? AND status IN ?", deliveryID, activeStatuses).Order("sequence, id").Find(&assignments).Error
+	return assignments, err
+}
+
+func (r *repository) NextSequence(deliveryID int) (int, error) {
+	var sequence int
+	err := r.db.Model(&Assignment{}).
+		Where("delivery_id = ? AND status IN ?", deliveryID, activeStatuses).
+		Select("COALESCE(MAX(sequence), 0)").
+		Scan(&sequence).Error
+	return sequence + 1, err
+}
+
+func (r *repository) SaveAssignment(assignment Assignment) (Assignment, error) {
+	err := r.db.Save(&assignment).Error
+	return assignment, err
+}
+
+func (r *repository) UpdateSequences(sequences map[uint64]int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for ID, sequence := range sequences {
+			if err := tx.Model(&Assignment{}).Where("id = ?", ID).Update("sequence", sequence).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *repository) TransitionAssignment(assignment Assignment, from string) (bool, error) {
+	result := r.db.Model(&assignment).
+		Where("status = ?", from).
+		Select("status", "recipient_name", "proof_image", "note", "picked_up_at", "departed_at", "delivered_at").
+		Updates(&assignment)
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,22 @@
+package dispatch
+
+import "mime/multipart"
+
+type AssignRequest struct {
+	PaymentID  int `json:"payment_id" binding:"required"`
+	DeliveryID int `json:"delivery_id" binding:"required"`
+}
+
+type RouteRequest struct {
+	AssignmentIDs []uint64 `json:"assignment_ids" binding:"required,min=1"`
+}
+
+type StatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=picked_up on_the_way"`
+}
+
+type DeliverRequest struct {
+	Image         multipart.FileHeader `form:"image" binding:"required"`
+	RecipientName string               `form:"recipient_name" binding:"required"`
+	Note          string               `form:"note"`
+}
@@ -0,0 +1,34 @@
+package dispatch
+
+import "time"
+
+type AssignmentResponse struct {
+	ID            uint64     `json:"id"`
+	PaymentID     uint64     `json:"payment_id"`
+	DeliveryID    int        `json:"delivery_id"`
+	Reference     string     `json:"reference"`
+	Sequence      int        `json:"sequence"`
+	Status        string     `json:"status"`
+	RecipientName string     `json:"recipient_name"`
+	ProofImage    string     `json:"proof_image"`
+	Note          string     `json:"note"`
+	PickedUpAt    *time.Time `json:"picked_up_at"`
+	DepartedAt    *time.Time `json:"departed_at"`
+	DeliveredAt   *time.Time `json:"delivered_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type StopResponse struct {
+	Sequence      int      `json:"sequence"`
+	AssignmentID  uint64   `json:"assignment_id"`
+	PaymentID     uint64   `json:"payment_id"`
+	Reference     string   `json:"reference"`
+	Status        string   `json:"status"`
+	Recipient     string   `json:"recipient"`
+	Address       string   `json:"address"`
+	Whatsapp      string   `json:"whatsapp"`
+	Latitude      *float64 `json:"latitude"`
+	Longitude     *float64 `json:"longitude"`
+	PaymentMethod string   `json:"payment_method"`
+	CashToCollect int      `json:"cash_to_collect"`
+}
@@ -0,0 +1,398 @@
+package dispatch
+
+import (
+	"errors"
+	"fmt"
+	"taman-pempek/delivery"
+	"taman-pempek/payment"
+	"taman-pempek/shipment"
+	"time"
+)
+
+type Stop struct {
+	Assignment Assignment
+	Payment    payment.Payment
+}
+
+type DispatchService interface {
+	FindAssignmentByID(ID int) (Assignment, error)
+	FindAssignmentByPayment(paymentID int) (Assignment, error)
+	FindRoute(deliveryID int) ([]Stop, error)
+	Assign(request AssignRequest, actor payment.Actor) (Assignment, error)
+	Unassign(ID int) (Assignment, error)
+	ReorderRoute(deliveryID int, request RouteRequest) ([]Stop, error)
+	UpdateStatus(ID int, status string) (Assignment, error)
+	ConfirmDelivery(ID int, recipientName string, note string, image string) (Assignment, error)
+}
+
+var transitions = map[string][]string{
+	StatusAssigned: {StatusPickedUp, StatusCancelled},
+	StatusPickedUp: {StatusOnTheWay},
+	StatusOnTheWay: {StatusDelivered},
+}
+
+var dispatchableStatuses = []string{
+	payment.StatusVerified,
+	payment.StatusProcessing,
+	payment.StatusAwaitingCash,
+}
+
+type service struct {
+	dispatchRepository DispatchRepository
+	deliveryService    delivery.DeliveryService
+	paymentService     payment.PaymentService
+	shipmentService    shipment.ShipmentService
+}
+
+func NewService(dispatchRepository DispatchRepository, deliveryService delivery.DeliveryService, paymentService payment.PaymentService, shipmentService shipment.ShipmentService) *service {
+	return &service{dispatchRepository, deliveryService, paymentService, shipmentService}
+}
+
+func (s *service) FindAssignmentByID(ID int) (Assignment, error) {
+	return s.dispatchRepository.FindAssignmentByID(ID)
+}
+
+func (s *service) FindAssignmentByPayment(paymentID int) (Assignment, error) {
+	return s.dispatchRepository.FindAssignmentByPayment(uint64(paymentID))
+}
+
+func (s *service) FindRoute(deliveryID int) ([]Stop, error) {
+	if _, err := s.deliveryService.FindDeliveryByID(deliveryID); err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.dispatchRepository.FindActiveAssignmentsByDelivery(deliveryID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stops := []Stop{}
+
+	for _, assignment := range assignments {
+		p, err := s.paymentService.FindPaymentByID(int(assignment.PaymentID))
+
+		if err != nil {
+			return nil, err
+		}
+
+		stops = append(stops, Stop{Assignment: assignment, Payment: p})
+	}
+
+	return stops, nil
+}
+
+func (s *service) Assign(request AssignRequest, actor payment.Actor) (Assignment, error) {
+	p, err := s.paymentService.FindPaymentByID(request.PaymentID)
+
+	if err != nil {
+		return Assignment{}, err
+	}
+
+	if p.PaymentMethod == payment.MethodPickup {
+		return Assignment{}, errors.New("Pickup orders are collected at the store and cannot be dispatched")
+	}
+
+	if !contains(dispatchableStatuses, p.PaymentStatus) {
+		return Assignment{}, fmt.Errorf("Cannot dispatch payment with status %s", p.PaymentStatus)
+	}
+
+	driver, err := s.deliveryService.FindDeliveryByID(request.DeliveryID)
+
+	if err != nil {
+		return Assignment{}, err
+	}
+
+	if driver.CourierID == 0 {
+		return Assignment{}, errors.New("Delivery has no driver account")
+	}
+
+	assignment, err := s.dispatchRepository.FindAssignmentByPayment(p.ID)
+
+	if err == nil && assignment.Status != StatusAssigned && assignment.Status != StatusCancelled {
+		return Assignment{}, fmt.Errorf("Cannot reassign a delivery with status %s", assignment.Status)
+	}
+
+	if err == nil && assignment.Status == StatusAssigned && assignment.DeliveryID == request.DeliveryID {
+		return assignment, nil
+	}
+
+	sequence, err := s.dispatchRepository.NextSequence(request.DeliveryID)
+
+	if err != nil {
+		return Assignment{}, err
+	}
+
+	assignment.PaymentID = p.ID
+	assignment.DeliveryID = request.DeliveryID
+	assignment.Sequence = sequence
+	assignment.Status = StatusAssigned
+	assignment.AssignedBy = actor.ID
+
+	assignment, err = s.dispatchRepository.SaveAssignment(assignment)
+
+	if err != nil {
+		return Assignment{}, err
+	}
+
+	deliveryRequest := shipment.ShipmentDeliveryRequest{DeliveryID: request.DeliveryID, DeliveryName: driver.Name}
+
+	if _, err := s.paymentService.UpdatePayment(int(p.ID), payment.PaymentUpdateRequest{DeliveryID: deliveryRequest.DeliveryID, DeliveryName: deliveryRequest.DeliveryName}, actor); err != nil {
+		return Assignment{}, err
+	}
+
+	fulfilments, err := s.shipmentService.FindShipmentsByPayment(int(p.ID))
+
+	if err != nil {
+		return Assignment{}, err
+	}
+
+	for _, fulfilment := range fulfilments {
+		if fulfilment.Shipment.Status == shipment.StatusCancelled {
+			continue
+		}
+
+		if _, err := s.shipmentService.UpdateDelivery(int(fulfilment.Shipment.ID), deliveryRequest); err != nil {
+			return Assignment{}, err
+		}
+	}
+
+	return assignment, nil
+}
+
+func (s *service) Unassign(ID int) (Assignment, error) {
+	assignment, err := s.dispatchRepository.FindAssignmentByID(ID)
+
+	if err != nil {
+		return Assignment{}, err
+	}
+
+	return s.transition(assignment, StatusCancelled)
+}
+
+func (s *service) ReorderRoute(deliveryID int, request RouteRequest) ([]Stop, error) {
+	assignments, err := s.dispatchRepository.FindActiveAssignmentsByDelivery(deliveryID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	active := map[uint64]bool{}
+
+	for _, assignment := range assignments {
+		active[assignment.ID] = true
+	}
+
+	sequences := map[uint64]int{}
+
+	for i, ID := range request.AssignmentIDs {
+		if !active[ID] {
+			return nil, fmt.Errorf("Assignment %d is not an active stop of this driver", ID)
+		}
+
+		if _, ok := sequences[ID]; ok {
+			return nil, fmt.Errorf("Assignment %d is listed more than once", ID)
+		}
+
+		sequences[ID] = i + 1
+	}
+
+	if len(sequences) != len(active) {
+		return nil, errors.New("Route must list every active stop of this driver")
+	}
+
+	if err := s.dispatchRepository.UpdateSequences(sequences); err != nil {
+		return nil, err
+	}
+
+	return s.FindRoute(deliveryID)
+}
+
+func (s *service) UpdateStatus(ID int, status string) (Assignment, error) {
+	assignment, err := s.dispatchRepository.FindAssignmentByID(ID)
+
+	if err != nil {
+		return Assignment{}, err
+	}
+
+	if status != StatusPickedUp && status != StatusOnTheWay {
+		return Assignment{}, errors.New("Invalid dispatch status")
+	}
+
+	if !canTransition(assignment.Status, status) {
+		return Assignment{}, fmt.Errorf("Cannot change dispatch status from %s to %s", assignment.Status, status)
+	}
+
+	if status == StatusPickedUp {
+		if err := s.ship(assignment); err != nil {
+			return Assignment{}, err
+		}
+	}
+
+	return s.transition(assignment, status)
+}
+
+func (s *service) ConfirmDelivery(ID int, recipientName string, note string, image string) (Assignment, error) {
+	assignment, err := s.dispatchRepository.FindAssignmentByID(ID)
+
+	if err != nil {
+		return Assignment{}, err
+	}
+
+	if !canTransition(assignment.Status, StatusDelivered) {
+		return Assignment{}, fmt.Errorf("Cannot change dispatch status from %s to %s", assignment.Status, StatusDelivered)
+	}
+
+	if err := s.deliver(assignment); err != nil {
+		return Assignment{}, err
+	}
+
+	assignment.RecipientName = recipientName
+	assignment.Note = note
+	assignment.ProofImage = image
+
+	return s.transition(assignment, StatusDelivered)
+}
+
+func (s *service) transition(assignment Assignment, status string) (Assignment, error) {
+	if !canTransition(assignment.Status, status) {
+		return Assignment{}, fmt.Errorf("Cannot change dispatch status from %s to %s", assignment.Status, status)
+	}
+
+	from := assignment.Status
+	now := time.Now()
+	assignment.Status = status
+
+	switch status {
+	case StatusPickedUp:
+		assignment.PickedUpAt = &now
+	case StatusOnTheWay:
+		assignment.DepartedAt = &now
+	case StatusDelivered:
+		assignment.DeliveredAt = &now
+	}
+
+	transitioned, err := s.dispatchRepository.TransitionAssignment(assignment, from)
+
+	if err != nil {
+		return Assignment{}, err
+	}
+
+	if !transitioned {
+		return Assignment{}, errors.New("Dispatch status has been changed by another request")
+	}
+
+	return assignment, nil
+}
+
+func (s *service) ship(assignment Assignment) error {
+	p, err := s.paymentService.FindPaymentByID(int(assignment.PaymentID))
+
+	if err != nil {
+		return err
+	}
+
+	fulfilments, err := s.shipmentService.FindShipmentsByPayment(int(p.ID))
+
+	if err != nil {
+		return err
+	}
+
+	if len(fulfilments) == 0 {
+		return s.advancePayment(p, payment.StatusProcessing, payment.StatusShipped)
+	}
+
+	for _, fulfilment := range fulfilments {
+		sh := fulfilment.Shipment
+
+		if sh.Status != shipment.StatusPending && sh.Status != shipment.StatusProcessing {
+			continue
+		}
+
+		if sh.Resi == "" {
+			if _, err := s.shipmentService.UpdateDelivery(int(sh.ID), shipment.ShipmentDeliveryRequest{Resi: assignment.Reference()}); err != nil {
+				return err
+			}
+		}
+
+		if sh.Status == shipment.StatusPending {
+			if _, err := s.shipmentService.UpdateStatus(int(sh.ID), shipment.StatusProcessing, payment.SystemActor); err != nil {
+				return err
+			}
+		}
+
+		if _, err := s.shipmentService.UpdateStatus(int(sh.ID), shipment.StatusShipped, payment.SystemActor); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *service) deliver(assignment Assignment) error {
+	p, err := s.paymentService.FindPaymentByID(int(assignment.PaymentID))
+
+	if err != nil {
+		return err
+	}
+
+	if payment.IsCashMethod(p.PaymentMethod) {
+		if p.PaymentStatus != payment.StatusDelivered && p.PaymentStatus != payment.StatusCompleted {
+			return errors.New("Collect the cash before confirming delivery")
+		}
+		return nil
+	}
+
+	fulfilments, err := s.shipmentService.FindShipmentsByPayment(int(p.ID))
+
+	if err != nil {
+		return err
+	}
+
+	if len(fulfilments) == 0 {
+		return s.advancePayment(p, payment.StatusDelivered)
+	}
+
+	for _, fulfilment := range fulfilments {
+		if fulfilment.Shipment.Status != shipment.StatusShipped {
+			continue
+		}
+
+		if _, err := s.shipmentService.UpdateStatus(int(fulfilment.Shipment.ID), shipment.StatusDelivered, payment.SystemActor); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *service) advancePayment(p payment.Payment, targets ...string) error {
+	for _, target := range targets {
+		if !payment.CanActorTransition(payment.SystemActor, p.PaymentMethod, p.PaymentStatus, target) {
+			continue
+		}
+
+		next, err := s.paymentService.TransitionStatus(int(p.ID), target, payment.SystemActor, "Updated from driver dispatch")
+
+		if err != nil {
+			return err
+		}
+
+		p = next
+	}
+
+	return nil
+}
+
+func canTransition(from string, to string) bool {
+	return contains(transitions[from], to)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
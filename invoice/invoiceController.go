@@ -0,0 +1,146 @@
+package invoice
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type controller struct {
+	invoiceService InvoiceService
+}
+
+func NewController(invoiceService InvoiceService) *controller {
+	return &controller{invoiceService}
+}
+
+func (cn *controller) GetInvoice(c *gin.Context) {
+	document, ok := cn.prepare(c)
+
+	if !ok {
+		return
+	}
+
+	token, expiresAt := cn.invoiceService.ShareToken(document.Payment.ID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data": InvoiceResponse{
+			Number:         document.Invoice.Number,
+			PaymentID:      document.Payment.ID,
+			IssuedAt:       document.Invoice.IssuedAt,
+			Paid:           document.Paid,
+			ShareToken:     token,
+			ShareExpiresAt: expiresAt,
+			PDFPath:        fmt.Sprintf("/v1/payment/%d/invoice.pdf", document.Payment.ID),
+			HTMLPath:       fmt.Sprintf("/v1/payment/%d/invoice.html", document.Payment.ID),
+			SharePath:      "/v1/invoice/share/" + token,
+		},
+	})
+}
+
+func (cn *controller) GetInvoicePDF(c *gin.Context) {
+	document, ok := cn.prepare(c)
+
+	if !ok {
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", document.Invoice.Number+".pdf"))
+	c.Data(http.StatusOK, "application/pdf", cn.invoiceService.RenderPDF(document))
+}
+
+func (cn *controller) GetInvoiceHTML(c *gin.Context) {
+	document, ok := cn.prepare(c)
+
+	if !ok {
+		return
+	}
+
+	cn.writeHTML(c, document)
+}
+
+func (cn *controller) GetSharedInvoice(c *gin.Context) {
+	id, err := cn.invoiceService.PaymentFromShareToken(c.Param("token"))
+
+	if err != nil {
+		statusCode := http.StatusNotFound
+		message := "Invoice not found"
+		if err.Error() == "Invoice link has expired" {
+			statusCode = http.StatusGone
+			message = err.Error()
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   message,
+		})
+		return
+	}
+
+	document, err := cn.invoiceService.Prepare(id)
+
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invoice not found",
+		})
+		return
+	}
+
+	cn.writeHTML(c, document)
+}
+
+func (cn *controller) prepare(c *gin.Context) (Document, bool) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid payment ID",
+		})
+		return Document{}, false
+	}
+
+	document, err := cn.invoiceService.Prepare(id)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "Payment not found" {
+			statusCode = http.StatusNotFound
+		}
+		if strings.HasPrefix(err.Error(), "Cannot issue invoice") {
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return Document{}, false
+	}
+
+	return document, true
+}
+
+func (cn *controller) writeHTML(c *gin.Context, document Document) {
+	html, err := cn.invoiceService.RenderHTML(document)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.Data(http.StatusOK, "text/html; charset=utf-8", html)
+}
@@ -0,0 +1,18 @@
+package invoice
+
+import "time"
+
+type Invoice struct {
+	ID        uint64    `gorm:"column:id;primaryKey;autoIncrement"`
+	PaymentID uint64    `gorm:"column:payment_id;uniqueIndex"`
+	Number    string    `gorm:"column:number;type:varchar(64);uniqueIndex"`
+	Period    string    `gorm:"column:period;type:varchar(6)"`
+	Sequence  int       `gorm:"column:sequence"`
+	IssuedAt  time.Time `gorm:"column:issued_at"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+type InvoiceSequence struct {
+	Period     string `gorm:"column:period;type:varchar(6);primaryKey"`
+	LastNumber int    `gorm:"column:last_number"`
+}
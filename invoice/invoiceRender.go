@@ -0,0 +1,286 @@
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+	"taman-pempek/payment"
+)
+
+func renderPDF(document Document, logo *pdfImage) []byte {
+	d := newPDF()
+	d.image = logo
+
+	margin := 50.0
+	right := pageWidth - margin
+	y := pageHeight - 60
+
+	title := "INVOICE"
+	if document.Paid {
+		title = "RECEIPT"
+	}
+
+	textX := margin
+
+	if logo != nil {
+		height := 48.0
+		width := height * float64(logo.width) / float64(logo.height)
+		if width > 120 {
+			width = 120
+			height = width * float64(logo.height) / float64(logo.width)
+		}
+		d.drawImage(margin, y-height+12, width, height)
+		textX = margin + width + 12
+	}
+
+	d.text(textX, y, 18, true, storeName)
+	d.text(textX, y-16, 9, false, truncate(document.Store.Description, 60))
+	d.text(textX, y-28, 9, false, storeContact(document))
+
+	d.textRight(right, y, 20, true, title)
+	d.textRight(right, y-18, 10, false, document.Invoice.Number)
+	d.textRight(right, y-31, 9, false, "Issued "+document.Invoice.IssuedAt.Format("02 Jan 2006"))
+
+	y -= 60
+	d.line(margin, y, right, y, 0.8)
+	y -= 20
+
+	d.text(margin, y, 10, true, "Bill to")
+	d.text(330, y, 10, true, "Order")
+	y -= 14
+
+	billTo := []string{document.Buyer.Name, document.Payment.Whatsapp}
+	billTo = append(billTo, wrap(document.Payment.Address, 45)...)
+
+	order := []string{
+		fmt.Sprintf("Payment #%d", document.Payment.ID),
+		"Status: " + strings.ReplaceAll(document.Payment.PaymentStatus, "_", " "),
+		"Delivery: " + document.Payment.DeliveryName,
+	}
+
+	for i := 0; i < len(billTo) || i < len(order); i++ {
+		if i < len(billTo) {
+			d.text(margin, y, 9, false, billTo[i])
+		}
+		if i < len(order) {
+			d.text(330, y, 9, false, order[i])
+		}
+		y -= 12
+	}
+
+	y -= 14
+
+	tableHeader := func() {
+		d.fillRect(margin, y-5, right-margin, 18, 0.92)
+		d.text(margin+6, y, 9, true, "No")
+		d.text(margin+34, y, 9, true, "Item")
+		d.textRight(370, y, 9, true, "Qty")
+		d.textRight(460, y, 9, true, "Price")
+		d.textRight(right-6, y, 9, true, "Total")
+		y -= 20
+	}
+
+	tableHeader()
+
+	for i, line := range document.Lines {
+		if y < 150 {
+			d.addPage()
+			y = pageHeight - 60
+			tableHeader()
+		}
+
+		d.text(margin+6, y, 9, false, fmt.Sprintf("%d", i+1))
+		d.text(margin+34, y, 9, false, truncate(line.Name, 42))
+		d.textRight(370, y, 9, false, fmt.Sprintf("%d", line.Quantity))
+		d.textRight(460, y, 9, false, formatRupiah(line.Price))
+		d.textRight(right-6, y, 9, false, formatRupiah(line.Total))
+		y -= 6
+		d.line(margin, y, right, y, 0.3)
+		y -= 12
+	}
+
+	y -= 6
+
+	for _, total := range totals(document) {
+		d.text(330, y, 9, total.bold, total.label)
+		d.textRight(right-6, y, 9, total.bold, formatRupiah(total.amount))
+		y -= 14
+	}
+
+	y -= 20
+
+	d.text(margin, y, 10, true, paymentNote(document))
+
+	d.text(margin, 40, 8, false, fmt.Sprintf("%s - %s", storeName, document.Invoice.Number))
+
+	return d.bytes()
+}
+
+type total struct {
+	label  string
+	amount int
+	bold   bool
+}
+
+func totals(document Document) []total {
+	rows := []total{{label: "Subtotal", amount: document.Subtotal}}
+
+	if document.Payment.ShippingFee > 0 {
+		label := strings.TrimSpace("Shipping " + strings.ToUpper(document.Payment.ShippingCourier) + " " + document.Payment.ShippingService)
+		rows = append(rows, total{label: label, amount: document.Payment.ShippingFee})
+	}
+
+	if document.Payment.UniqueCode > 0 {
+		rows = append(rows, total{label: "Unique transfer code", amount: document.Payment.UniqueCode})
+	}
+
+	rows = append(rows, total{label: "Total", amount: amountDue(document), bold: true})
+
+	if document.Payment.RefundedAmount > 0 {
+		rows = append(rows, total{label: "Refunded", amount: -document.Payment.RefundedAmount})
+	}
+
+	return rows
+}
+
+func amountDue(document Document) int {
+	if document.Payment.TransferAmount > 0 {
+		return document.Payment.TransferAmount
+	}
+	if document.Payment.ShippingFee > 0 {
+		return document.Subtotal + document.Payment.ShippingFee
+	}
+	return document.Subtotal
+}
+
+func paymentNote(document Document) string {
+	switch {
+	case document.Paid:
+		return "PAID - thank you for your order."
+	case document.Payment.PaymentMethod == payment.MethodCOD:
+		return fmt.Sprintf("Please pay %s in cash to the courier on delivery.", formatRupiah(amountDue(document)))
+	case document.Payment.PaymentMethod == payment.MethodPickup:
+		return fmt.Sprintf("Please pay %s in cash when you pick up your order.", formatRupiah(amountDue(document)))
+	}
+	return fmt.Sprintf("Please transfer exactly %s so we can match your payment.", formatRupiah(amountDue(document)))
+}
+
+func storeContact(document Document) string {
+	contact := []string{}
+
+	if document.Store.Email != "" {
+		contact = append(contact, document.Store.Email)
+	}
+	if document.Store.Instagram != "" {
+		contact = append(contact, "@"+strings.TrimPrefix(document.Store.Instagram, "@"))
+	}
+	if document.Store.Website != "" {
+		contact = append(contact, document.Store.Website)
+	}
+
+	return strings.Join(contact, "  |  ")
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max-3]) + "..."
+}
+
+func wrap(s string, width int) []string {
+	lines := []string{}
+	current := ""
+
+	for _, word := range strings.Fields(s) {
+		if current != "" && len(current)+1+len(word) > width {
+			lines = append(lines, current)
+			current = word
+			continue
+		}
+		if current != "" {
+			current += " "
+		}
+		current += word
+	}
+
+	if current != "" {
+		lines = append(lines, current)
+	}
+
+	return lines
+}
+
+var htmlTemplate = template.Must(template.New("invoice").Funcs(template.FuncMap{
+	"rupiah": formatRupiah,
+	"inc":    func(i int) int { return i + 1 },
+}).Parse(`<!DOCTYPE html>
+<html lang="id">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}} {{.Document.Invoice.Number}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; color: #222; max-width: 640px; margin: 0 auto; padding: 16px; }
+header { display: flex; justify-content: space-between; align-items: flex-start; border-bottom: 1px solid #ccc; padding-bottom: 12px; }
+header img { max-height: 48px; margin-right: 12px; }
+h1 { font-size: 20px; margin: 0; }
+small { color: #666; }
+table { width: 100%; border-collapse: collapse; margin-top: 16px; font-size: 14px; }
+th { background: #eee; text-align: left; }
+th, td { padding: 6px; border-bottom: 1px solid #ddd; }
+td.num, th.num { text-align: right; }
+.totals td { border: none; }
+.note { margin-top: 16px; font-weight: bold; }
+</style>
+</head>
+<body>
+<header>
+<div style="display:flex">
+{{if .Document.Store.Image}}<img src="{{.Document.Store.Image}}" alt="">{{end}}
+<div><h1>{{.Store}}</h1><small>{{.Document.Store.Description}}</small><br><small>{{.Contact}}</small></div>
+</div>
+<div style="text-align:right"><h1>{{.Title}}</h1><div>{{.Document.Invoice.Number}}</div><small>Issued {{.Document.Invoice.IssuedAt.Format "02 Jan 2006"}}</small></div>
+</header>
+<p><strong>Bill to</strong><br>{{.Document.Buyer.Name}}<br>{{.Document.Payment.Whatsapp}}<br>{{.Document.Payment.Address}}</p>
+<p><strong>Order</strong><br>Payment #{{.Document.Payment.ID}}<br>Status: {{.Status}}<br>Delivery: {{.Document.Payment.DeliveryName}}</p>
+<table>
+<tr><th>No</th><th>Item</th><th class="num">Qty</th><th class="num">Price</th><th class="num">Total</th></tr>
+{{range $i, $line := .Document.Lines}}<tr><td>{{inc $i}}</td><td>{{$line.Name}}</td><td class="num">{{$line.Quantity}}</td><td class="num">{{rupiah $line.Price}}</td><td class="num">{{rupiah $line.Total}}</td></tr>
+{{end}}</table>
+<table class="totals">
+{{range .Totals}}<tr><td></td><td class="num">{{if .bold}}<strong>{{.label}}</strong>{{else}}{{.label}}{{end}}</td><td class="num">{{if .bold}}<strong>{{rupiah .amount}}</strong>{{else}}{{rupiah .amount}}{{end}}</td></tr>
+{{end}}</table>
+<p class="note">{{.Note}}</p>
+</body>
+</html>
+`))
+
+func renderHTML(document Document) ([]byte, error) {
+	title := "Invoice"
+	if document.Paid {
+		title = "Receipt"
+	}
+
+	rows := []map[string]any{}
+	for _, t := range totals(document) {
+		rows = append(rows, map[string]any{"label": t.label, "amount": t.amount, "bold": t.bold})
+	}
+
+	var out bytes.Buffer
+
+	err := htmlTemplate.Execute(&out, map[string]any{
+		"Title":     title,
+		"Store":     storeName,
+		"Contact":   storeContact(document),
+		"Status":    strings.ReplaceAll(document.Payment.PaymentStatus, "_", " "),
+		"Document":  document,
+		"Totals":    rows,
+		"AmountDue": amountDue(document),
+		"Note":      paymentNote(document),
+	})
+
+	return out.Bytes(), err
+}
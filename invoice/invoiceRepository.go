@@ -0,0 +1,60 @@
+package invoice
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InvoiceRepository interface {
+	FindInvoiceByPayment(paymentID uint64) (Invoice, error)
+	IssueInvoice(paymentID uint64, issuedAt time.Time) (Invoice, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) FindInvoiceByPayment(paymentID uint64) (Invoice, error) {
+	var invoice Invoice
+	err := r.db.Where("payment_id = ?", paymentID).First(&invoice).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Invoice{}, errors.New("Invoice not found")
+	}
+	return invoice, err
+}
+
+func (r *repository) IssueInvoice(paymentID uint64, issuedAt time.Time) (Invoice, error) {
+	period := issuedAt.Format("200601")
+	invoice := Invoice{PaymentID: paymentID, Period: period, IssuedAt: issuedAt}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]any{"last_number": gorm.Expr("last_number + 1")}),
+		}).Create(&InvoiceSequence{Period: period, LastNumber: 1}).Error
+
+		if err != nil {
+			return err
+		}
+
+		var sequence InvoiceSequence
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&sequence, "period = ?", period).Error; err != nil {
+			return err
+		}
+
+		invoice.Sequence = sequence.LastNumber
+		invoice.Number = fmt.Sprintf("INV-%s-%04d", period, sequence.LastNumber)
+
+		return tx.Create(&invoice).Error
+	})
+
+	return invoice, err
+}
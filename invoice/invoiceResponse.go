@@ -0,0 +1,15 @@
+package invoice
+
+import "time"
+
+type InvoiceResponse struct {
+	Number         string    `json:"number"`
+	PaymentID      uint64    `json:"payment_id"`
+	IssuedAt       time.Time `json:"issued_at"`
+	Paid           bool      `json:"paid"`
+	ShareToken     string    `json:"share_token"`
+	ShareExpiresAt time.Time `json:"share_expires_at"`
+	PDFPath        string    `json:"pdf_path"`
+	HTMLPath       string    `json:"html_path"`
+	SharePath      string    `json:"share_path"`
+}
@@ -0,0 +1,274 @@
+package invoice
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"taman-pempek/cart"
+	"taman-pempek/payment"
+	"taman-pempek/product"
+	"taman-pempek/setting"
+	"taman-pempek/user"
+	"time"
+)
+
+const (
+	storeName     = "Taman Pempek"
+	shareLifetime = 30 * 24 * time.Hour
+)
+
+type Line struct {
+	Name     string
+	Quantity int
+	Price    int
+	Total    int
+}
+
+type Document struct {
+	Invoice  Invoice
+	Payment  payment.Payment
+	Buyer    user.User
+	Store    setting.Setting
+	Lines    []Line
+	Subtotal int
+	Paid     bool
+}
+
+type InvoiceService interface {
+	Prepare(paymentID int) (Document, error)
+	RenderPDF(document Document) []byte
+	RenderHTML(document Document) ([]byte, error)
+	ShareToken(paymentID uint64) (string, time.Time)
+	PaymentFromShareToken(token string) (int, error)
+}
+
+var unbillableStatuses = []string{payment.StatusCancelled, payment.StatusExpired}
+
+var paidStatuses = []string{
+	payment.StatusVerified,
+	payment.StatusProcessing,
+	payment.StatusShipped,
+	payment.StatusDelivered,
+	payment.StatusCompleted,
+	payment.StatusRefunded,
+}
+
+var cashPaidStatuses = []string{
+	payment.StatusDelivered,
+	payment.StatusCompleted,
+	payment.StatusRefunded,
+}
+
+type service struct {
+	invoiceRepository InvoiceRepository
+	paymentService    payment.PaymentService
+	cartService       cart.CartService
+	productService    product.ProductService
+	settingService    setting.SettingService
+	userService       user.UserService
+}
+
+func NewService(invoiceRepository InvoiceRepository, paymentService payment.PaymentService, cartService cart.CartService, productService product.ProductService, settingService setting.SettingService, userService user.UserService) *service {
+	return &service{invoiceRepository, paymentService, cartService, productService, settingService, userService}
+}
+
+func (s *service) Prepare(paymentID int) (Document, error) {
+	p, err := s.paymentService.FindPaymentByID(paymentID)
+
+	if err != nil {
+		return Document{}, err
+	}
+
+	if contains(unbillableStatuses, p.PaymentStatus) {
+		return Document{}, fmt.Errorf("Cannot issue invoice for payment with status %s", p.PaymentStatus)
+	}
+
+	invoice, err := s.invoiceRepository.FindInvoiceByPayment(p.ID)
+
+	if err != nil {
+		invoice, err = s.invoiceRepository.IssueInvoice(p.ID, time.Now())
+	}
+
+	if err != nil {
+		if existing, findErr := s.invoiceRepository.FindInvoiceByPayment(p.ID); findErr == nil {
+			invoice, err = existing, nil
+		} else {
+			return Document{}, err
+		}
+	}
+
+	document := Document{
+		Invoice: invoice,
+		Payment: p,
+		Paid:    isPaid(p),
+	}
+
+	document.Store, _ = s.settingService.FindSettingByID(setting.StoreSettingID)
+	document.Buyer, _ = s.userService.FindUserByID(p.UserID)
+
+	carts, _ := s.cartService.FindCartsByPaymentID(int(p.ID))
+
+	for _, c := range carts {
+		productID, _ := strconv.Atoi(c.ProductID.String())
+		quantity, _ := strconv.Atoi(c.Quantity.String())
+		total, _ := strconv.Atoi(c.TotalPrice.String())
+
+		line := Line{Name: fmt.Sprintf("Product #%d", productID), Quantity: quantity, Total: total}
+
+		if item, err := s.productService.FindProductByID(productID); err == nil {
+			line.Name = item.Name
+		}
+
+		if c.Label != "" {
+			line.Name += " (" + c.Label + ")"
+		}
+
+		if quantity > 0 {
+			line.Price = total / quantity
+		}
+
+		document.Lines = append(document.Lines, line)
+		document.Subtotal += total
+	}
+
+	if len(document.Lines) == 0 {
+		document.Lines = append(document.Lines, Line{Name: "Order " + invoice.Number, Quantity: 1, Price: p.TotalPrice, Total: p.TotalPrice})
+		document.Subtotal = p.TotalPrice
+	}
+
+	return document, nil
+}
+
+func (s *service) RenderPDF(document Document) []byte {
+	return renderPDF(document, fetchLogo(document.Store.Image))
+}
+
+func (s *service) RenderHTML(document Document) ([]byte, error) {
+	return renderHTML(document)
+}
+
+func (s *service) ShareToken(paymentID uint64) (string, time.Time) {
+	expiresAt := time.Now().Add(shareLifetime)
+	payload := strconv.FormatUint(paymentID, 10) + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	return payload + "." + shareSignature(payload), expiresAt
+}
+
+func (s *service) PaymentFromShareToken(token string) (int, error) {
+	parts := strings.Split(token, ".")
+
+	if len(parts) != 3 {
+		return 0, errors.New("Invalid invoice link")
+	}
+
+	payload := parts[0] + "." + parts[1]
+
+	if !hmac.Equal([]byte(parts[2]), []byte(shareSignature(payload))) {
+		return 0, errors.New("Invalid invoice link")
+	}
+
+	expiresAt, err := strconv.ParseInt(parts[1], 10, 64)
+
+	if err != nil {
+		return 0, errors.New("Invalid invoice link")
+	}
+
+	if time.Now().Unix() > expiresAt {
+		return 0, errors.New("Invoice link has expired")
+	}
+
+	return strconv.Atoi(parts[0])
+}
+
+func shareSignature(payload string) string {
+	mac := hmac.New(sha256.New, []byte(os.Getenv("SECRET")))
+	mac.Write([]byte("invoice:" + payload))
+	return hex.EncodeToString(mac.Sum(nil))[:32]
+}
+
+func fetchLogo(url string) *pdfImage {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return nil
+	}
+
+	client := http.Client{Timeout: 3 * time.Second}
+	response, err := client.Get(url)
+
+	if err != nil {
+		return nil
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(response.Body, 2<<20))
+
+	if err != nil {
+		return nil
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+
+	if err != nil || format != "jpeg" {
+		return nil
+	}
+
+	colorSpace := "DeviceRGB"
+
+	switch config.ColorModel {
+	case color.GrayModel:
+		colorSpace = "DeviceGray"
+	case color.CMYKModel:
+		return nil
+	}
+
+	return &pdfImage{data: data, width: config.Width, height: config.Height, colorSpace: colorSpace}
+}
+
+func formatRupiah(amount int) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.Itoa(amount)
+	var b strings.Builder
+
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(d)
+	}
+
+	return sign + "Rp " + b.String()
+}
+
+func isPaid(p payment.Payment) bool {
+	if payment.IsCashMethod(p.PaymentMethod) {
+		return contains(cashPaidStatuses, p.PaymentStatus)
+	}
+	return contains(paidStatuses, p.PaymentStatus)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package invoice
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShareTokenRoundTrip(t *testing.T) {
+	s := &service{}
+
+	token, expiresAt := s.ShareToken(42)
+
+	if expiresAt.Before(time.Now().Add(shareLifetime - time.Minute)) {
+		t.Errorf("expires at %v", expiresAt)
+	}
+
+	id, err := s.PaymentFromShareToken(token)
+	if err != nil || id != 42 {
+		t.Fatalf("PaymentFromShareToken = %d, %v", id, err)
+	}
+}
+
+func TestShareTokenRejectsTamperedAndExpired(t *testing.T) {
+	s := &service{}
+	token, _ := s.ShareToken(42)
+	parts := strings.Split(token, ".")
+
+	for _, tampered := range []string{
+		"43." + parts[1] + "." + parts[2],
+		parts[0] + "." + strconv.FormatInt(time.Now().Add(365*24*time.Hour).Unix(), 10) + "." + parts[2],
+		parts[0] + "." + parts[2],
+	} {
+		if _, err := s.PaymentFromShareToken(tampered); err == nil || err.Error() != "Invalid invoice link" {
+			t.Errorf("PaymentFromShareToken(%q) = %v", tampered, err)
+		}
+	}
+
+	payload := "42." + strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)
+
+	if _, err := s.PaymentFromShareToken(payload + "." + shareSignature(payload)); err == nil || err.Error() != "Invoice link has expired" {
+		t.Errorf("expired link = %v", err)
+	}
+}
@@ -0,0 +1,175 @@
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	pageWidth  = 595.0
+	pageHeight = 842.0
+)
+
+type pdfImage struct {
+	data       []byte
+	width      int
+	height     int
+	colorSpace string
+}
+
+type pdfDocument struct {
+	pages []*bytes.Buffer
+	image *pdfImage
+}
+
+func newPDF() *pdfDocument {
+	return &pdfDocument{}
+}
+
+func (d *pdfDocument) addPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+}
+
+func (d *pdfDocument) page() *bytes.Buffer {
+	if len(d.pages) == 0 {
+		d.addPage()
+	}
+	return d.pages[len(d.pages)-1]
+}
+
+func (d *pdfDocument) text(x float64, y float64, size float64, bold bool, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(d.page(), "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, pdfString(s))
+}
+
+func (d *pdfDocument) textRight(right float64, y float64, size float64, bold bool, s string) {
+	d.text(right-textWidth(s, size, bold), y, size, bold, s)
+}
+
+func (d *pdfDocument) line(x1 float64, y1 float64, x2 float64, y2 float64, width float64) {
+	fmt.Fprintf(d.page(), "%.2f w %.2f %.2f m %.2f %.2f l S\n", width, x1, y1, x2, y2)
+}
+
+func (d *pdfDocument) fillRect(x float64, y float64, w float64, h float64, gray float64) {
+	fmt.Fprintf(d.page(), "q %.2f g %.2f %.2f %.2f %.2f re f Q\n", gray, x, y, w, h)
+}
+
+func (d *pdfDocument) drawImage(x float64, y float64, w float64, h float64) {
+	if d.image == nil {
+		return
+	}
+	fmt.Fprintf(d.page(), "q %.2f 0 0 %.2f %.2f %.2f cm /Im1 Do Q\n", w, h, x, y)
+}
+
+func (d *pdfDocument) bytes() []byte {
+	if len(d.pages) == 0 {
+		d.addPage()
+	}
+
+	var out bytes.Buffer
+	offsets := []int{}
+
+	object := func(body string, stream []byte) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\n", len(offsets), body)
+		if stream != nil {
+			out.WriteString("stream\n")
+			out.Write(stream)
+			out.WriteString("\nendstream\n")
+		}
+		out.WriteString("endobj\n")
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	firstPage := 5
+	if d.image != nil {
+		firstPage = 6
+	}
+
+	kids := []string{}
+	for i := range d.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", firstPage+i*2))
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>", nil)
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)), nil)
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>", nil)
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>", nil)
+
+	resources := "<< /Font << /F1 3 0 R /F2 4 0 R >> >>"
+
+	if d.image != nil {
+		object(fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /DCTDecode /Length %d >>", d.image.width, d.image.height, d.image.colorSpace, len(d.image.data)), d.image.data)
+		resources = "<< /Font << /F1 3 0 R /F2 4 0 R >> /XObject << /Im1 5 0 R >> >>"
+	}
+
+	for i, content := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources %s /Contents %d 0 R >>", pageWidth, pageHeight, resources, firstPage+i*2+1), nil)
+		object(fmt.Sprintf("<< /Length %d >>", content.Len()), content.Bytes())
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
+
+func pdfString(s string) string {
+	var b strings.Builder
+
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n' || r == '\r' || r == '\t':
+			b.WriteByte(' ')
+		case r < 32:
+		case r < 128:
+			b.WriteRune(r)
+		case r >= 160 && r <= 255:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+
+	return b.String()
+}
+
+var helveticaWidths = map[rune]float64{
+	' ': 278, '.': 278, ',': 278, '-': 333, ':': 278, '/': 278, '#': 556,
+	'R': 722, 'p': 556, 'I': 278, 'N': 722, 'V': 667,
+}
+
+func textWidth(s string, size float64, bold bool) float64 {
+	width := 0.0
+
+	for _, r := range s {
+		w, ok := helveticaWidths[r]
+
+		if !ok {
+			w = 556
+			if r >= 'A' && r <= 'Z' {
+				w = 667
+			}
+		}
+
+		width += w
+	}
+
+	if bold {
+		width *= 1.05
+	}
+
+	return width * size / 1000
+}
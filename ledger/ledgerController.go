@@ -0,0 +1,338 @@
+package ledger
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type controller struct {
+	ledgerService LedgerService
+}
+
+func NewController(ledgerService LedgerService) *controller {
+	return &controller{ledgerService}
+}
+
+func (cn *controller) GetSellerBalance(c *gin.Context) {
+	userIDString := c.Param("userId")
+	userID, err := strconv.Atoi(userIDString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid user ID",
+		})
+		return
+	}
+
+	balance, err := cn.ledgerService.FindSellerBalance(userID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToSellerBalanceResponse(balance),
+	})
+}
+
+func (cn *controller) GetSellerBalances(c *gin.Context) {
+	balances, err := cn.ledgerService.FindSellerBalances()
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	balancesResponse := []SellerBalanceResponse{}
+
+	for _, balance := range balances {
+		balancesResponse = append(balancesResponse, convertToSellerBalanceResponse(balance))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  balancesResponse,
+	})
+}
+
+func (cn *controller) GetSellerStatement(c *gin.Context) {
+	userIDString := c.Param("userId")
+	userID, err := strconv.Atoi(userIDString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid user ID",
+		})
+		return
+	}
+
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	to := from.AddDate(0, 1, 0)
+
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", value, now.Location())
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": true,
+				"data":  nil,
+				"msg":   "Invalid from date, expected YYYY-MM-DD",
+			})
+			return
+		}
+
+		from = parsed
+	}
+
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", value, now.Location())
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": true,
+				"data":  nil,
+				"msg":   "Invalid to date, expected YYYY-MM-DD",
+			})
+			return
+		}
+
+		to = parsed.AddDate(0, 0, 1)
+	}
+
+	statement, err := cn.ledgerService.FindStatement(userID, from, to)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	linesResponse := []StatementLineResponse{}
+	balance := statement.OpeningBalance
+
+	for _, line := range statement.Lines {
+		balance += line.Credit - line.Debit
+		linesResponse = append(linesResponse, StatementLineResponse{
+			EntryID:     line.EntryID,
+			JournalID:   line.JournalID,
+			Type:        line.Type,
+			Reference:   line.Reference,
+			Description: line.Description,
+			Debit:       line.Debit,
+			Credit:      line.Credit,
+			Balance:     balance,
+			CreatedAt:   line.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data": StatementResponse{
+			SellerID:       statement.SellerID,
+			From:           statement.From,
+			To:             statement.To,
+			OpeningBalance: statement.OpeningBalance,
+			ClosingBalance: statement.ClosingBalance,
+			Lines:          linesResponse,
+		},
+	})
+}
+
+func (cn *controller) CreatePayoutBatch(c *gin.Context) {
+	var batchRequest PayoutBatchCreateRequest
+
+	c.ShouldBindJSON(&batchRequest)
+
+	batch, err := cn.ledgerService.CreatePayoutBatch(batchRequest.Minimum)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "No seller balance is eligible for payout" {
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToPayoutBatchResponse(batch),
+	})
+}
+
+func (cn *controller) GetPayoutBatches(c *gin.Context) {
+	batches, err := cn.ledgerService.FindPayoutBatches()
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	batchesResponse := []PayoutBatchResponse{}
+
+	for _, batch := range batches {
+		batchesResponse = append(batchesResponse, convertToPayoutBatchResponse(batch))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  batchesResponse,
+	})
+}
+
+func (cn *controller) GetPayoutBatch(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid payout batch ID",
+		})
+		return
+	}
+
+	batch, err := cn.ledgerService.FindPayoutBatchByID(id)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "Payout batch not found" {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToPayoutBatchResponse(batch),
+	})
+}
+
+func (cn *controller) MarkPayoutBatchPaid(c *gin.Context) {
+	var paidRequest PayoutBatchPaidRequest
+
+	err := c.ShouldBindJSON(&paidRequest)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Error on Reference field, condition required",
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid payout batch ID",
+		})
+		return
+	}
+
+	batch, err := cn.ledgerService.MarkPayoutBatchPaid(id, paidRequest.Reference)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "Payout batch not found" {
+			statusCode = http.StatusNotFound
+		}
+		if err.Error() == "Payout batch is already paid" {
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToPayoutBatchResponse(batch),
+	})
+}
+
+func convertToSellerBalanceResponse(balance SellerBalance) SellerBalanceResponse {
+	return SellerBalanceResponse{
+		SellerID:  balance.SellerID,
+		Available: balance.Available,
+		InTransit: balance.InTransit,
+		PaidOut:   balance.PaidOut,
+	}
+}
+
+func convertToPayoutBatchResponse(batch PayoutBatch) PayoutBatchResponse {
+	payouts := []PayoutResponse{}
+
+	for _, payout := range batch.Payouts {
+		payouts = append(payouts, PayoutResponse{
+			ID:         payout.ID,
+			SellerID:   payout.SellerID,
+			BankID:     payout.BankID,
+			BankName:   payout.BankName,
+			BankNumber: payout.BankNumber,
+			Amount:     payout.Amount,
+			Status:     payout.Status,
+			Reference:  payout.Reference,
+			PaidAt:     payout.PaidAt,
+		})
+	}
+
+	return PayoutBatchResponse{
+		ID:        batch.ID,
+		Status:    batch.Status,
+		Total:     batch.Total,
+		Reference: batch.Reference,
+		PaidAt:    batch.PaidAt,
+		Payouts:   payouts,
+		CreatedAt: batch.CreatedAt,
+	}
+}
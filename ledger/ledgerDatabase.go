@@ -0,0 +1,48 @@
+package ledger
+
+import "time"
+
+const (
+	AccountClearing      = "clearing"
+	AccountSellerPayable = "seller_payable"
+	AccountCommission    = "commission"
+	AccountPayoutTransit = "payout_in_transit"
+	AccountCash          = "cash"
+	AccountShipping      = "shipping"
+)
+
+const (
+	JournalSale       = "sale"
+	JournalRefund     = "refund"
+	JournalPayout     = "payout"
+	JournalPayoutPaid = "payout_paid"
+)
+
+type Journal struct {
+	ID             uint64    `gorm:"column:id;primaryKey;autoIncrement"`
+	Type           string    `gorm:"column:type;type:varchar(64)"`
+	Reference      string    `gorm:"column:reference;type:varchar(191);uniqueIndex"`
+	Description    string    `gorm:"column:description;type:varchar(255)"`
+	PaymentID      uint64    `gorm:"column:payment_id;index"`
+	CommissionRate int       `gorm:"column:commission_rate"`
+	Entries        []Entry   `gorm:"foreignKey:JournalID"`
+	CreatedAt      time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (Journal) TableName() string {
+	return "ledger_journals"
+}
+
+type Entry struct {
+	ID        uint64    `gorm:"column:id;primaryKey;autoIncrement"`
+	JournalID uint64    `gorm:"column:journal_id;index"`
+	Account   string    `gorm:"column:account;type:varchar(64);index:idx_ledger_account"`
+	SellerID  int       `gorm:"column:seller_id;index:idx_ledger_account"`
+	Debit     int       `gorm:"column:debit"`
+	Credit    int       `gorm:"column:credit"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (Entry) TableName() string {
+	return "ledger_entries"
+}
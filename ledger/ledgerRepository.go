@@ -0,0 +1,203 @@
+package ledger
+
+import (
+	"errors"
+	"taman-pempek/bank"
+	"taman-pempek/cart"
+	"taman-pempek/payment"
+	"taman-pempek/product"
+	"taman-pempek/refund"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repositories struct {
+	Ledger  LedgerRepository
+	Bank    bank.BankRepository
+	Cart    cart.CartRepository
+	Product product.ProductRepository
+	Refund  refund.RefundRepository
+}
+
+type Balance struct {
+	SellerID int
+	Amount   int
+}
+
+type StatementLine struct {
+	EntryID     uint64
+	JournalID   uint64
+	Type        string
+	Reference   string
+	Description string
+	Debit       int
+	Credit      int
+	CreatedAt   time.Time
+}
+
+type LedgerRepository interface {
+	Transaction(fn func(repositories Repositories) error) error
+	FindJournalByReference(reference string) (Journal, error)
+	CreateJournal(journal Journal) (Journal, error)
+	FindSettlablePayments() ([]payment.Payment, error)
+	MarkPaymentSettled(ID uint64) (bool, error)
+	FindUnpostedRefunds() ([]refund.Refund, error)
+	SumSellerAccount(sellerID int, account string, before time.Time) (int, int, error)
+	FindSellerBalances(account string) ([]Balance, error)
+	LockSellerBalances(account string) ([]Balance, error)
+	FindSellerEntries(sellerID int, account string, from time.Time, to time.Time) ([]StatementLine, error)
+	CreatePayoutBatch(batch PayoutBatch) (PayoutBatch, error)
+	UpdatePayoutBatch(batch PayoutBatch) (PayoutBatch, error)
+	LockPayoutBatchByID(ID int) (PayoutBatch, error)
+	FindPayoutBatchByID(ID int) (PayoutBatch, error)
+	FindPayoutBatches() ([]PayoutBatch, error)
+	CreatePayout(payout Payout) (Payout, error)
+	UpdatePayout(payout Payout) (Payout, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) Transaction(fn func(repositories Repositories) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(Repositories{
+			Ledger:  NewRepository(tx),
+			Bank:    bank.NewRepository(tx),
+			Cart:    cart.NewRepository(tx),
+			Product: product.NewRepository(tx),
+			Refund:  refund.NewRepository(tx),
+		})
+	})
+}
+
+func (r *repository) FindJournalByReference(reference string) (Journal, error) {
+	var journal Journal
+	err := r.db.Preload("Entries").Where("reference = ?", reference).First(&journal).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Journal{}, errors.New("Journal not found")
+	}
+	return journal, err
+}
+
+func (r *repository) CreateJournal(journal Journal) (Journal, error) {
+	err := r.db.Create(&journal).Error
+	return journal, err
+}
+
+func (r *repository) FindSettlablePayments() ([]payment.Payment, error) {
+	var payments []payment.Payment
+	err := r.db.Where("payment_status = ? AND settled_at IS NULL", payment.StatusCompleted).Order("id").Find(&payments).Error
+	return payments, err
+}
+
+func (r *repository) MarkPaymentSettled(ID uint64) (bool, error) {
+	result := r.db.Model(&payment.Payment{}).
+		Where("id = ? AND settled_at IS NULL", ID).
+		Update("settled_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *repository) FindUnpostedRefunds() ([]refund.Refund, error) {
+	var refunds []refund.Refund
+	err := r.db.Preload("Items").
+		Joins("JOIN payments ON payments.id = refunds.payment_id AND payments.settled_at IS NOT NULL").
+		Where("refunds.status = ?", refund.StatusPaid).
+		Where("NOT EXISTS (SELECT 1 FROM ledger_journals WHERE ledger_journals.reference = CONCAT('refund:', refunds.id))").
+		Order("refunds.id").
+		Find(&refunds).Error
+	return refunds, err
+}
+
+func (r *repository) SumSellerAccount(sellerID int, account string, before time.Time) (int, int, error) {
+	var sums struct {
+		Debit  int
+		Credit int
+	}
+	err := r.db.Model(&Entry{}).
+		Select("COALESCE(SUM(debit), 0) AS debit, COALESCE(SUM(credit), 0) AS credit").
+		Where("seller_id = ? AND account = ? AND created_at < ?", sellerID, account, before).
+		Scan(&sums).Error
+	return sums.Debit, sums.Credit, err
+}
+
+func (r *repository) FindSellerBalances(account string) ([]Balance, error) {
+	return r.sellerBalances(r.db, account)
+}
+
+func (r *repository) LockSellerBalances(account string) ([]Balance, error) {
+	return r.sellerBalances(r.db.Clauses(clause.Locking{Strength: "UPDATE"}), account)
+}
+
+func (r *repository) sellerBalances(db *gorm.DB, account string) ([]Balance, error) {
+	var balances []Balance
+	err := db.Model(&Entry{}).
+		Select("seller_id, SUM(credit) - SUM(debit) AS amount").
+		Where("account = ? AND seller_id > 0", account).
+		Group("seller_id").
+		Order("seller_id").
+		Scan(&balances).Error
+	return balances, err
+}
+
+func (r *repository) FindSellerEntries(sellerID int, account string, from time.Time, to time.Time) ([]StatementLine, error) {
+	var lines []StatementLine
+	err := r.db.Model(&Entry{}).
+		Select("ledger_entries.id AS entry_id, ledger_entries.journal_id, ledger_journals.type, ledger_journals.reference, ledger_journals.description, ledger_entries.debit, ledger_entries.credit, ledger_entries.created_at").
+		Joins("JOIN ledger_journals ON ledger_journals.id = ledger_entries.journal_id").
+		Where("ledger_entries.seller_id = ? AND ledger_entries.account = ?", sellerID, account).
+		Where("ledger_entries.created_at >= ? AND ledger_entries.created_at < ?", from, to).
+		Order("ledger_entries.id").
+		Scan(&lines).Error
+	return lines, err
+}
+
+func (r *repository) CreatePayoutBatch(batch PayoutBatch) (PayoutBatch, error) {
+	err := r.db.Create(&batch).Error
+	return batch, err
+}
+
+func (r *repository) UpdatePayoutBatch(batch PayoutBatch) (PayoutBatch, error) {
+	err := r.db.Omit(clause.Associations).Save(&batch).Error
+	return batch, err
+}
+
+func (r *repository) LockPayoutBatchByID(ID int) (PayoutBatch, error) {
+	var batch PayoutBatch
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Payouts").First(&batch, ID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return PayoutBatch{}, errors.New("Payout batch not found")
+	}
+	return batch, err
+}
+
+func (r *repository) FindPayoutBatchByID(ID int) (PayoutBatch, error) {
+	var batch PayoutBatch
+	err := r.db.Preload("Payouts").First(&batch, ID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return PayoutBatch{}, errors.New("Payout batch not found")
+	}
+	return batch, err
+}
+
+func (r *repository) FindPayoutBatches() ([]PayoutBatch, error) {
+	var batches []PayoutBatch
+	err := r.db.Preload("Payouts").Order("id DESC").Find(&batches).Error
+	return batches, err
+}
+
+func (r *repository) CreatePayout(payout Payout) (Payout, error) {
+	err := r.db.Create(&payout).Error
+	return payout, err
+}
+
+func (r *repository) UpdatePayout(payout Payout) (Payout, error) {
+	err := r.db.Save(&payout).Error
+	return payout, err
+}
@@ -0,0 +1,9 @@
+package ledger
+
+type PayoutBatchCreateRequest struct {
+	Minimum int `json:"minimum" binding:"omitempty,min=0"`
+}
+
+type PayoutBatchPaidRequest struct {
+	Reference string `json:"reference" binding:"required"`
+}
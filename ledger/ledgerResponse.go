@@ -0,0 +1,53 @@
+package ledger
+
+import "time"
+
+type SellerBalanceResponse struct {
+	SellerID  int `json:"seller_id"`
+	Available int `json:"available"`
+	InTransit int `json:"in_transit"`
+	PaidOut   int `json:"paid_out"`
+}
+
+type StatementLineResponse struct {
+	EntryID     uint64    `json:"entry_id"`
+	JournalID   uint64    `json:"journal_id"`
+	Type        string    `json:"type"`
+	Reference   string    `json:"reference"`
+	Description string    `json:"description"`
+	Debit       int       `json:"debit"`
+	Credit      int       `json:"credit"`
+	Balance     int       `json:"balance"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type StatementResponse struct {
+	SellerID       int                     `json:"seller_id"`
+	From           time.Time               `json:"from"`
+	To             time.Time               `json:"to"`
+	OpeningBalance int                     `json:"opening_balance"`
+	ClosingBalance int                     `json:"closing_balance"`
+	Lines          []StatementLineResponse `json:"lines"`
+}
+
+type PayoutResponse struct {
+	ID         uint64     `json:"id"`
+	SellerID   int        `json:"seller_id"`
+	BankID     uint64     `json:"bank_id"`
+	BankName   string     `json:"bank_name"`
+	BankNumber string     `json:"bank_number"`
+	Amount     int        `json:"amount"`
+	Status     string     `json:"status"`
+	Reference  string     `json:"reference"`
+	PaidAt     *time.Time `json:"paid_at"`
+}
+
+type PayoutBatchResponse struct {
+	ID        uint64           `json:"id"`
+	Status    string           `json:"status"`
+	Total     int              `json:"total"`
+	Reference string           `json:"reference"`
+	PaidAt    *time.Time       `json:"paid_at"`
+	Payouts   []PayoutResponse `json:"payouts"`
+	CreatedAt time.Time        `json:"created_at"`
+}
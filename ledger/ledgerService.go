@@ -0,0 +1,505 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"taman-pempek/refund"
+	"taman-pempek/setting"
+	"time"
+)
+
+type SellerBalance struct {
+	SellerID  int
+	Available int
+	InTransit int
+	PaidOut   int
+}
+
+type Statement struct {
+	SellerID       int
+	From           time.Time
+	To             time.Time
+	OpeningBalance int
+	ClosingBalance int
+	Lines          []StatementLine
+}
+
+type LedgerService interface {
+	SettleCompletedPayments() (int, error)
+	FindSellerBalance(sellerID int) (SellerBalance, error)
+	FindSellerBalances() ([]SellerBalance, error)
+	FindStatement(sellerID int, from time.Time, to time.Time) (Statement, error)
+	CreatePayoutBatch(minimum int) (PayoutBatch, error)
+	MarkPayoutBatchPaid(ID int, reference string) (PayoutBatch, error)
+	FindPayoutBatchByID(ID int) (PayoutBatch, error)
+	FindPayoutBatches() ([]PayoutBatch, error)
+}
+
+type service struct {
+	ledgerRepository LedgerRepository
+	settingService   setting.SettingService
+}
+
+func NewService(ledgerRepository LedgerRepository, settingService setting.SettingService) *service {
+	return &service{ledgerRepository, settingService}
+}
+
+func (s *service) SettleCompletedPayments() (int, error) {
+	rate := 0
+
+	if storeSetting, err := s.settingService.FindSettingByID(setting.StoreSettingID); err == nil {
+		rate = storeSetting.PlatformCommission
+	}
+
+	payments, err := s.ledgerRepository.FindSettlablePayments()
+
+	if err != nil {
+		return 0, err
+	}
+
+	settled := 0
+
+	for _, p := range payments {
+		err := s.ledgerRepository.Transaction(func(repositories Repositories) error {
+			ok, err := repositories.Ledger.MarkPaymentSettled(p.ID)
+
+			if err != nil || !ok {
+				return err
+			}
+
+			refunds, err := repositories.Refund.FindRefundsByPayment(int(p.ID))
+
+			if err != nil {
+				return err
+			}
+
+			refunded := map[uint64]int{}
+			refundedShipping := 0
+
+			for _, r := range refunds {
+				if r.Status != refund.StatusPaid {
+					continue
+				}
+
+				for cartID, amount := range refundedPerCart(r) {
+					refunded[cartID] += amount
+				}
+
+				refundedShipping += r.ShippingAmount
+
+				_, err := postJournal(repositories.Ledger, Journal{
+					Type:        JournalRefund,
+					Reference:   fmt.Sprintf("refund:%d", r.ID),
+					Description: fmt.Sprintf("Refund %d paid before settlement", r.ID),
+					PaymentID:   p.ID,
+				})
+
+				if err != nil {
+					return err
+				}
+			}
+
+			carts, err := repositories.Cart.FindCartsByPaymentID(int(p.ID))
+
+			if err != nil {
+				return err
+			}
+
+			for _, c := range carts {
+				productID, _ := strconv.Atoi(c.ProductID.String())
+				totalPrice, _ := strconv.Atoi(c.TotalPrice.String())
+				amount := totalPrice - refunded[c.ID]
+
+				if amount <= 0 {
+					continue
+				}
+
+				product, err := repositories.Product.FindProductByID(productID)
+
+				if err != nil {
+					return err
+				}
+
+				commission := amount * rate / 100
+				entries := []Entry{
+					{Account: AccountClearing, Debit: amount},
+					{Account: AccountSellerPayable, SellerID: product.UserID, Credit: amount - commission},
+				}
+
+				if commission > 0 {
+					entries = append(entries, Entry{Account: AccountCommission, Credit: commission})
+				}
+
+				_, err = postJournal(repositories.Ledger, Journal{
+					Type:           JournalSale,
+					Reference:      fmt.Sprintf("sale:cart:%d", c.ID),
+					Description:    fmt.Sprintf("Sale of %s on payment %d", product.Name, p.ID),
+					PaymentID:      p.ID,
+					CommissionRate: rate,
+					Entries:        entries,
+				})
+
+				if err != nil {
+					return err
+				}
+			}
+
+			if shipping := p.ShippingFee - refundedShipping; shipping > 0 {
+				_, err = postJournal(repositories.Ledger, Journal{
+					Type:        JournalSale,
+					Reference:   fmt.Sprintf("sale:shipping:%d", p.ID),
+					Description: fmt.Sprintf("Shipping fee on payment %d", p.ID),
+					PaymentID:   p.ID,
+					Entries: []Entry{
+						{Account: AccountClearing, Debit: shipping},
+						{Account: AccountShipping, Credit: shipping},
+					},
+				})
+
+				if err != nil {
+					return err
+				}
+			}
+
+			settled++
+
+			return nil
+		})
+
+		if err != nil {
+			return settled, err
+		}
+	}
+
+	return settled, s.postRefunds()
+}
+
+func (s *service) postRefunds() error {
+	refunds, err := s.ledgerRepository.FindUnpostedRefunds()
+
+	if err != nil {
+		return err
+	}
+
+	for _, r := range refunds {
+		err := s.ledgerRepository.Transaction(func(repositories Repositories) error {
+			entries := []Entry{}
+
+			for cartID, amount := range refundedPerCart(r) {
+				sale, err := repositories.Ledger.FindJournalByReference(fmt.Sprintf("sale:cart:%d", cartID))
+
+				if err != nil {
+					continue
+				}
+
+				commission := amount * sale.CommissionRate / 100
+
+				for _, entry := range sale.Entries {
+					if entry.Account == AccountSellerPayable {
+						entries = append(entries, Entry{Account: AccountSellerPayable, SellerID: entry.SellerID, Debit: amount - commission})
+					}
+				}
+
+				if commission > 0 {
+					entries = append(entries, Entry{Account: AccountCommission, Debit: commission})
+				}
+
+				entries = append(entries, Entry{Account: AccountClearing, Credit: amount})
+			}
+
+			if r.ShippingAmount > 0 {
+				if _, err := repositories.Ledger.FindJournalByReference(fmt.Sprintf("sale:shipping:%d", r.PaymentID)); err == nil {
+					entries = append(entries,
+						Entry{Account: AccountShipping, Debit: r.ShippingAmount},
+						Entry{Account: AccountClearing, Credit: r.ShippingAmount},
+					)
+				}
+			}
+
+			_, err := postJournal(repositories.Ledger, Journal{
+				Type:        JournalRefund,
+				Reference:   fmt.Sprintf("refund:%d", r.ID),
+				Description: fmt.Sprintf("Refund %d paid after settlement", r.ID),
+				PaymentID:   r.PaymentID,
+				Entries:     entries,
+			})
+
+			return err
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *service) FindSellerBalance(sellerID int) (SellerBalance, error) {
+	now := time.Now().Add(time.Second)
+	balance := SellerBalance{SellerID: sellerID}
+
+	debit, credit, err := s.ledgerRepository.SumSellerAccount(sellerID, AccountSellerPayable, now)
+
+	if err != nil {
+		return SellerBalance{}, err
+	}
+
+	balance.Available = credit - debit
+
+	debit, credit, err = s.ledgerRepository.SumSellerAccount(sellerID, AccountPayoutTransit, now)
+
+	if err != nil {
+		return SellerBalance{}, err
+	}
+
+	balance.InTransit = credit - debit
+	balance.PaidOut = debit
+
+	return balance, nil
+}
+
+func (s *service) FindSellerBalances() ([]SellerBalance, error) {
+	available, err := s.ledgerRepository.FindSellerBalances(AccountSellerPayable)
+
+	if err != nil {
+		return nil, err
+	}
+
+	inTransit, err := s.ledgerRepository.FindSellerBalances(AccountPayoutTransit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	balances := []SellerBalance{}
+	index := map[int]int{}
+
+	for _, b := range available {
+		index[b.SellerID] = len(balances)
+		balances = append(balances, SellerBalance{SellerID: b.SellerID, Available: b.Amount})
+	}
+
+	for _, b := range inTransit {
+		i, ok := index[b.SellerID]
+
+		if !ok {
+			i = len(balances)
+			balances = append(balances, SellerBalance{SellerID: b.SellerID})
+		}
+
+		balances[i].InTransit = b.Amount
+	}
+
+	return balances, nil
+}
+
+func (s *service) FindStatement(sellerID int, from time.Time, to time.Time) (Statement, error) {
+	debit, credit, err := s.ledgerRepository.SumSellerAccount(sellerID, AccountSellerPayable, from)
+
+	if err != nil {
+		return Statement{}, err
+	}
+
+	lines, err := s.ledgerRepository.FindSellerEntries(sellerID, AccountSellerPayable, from, to)
+
+	if err != nil {
+		return Statement{}, err
+	}
+
+	statement := Statement{
+		SellerID:       sellerID,
+		From:           from,
+		To:             to,
+		OpeningBalance: credit - debit,
+		ClosingBalance: credit - debit,
+		Lines:          lines,
+	}
+
+	for _, line := range lines {
+		statement.ClosingBalance += line.Credit - line.Debit
+	}
+
+	return statement, nil
+}
+
+func (s *service) CreatePayoutBatch(minimum int) (PayoutBatch, error) {
+	if _, err := s.SettleCompletedPayments(); err != nil {
+		return PayoutBatch{}, err
+	}
+
+	var batch PayoutBatch
+
+	err := s.ledgerRepository.Transaction(func(repositories Repositories) error {
+		balances, err := repositories.Ledger.LockSellerBalances(AccountSellerPayable)
+
+		if err != nil {
+			return err
+		}
+
+		batch, err = repositories.Ledger.CreatePayoutBatch(PayoutBatch{Status: PayoutPending})
+
+		if err != nil {
+			return err
+		}
+
+		for _, balance := range balances {
+			if balance.Amount <= 0 || balance.Amount < minimum {
+				continue
+			}
+
+			destination, err := repositories.Bank.FindDefaultBank(balance.SellerID)
+
+			if err != nil {
+				continue
+			}
+
+			payout, err := repositories.Ledger.CreatePayout(Payout{
+				BatchID:    batch.ID,
+				SellerID:   balance.SellerID,
+				BankID:     destination.ID,
+				BankName:   destination.Name,
+				BankNumber: destination.Number,
+				Amount:     balance.Amount,
+				Status:     PayoutPending,
+			})
+
+			if err != nil {
+				return err
+			}
+
+			_, err = postJournal(repositories.Ledger, Journal{
+				Type:        JournalPayout,
+				Reference:   fmt.Sprintf("payout:%d", payout.ID),
+				Description: fmt.Sprintf("Payout batch %d to %s %s", batch.ID, destination.Name, destination.Number),
+				Entries: []Entry{
+					{Account: AccountSellerPayable, SellerID: balance.SellerID, Debit: balance.Amount},
+					{Account: AccountPayoutTransit, SellerID: balance.SellerID, Credit: balance.Amount},
+				},
+			})
+
+			if err != nil {
+				return err
+			}
+
+			batch.Total += payout.Amount
+			batch.Payouts = append(batch.Payouts, payout)
+		}
+
+		if len(batch.Payouts) == 0 {
+			return errors.New("No seller balance is eligible for payout")
+		}
+
+		_, err = repositories.Ledger.UpdatePayoutBatch(batch)
+
+		return err
+	})
+
+	return batch, err
+}
+
+func (s *service) MarkPayoutBatchPaid(ID int, reference string) (PayoutBatch, error) {
+	var batch PayoutBatch
+
+	err := s.ledgerRepository.Transaction(func(repositories Repositories) error {
+		var err error
+
+		batch, err = repositories.Ledger.LockPayoutBatchByID(ID)
+
+		if err != nil {
+			return err
+		}
+
+		if batch.Status != PayoutPending {
+			return errors.New("Payout batch is already paid")
+		}
+
+		now := time.Now()
+
+		for i, payout := range batch.Payouts {
+			_, err := postJournal(repositories.Ledger, Journal{
+				Type:        JournalPayoutPaid,
+				Reference:   fmt.Sprintf("payout_paid:%d", payout.ID),
+				Description: fmt.Sprintf("Payout batch %d transferred (%s)", batch.ID, reference),
+				Entries: []Entry{
+					{Account: AccountPayoutTransit, SellerID: payout.SellerID, Debit: payout.Amount},
+					{Account: AccountCash, Credit: payout.Amount},
+				},
+			})
+
+			if err != nil {
+				return err
+			}
+
+			payout.Status = PayoutPaid
+			payout.Reference = reference
+			payout.PaidAt = &now
+
+			if batch.Payouts[i], err = repositories.Ledger.UpdatePayout(payout); err != nil {
+				return err
+			}
+		}
+
+		batch.Status = PayoutPaid
+		batch.Reference = reference
+		batch.PaidAt = &now
+
+		_, err = repositories.Ledger.UpdatePayoutBatch(batch)
+
+		return err
+	})
+
+	return batch, err
+}
+
+func (s *service) FindPayoutBatchByID(ID int) (PayoutBatch, error) {
+	return s.ledgerRepository.FindPayoutBatchByID(ID)
+}
+
+func (s *service) FindPayoutBatches() ([]PayoutBatch, error) {
+	return s.ledgerRepository.FindPayoutBatches()
+}
+
+func postJournal(ledgerRepository LedgerRepository, journal Journal) (Journal, error) {
+	debit, credit := 0, 0
+
+	for _, entry := range journal.Entries {
+		debit += entry.Debit
+		credit += entry.Credit
+	}
+
+	if debit != credit {
+		return Journal{}, fmt.Errorf("Journal %s is not balanced", journal.Reference)
+	}
+
+	return ledgerRepository.CreateJournal(journal)
+}
+
+func refundedPerCart(r refund.Refund) map[uint64]int {
+	refunded := map[uint64]int{}
+	total := 0
+
+	for _, item := range r.Items {
+		total += item.Amount
+	}
+
+	if total == 0 {
+		return refunded
+	}
+
+	remaining := r.Amount - r.ShippingAmount
+
+	for i, item := range r.Items {
+		amount := item.Amount * (r.Amount - r.ShippingAmount) / total
+
+		if i == len(r.Items)-1 {
+			amount = remaining
+		}
+
+		refunded[item.CartID] += amount
+		remaining -= amount
+	}
+
+	return refunded
+}
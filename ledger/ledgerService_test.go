@@ -0,0 +1,302 @@
+package ledger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"taman-pempek/cart"
+	"taman-pempek/payment"
+	"taman-pempek/product"
+	"taman-pempek/refund"
+	"taman-pempek/setting"
+	"testing"
+	"time"
+)
+
+type memoryLedger struct {
+	LedgerRepository
+	journals []Journal
+	payments []payment.Payment
+	refunds  []refund.Refund
+	carts    []cart.Cart
+	products []product.Product
+}
+
+func (r *memoryLedger) Transaction(fn func(repositories Repositories) error) error {
+	return fn(Repositories{
+		Ledger:  r,
+		Cart:    &memoryCarts{ledger: r},
+		Product: &memoryProducts{ledger: r},
+		Refund:  &memoryRefunds{ledger: r},
+	})
+}
+
+func (r *memoryLedger) FindJournalByReference(reference string) (Journal, error) {
+	for _, journal := range r.journals {
+		if journal.Reference == reference {
+			return journal, nil
+		}
+	}
+	return Journal{}, errors.New("Journal not found")
+}
+
+func (r *memoryLedger) CreateJournal(journal Journal) (Journal, error) {
+	if _, err := r.FindJournalByReference(journal.Reference); err == nil {
+		return Journal{}, fmt.Errorf("duplicate journal %s", journal.Reference)
+	}
+	journal.ID = uint64(len(r.journals) + 1)
+	r.journals = append(r.journals, journal)
+	return journal, nil
+}
+
+func (r *memoryLedger) FindSettlablePayments() ([]payment.Payment, error) {
+	payments := []payment.Payment{}
+	for _, p := range r.payments {
+		if p.PaymentStatus == payment.StatusCompleted && p.SettledAt == nil {
+			payments = append(payments, p)
+		}
+	}
+	return payments, nil
+}
+
+func (r *memoryLedger) MarkPaymentSettled(ID uint64) (bool, error) {
+	for i := range r.payments {
+		if r.payments[i].ID == ID && r.payments[i].SettledAt == nil {
+			now := time.Now()
+			r.payments[i].SettledAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryLedger) FindUnpostedRefunds() ([]refund.Refund, error) {
+	refunds := []refund.Refund{}
+	for _, rf := range r.refunds {
+		if rf.Status != refund.StatusPaid || !r.settled(rf.PaymentID) {
+			continue
+		}
+		if _, err := r.FindJournalByReference(fmt.Sprintf("refund:%d", rf.ID)); err == nil {
+			continue
+		}
+		refunds = append(refunds, rf)
+	}
+	return refunds, nil
+}
+
+func (r *memoryLedger) settled(paymentID uint64) bool {
+	for _, p := range r.payments {
+		if p.ID == paymentID {
+			return p.SettledAt != nil
+		}
+	}
+	return false
+}
+
+func (r *memoryLedger) balance(account string, sellerID int) int {
+	total := 0
+	for _, journal := range r.journals {
+		for _, entry := range journal.Entries {
+			if entry.Account == account && entry.SellerID == sellerID {
+				total += entry.Credit - entry.Debit
+			}
+		}
+	}
+	return total
+}
+
+type memoryCarts struct {
+	cart.CartRepository
+	ledger *memoryLedger
+}
+
+func (r *memoryCarts) FindCartsByPaymentID(paymentID int) ([]cart.Cart, error) {
+	carts := []cart.Cart{}
+	for _, c := range r.ledger.carts {
+		if c.PaymentID.String() == strconv.Itoa(paymentID) {
+			carts = append(carts, c)
+		}
+	}
+	return carts, nil
+}
+
+type memoryProducts struct {
+	product.ProductRepository
+	ledger *memoryLedger
+}
+
+func (r *memoryProducts) FindProductByID(ID int) (product.Product, error) {
+	for _, p := range r.ledger.products {
+		if p.ID == uint64(ID) {
+			return p, nil
+		}
+	}
+	return product.Product{}, errors.New("Product not found")
+}
+
+type memoryRefunds struct {
+	refund.RefundRepository
+	ledger *memoryLedger
+}
+
+func (r *memoryRefunds) FindRefundsByPayment(paymentID int) ([]refund.Refund, error) {
+	refunds := []refund.Refund{}
+	for _, rf := range r.ledger.refunds {
+		if rf.PaymentID == uint64(paymentID) {
+			refunds = append(refunds, rf)
+		}
+	}
+	return refunds, nil
+}
+
+type fixedSetting struct {
+	setting.SettingService
+	commission int
+}
+
+func (s *fixedSetting) FindSettingByID(ID int) (setting.Setting, error) {
+	return setting.Setting{PlatformCommission: s.commission}, nil
+}
+
+const (
+	sellerA = 5
+	sellerB = 6
+)
+
+func newTestLedger(commission int) (*service, *memoryLedger, *fixedSetting) {
+	ledger := &memoryLedger{
+		payments: []payment.Payment{{ID: 1, PaymentStatus: payment.StatusCompleted, ShippingFee: 10000}},
+		carts: []cart.Cart{
+			{ID: 1, PaymentID: json.Number("1"), ProductID: json.Number("1"), TotalPrice: json.Number("100000")},
+			{ID: 2, PaymentID: json.Number("1"), ProductID: json.Number("2"), TotalPrice: json.Number("50000")},
+		},
+		products: []product.Product{
+			{ID: 1, UserID: sellerA, Name: "Pempek Kapal Selam"},
+			{ID: 2, UserID: sellerB, Name: "Pempek Lenjer"},
+		},
+	}
+	settings := &fixedSetting{commission: commission}
+	return NewService(ledger, settings), ledger, settings
+}
+
+func assertBalanced(t *testing.T, journals []Journal) {
+	t.Helper()
+	for _, journal := range journals {
+		debit, credit := 0, 0
+		for _, entry := range journal.Entries {
+			debit += entry.Debit
+			credit += entry.Credit
+		}
+		if debit != credit {
+			t.Errorf("journal %s: debit %d, credit %d", journal.Reference, debit, credit)
+		}
+	}
+}
+
+func TestSettleFollowsPlatformCommission(t *testing.T) {
+	for _, commission := range []int{0, 10, 25} {
+		s, ledger, _ := newTestLedger(commission)
+
+		settled, err := s.SettleCompletedPayments()
+		if err != nil || settled != 1 {
+			t.Fatalf("commission %d: settled = %d, %v", commission, settled, err)
+		}
+
+		assertBalanced(t, ledger.journals)
+
+		if got, want := ledger.balance(AccountSellerPayable, sellerA), 100000-100000*commission/100; got != want {
+			t.Errorf("commission %d: seller A = %d, want %d", commission, got, want)
+		}
+		if got, want := ledger.balance(AccountSellerPayable, sellerB), 50000-50000*commission/100; got != want {
+			t.Errorf("commission %d: seller B = %d, want %d", commission, got, want)
+		}
+		if got, want := ledger.balance(AccountCommission, 0), 150000*commission/100; got != want {
+			t.Errorf("commission %d: commission = %d, want %d", commission, got, want)
+		}
+		if got := ledger.balance(AccountShipping, 0); got != 10000 {
+			t.Errorf("commission %d: shipping = %d, want 10000", commission, got)
+		}
+
+		sale, _ := ledger.FindJournalByReference("sale:cart:1")
+		if sale.CommissionRate != commission {
+			t.Errorf("commission %d: journal rate = %d", commission, sale.CommissionRate)
+		}
+
+		if settled, _ := s.SettleCompletedPayments(); settled != 0 {
+			t.Errorf("commission %d: payment settled twice", commission)
+		}
+	}
+}
+
+func TestRefundAfterSettlementReversesSellerCredit(t *testing.T) {
+	s, ledger, settings := newTestLedger(10)
+
+	if _, err := s.SettleCompletedPayments(); err != nil {
+		t.Fatalf("SettleCompletedPayments: %v", err)
+	}
+
+	settings.commission = 20
+	ledger.refunds = []refund.Refund{{
+		ID:             1,
+		PaymentID:      1,
+		Amount:         50000,
+		ShippingAmount: 10000,
+		Status:         refund.StatusPaid,
+		Items:          []refund.RefundItem{{CartID: 1, Amount: 40000}},
+	}}
+
+	if _, err := s.SettleCompletedPayments(); err != nil {
+		t.Fatalf("SettleCompletedPayments: %v", err)
+	}
+
+	assertBalanced(t, ledger.journals)
+
+	if _, err := ledger.FindJournalByReference("refund:1"); err != nil {
+		t.Fatal("refund was not posted")
+	}
+	if got := ledger.balance(AccountSellerPayable, sellerA); got != 90000-36000 {
+		t.Errorf("seller A = %d, want %d", got, 90000-36000)
+	}
+	if got := ledger.balance(AccountSellerPayable, sellerB); got != 45000 {
+		t.Errorf("seller B = %d, want 45000", got)
+	}
+	if got := ledger.balance(AccountCommission, 0); got != 15000-4000 {
+		t.Errorf("commission = %d, want %d", got, 15000-4000)
+	}
+	if got := ledger.balance(AccountShipping, 0); got != 0 {
+		t.Errorf("shipping = %d, want 0", got)
+	}
+
+	journals := len(ledger.journals)
+	if _, err := s.SettleCompletedPayments(); err != nil || len(ledger.journals) != journals {
+		t.Errorf("refund was posted twice: %v", err)
+	}
+}
+
+func TestRefundBeforeSettlementIsLeftOutOfSale(t *testing.T) {
+	s, ledger, _ := newTestLedger(10)
+	ledger.refunds = []refund.Refund{{
+		ID:        1,
+		PaymentID: 1,
+		Amount:    100000,
+		Status:    refund.StatusPaid,
+		Items:     []refund.RefundItem{{CartID: 1, Amount: 100000}},
+	}}
+
+	if _, err := s.SettleCompletedPayments(); err != nil {
+		t.Fatalf("SettleCompletedPayments: %v", err)
+	}
+
+	assertBalanced(t, ledger.journals)
+
+	if _, err := ledger.FindJournalByReference("sale:cart:1"); err == nil {
+		t.Error("fully refunded cart was credited to the seller")
+	}
+	if got := ledger.balance(AccountSellerPayable, sellerA); got != 0 {
+		t.Errorf("seller A = %d, want 0", got)
+	}
+	if got := ledger.balance(AccountSellerPayable, sellerB); got != 45000 {
+		t.Errorf("seller B = %d, want 45000", got)
+	}
+}
@@ -0,0 +1,34 @@
+package ledger
+
+import "time"
+
+const (
+	PayoutPending = "pending"
+	PayoutPaid    = "paid"
+)
+
+type PayoutBatch struct {
+	ID        uint64     `gorm:"column:id;primaryKey;autoIncrement"`
+	Status    string     `gorm:"column:status;type:varchar(255)"`
+	Total     int        `gorm:"column:total"`
+	Reference string     `gorm:"column:reference;type:varchar(255)"`
+	PaidAt    *time.Time `gorm:"column:paid_at"`
+	Payouts   []Payout   `gorm:"foreignKey:BatchID"`
+	CreatedAt time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+type Payout struct {
+	ID         uint64     `gorm:"column:id;primaryKey;autoIncrement"`
+	BatchID    uint64     `gorm:"column:batch_id;index"`
+	SellerID   int        `gorm:"column:seller_id;index"`
+	BankID     uint64     `gorm:"column:bank_id"`
+	BankName   string     `gorm:"column:bank_name;type:varchar(255)"`
+	BankNumber string     `gorm:"column:bank_number;type:varchar(255)"`
+	Amount     int        `gorm:"column:amount"`
+	Status     string     `gorm:"column:status;type:varchar(255)"`
+	Reference  string     `gorm:"column:reference;type:varchar(255)"`
+	PaidAt     *time.Time `gorm:"column:paid_at"`
+	CreatedAt  time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt  time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
@@ -3,15 +3,33 @@ package main
 import (
 	"log"
 	"os"
+	"strings"
+	"taman-pempek/address"
 	"taman-pempek/bank"
 	"taman-pempek/cart"
+	"taman-pempek/cash"
 	"taman-pempek/category"
+	"taman-pempek/checkout"
 	"taman-pempek/delivery"
+	"taman-pempek/dispatch"
+	"taman-pempek/invoice"
+	"taman-pempek/ledger"
 	"taman-pempek/middleware"
+	"taman-pempek/notification"
+	"taman-pempek/otp"
 	"taman-pempek/payment"
 	"taman-pempek/product"
+	"taman-pempek/refund"
+	"taman-pempek/scheduler"
+	"taman-pempek/session"
 	"taman-pempek/setting"
+	"taman-pempek/shipment"
+	"taman-pempek/shipping"
+	"taman-pempek/slot"
+	"taman-pempek/storage"
+	"taman-pempek/tracking"
 	"taman-pempek/user"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -19,6 +37,8 @@ import (
 	"gorm.io/gorm"
 )
 
+const localMediaDir = "./public"
+
 func goDotEnvVariable(key string) string {
 	err := godotenv.Load(".env")
 	if err != nil {
@@ -35,150 +55,514 @@ func main() {
 	dbName := goDotEnvVariable("MYSQLDATABASE")
 
 	dsn := dbUser + ":" + dbPassword + "@tcp(" + dbHost + ":" + dbPort + ")/" + dbName + "?charset=utf8mb4&parseTime=True&loc=Local"
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{TranslateError: true})
 
 	if err != nil {
 		log.Fatal("DB Connection error")
 	}
 
+	user.RegisterValidations()
+
 	router := gin.Default()
-	v1 := router.Group("/v1")
+
+	if goDotEnvVariable("MEDIASTORE") == "local" {
+		router.Static("/public", localMediaDir)
+	}
 
 	migration(db)
 
 	userRepository := user.NewRepository(db)
 	userService := user.NewService(userRepository)
-	userMiddleware := middleware.NewMiddleware(userService)
+	sessionRepository := session.NewRepository(db)
+	sessionService := session.NewService(sessionRepository)
+	userMiddleware := middleware.NewMiddleware(userService, sessionService)
+
+	public := router.Group("/v1")
+	private := router.Group("/v1", userMiddleware.RequireAuth, userMiddleware.Authorize(policies(db)))
 
-	requireAuth := userMiddleware.RequireAuth
+	routeUser(db, public, private)
+	routeSession(db, public, private)
+	routeProduct(db, public, private)
+	routeBank(db, public, private)
+	routeAddress(db, public, private)
+	routeCategory(db, public, private)
+	routeDelivery(db, public, private)
+	routeCart(db, public, private)
+	routePayment(db, public, private)
+	routeCheckout(db, public, private)
+	routeRefund(db, public, private)
+	routeLedger(db, public, private)
+	routeShipment(db, public, private)
+	routeCash(db, public, private)
+	routeDispatch(db, public, private)
+	routeSlot(db, public, private)
+	routeShipping(db, public, private)
+	routeTracking(db, public, private)
+	routeInvoice(db, public, private)
+	routeSetting(db, public, private)
 
-	routeUser(db, v1, requireAuth)
-	routeProduct(db, v1, requireAuth)
-	routeBank(db, v1, requireAuth)
-	routeCategory(db, v1, requireAuth)
-	routeDelivery(db, v1, requireAuth)
-	routeCart(db, v1, requireAuth)
-	routePayment(db, v1, requireAuth)
-	routeSetting(db, v1, requireAuth)
+	startSchedulers(db)
 
 	router.Run(":8888") // port
 }
 
+func startSchedulers(db *gorm.DB) {
+	interval, err := time.ParseDuration(goDotEnvVariable("EXPIRYINTERVAL"))
+
+	if err != nil || interval <= 0 {
+		interval = time.Minute
+	}
+
+	checkoutService := checkout.NewService(checkout.NewRepository(db))
+	settingService := setting.NewService(setting.NewRepository(db))
+
+	scheduler.NewExpiryScheduler(checkoutService, settingService, interval).Start()
+
+	settlementInterval, err := time.ParseDuration(goDotEnvVariable("SETTLEMENTINTERVAL"))
+
+	if err != nil || settlementInterval <= 0 {
+		settlementInterval = 10 * time.Minute
+	}
+
+	ledgerService := ledger.NewService(ledger.NewRepository(db), settingService)
+
+	scheduler.NewSettlementScheduler(ledgerService, settlementInterval).Start()
+
+	trackingInterval, err := time.ParseDuration(goDotEnvVariable("TRACKINGINTERVAL"))
+
+	if err != nil || trackingInterval <= 0 {
+		trackingInterval = 30 * time.Minute
+	}
+
+	scheduler.NewTrackingScheduler(trackingService(db), trackingInterval).Start()
+}
+
+func trackingService(db *gorm.DB) tracking.TrackingService {
+	var provider tracking.TrackingProvider = tracking.NewRajaOngkirTracker(goDotEnvVariable("RAJAONGKIR_URL"), goDotEnvVariable("RAJAONGKIR_KEY"))
+
+	if goDotEnvVariable("TRACKINGPROVIDER") == "fake" {
+		provider = tracking.NewFakeProvider()
+	}
+
+	paymentService := payment.NewService(payment.NewRepository(db), checkout.NewService(checkout.NewRepository(db)))
+	shipmentRepository := shipment.NewRepository(db)
+	shipmentService := shipment.NewService(shipmentRepository, cart.NewRepository(db), paymentService)
+
+	return tracking.NewService(tracking.NewRepository(db), shipmentRepository, shipmentService, paymentService, provider)
+}
+
+func notifiers() map[string]notification.Notifier {
+	if goDotEnvVariable("NOTIFIER") == "fake" {
+		fakeNotifier := notification.NewFakeNotifier()
+		return map[string]notification.Notifier{
+			notification.ChannelEmail:    fakeNotifier,
+			notification.ChannelWhatsapp: fakeNotifier,
+		}
+	}
+
+	return map[string]notification.Notifier{
+		notification.ChannelEmail: notification.NewEmailNotifier(
+			goDotEnvVariable("SMTPHOST"),
+			goDotEnvVariable("SMTPPORT"),
+			goDotEnvVariable("SMTPUSER"),
+			goDotEnvVariable("SMTPPASSWORD"),
+			goDotEnvVariable("SMTPFROM"),
+		),
+		notification.ChannelWhatsapp: notification.NewWhatsappNotifier(
+			goDotEnvVariable("WHATSAPPURL"),
+			goDotEnvVariable("WHATSAPPTOKEN"),
+		),
+	}
+}
+
+func mediaStore() storage.MediaStore {
+	switch goDotEnvVariable("MEDIASTORE") {
+	case "local":
+		baseURL := goDotEnvVariable("MEDIASTORE_URL")
+		if baseURL == "" {
+			baseURL = "/public"
+		}
+		return storage.NewLocalStore(localMediaDir, baseURL)
+	case "s3":
+		return storage.NewS3Store(
+			goDotEnvVariable("S3_ENDPOINT"),
+			goDotEnvVariable("S3_REGION"),
+			goDotEnvVariable("S3_BUCKET"),
+			goDotEnvVariable("S3_ACCESSKEY"),
+			goDotEnvVariable("S3_SECRETKEY"),
+			goDotEnvVariable("S3_PUBLICURL"),
+		)
+	}
+
+	cloud := goDotEnvVariable("CLOUDINARY_CLOUD")
+	if cloud == "" {
+		log.Fatal("CLOUDINARY_CLOUD is not set")
+	}
+
+	store, err := storage.NewCloudinaryStore(cloud, goDotEnvVariable("APIKEY"), goDotEnvVariable("APISECRET"))
+
+	if err != nil {
+		log.Fatalf("Invalid Cloudinary configuration: %v", err)
+	}
+
+	return store
+}
+
 func migration(db *gorm.DB) {
+	db.AutoMigrate(&address.Address{})
 	db.AutoMigrate(&bank.Bank{})
 	db.AutoMigrate(&cart.Cart{})
 	db.AutoMigrate(&category.Category{})
 	db.AutoMigrate(&delivery.Delivery{})
 	db.AutoMigrate(&payment.Payment{})
+	db.AutoMigrate(&payment.PaymentStatusHistory{})
+	db.AutoMigrate(&payment.PaymentProof{})
+	db.AutoMigrate(&payment.PaymentCharge{})
+	db.AutoMigrate(&payment.PaymentGatewayEvent{})
+	db.AutoMigrate(&payment.BankMutation{})
+	db.AutoMigrate(&refund.Refund{})
+	db.AutoMigrate(&refund.RefundItem{})
+	db.AutoMigrate(&shipment.Shipment{})
+	db.AutoMigrate(&cash.Collection{})
+	db.AutoMigrate(&shipping.Zone{})
+	db.AutoMigrate(&shipping.Tier{})
+	db.AutoMigrate(&tracking.Tracking{})
+	db.AutoMigrate(&tracking.Event{})
+	db.AutoMigrate(&cash.Reconciliation{})
+	db.AutoMigrate(&dispatch.Assignment{})
+	db.AutoMigrate(&slot.Slot{})
+	db.AutoMigrate(&invoice.Invoice{})
+	db.AutoMigrate(&invoice.InvoiceSequence{})
+	db.AutoMigrate(&ledger.Journal{})
+	db.AutoMigrate(&ledger.Entry{})
+	db.AutoMigrate(&ledger.PayoutBatch{})
+	db.AutoMigrate(&ledger.Payout{})
 	db.AutoMigrate(&product.Product{})
+	db.AutoMigrate(&product.Variant{})
+	db.AutoMigrate(&product.OptionGroup{})
+	db.AutoMigrate(&product.Option{})
+	db.AutoMigrate(&product.Image{})
 	db.AutoMigrate(&user.User{})
 	db.AutoMigrate(&setting.Setting{})
+	db.AutoMigrate(&session.Session{})
+	db.AutoMigrate(&otp.OTP{})
 }
 
-func routeUser(db *gorm.DB, v *gin.RouterGroup, requireAuth func(c *gin.Context)) {
+func routeUser(db *gorm.DB, public *gin.RouterGroup, private *gin.RouterGroup) {
 	userRepository := user.NewRepository(db)
 	userService := user.NewService(userRepository)
-	userController := user.NewController(userService)
+	sessionRepository := session.NewRepository(db)
+	sessionService := session.NewService(sessionRepository)
+	otpRepository := otp.NewRepository(db)
+	otpService := otp.NewService(otpRepository, notifiers())
+	userController := user.NewController(userService, sessionService, otpService)
 
-	v.GET("/users", userController.GetUsers)
-	v.GET("/users/role/:role", userController.FindUsersByRole)
-	v.GET("/user/:id", userController.GetUser)
-	v.POST("/user/register", userController.CreateUser)
-	v.PUT("/user/update/:id", userController.UpdateUser)
-	v.DELETE("/user/delete/:id", userController.DeleteUser)
+	private.GET("/users", userController.GetUsers)
+	private.GET("/users/role/:role", userController.FindUsersByRole)
+	private.GET("/user/:id", userController.GetUser)
+	public.POST("/user/register", userController.CreateUser)
+	private.PUT("/user/update/:id", userController.UpdateUser)
+	private.DELETE("/user/delete/:id", userController.DeleteUser)
 
-	v.POST("/login", userController.Login)
-	v.POST("/logout", userController.Logout)
+	public.POST("/login", userController.Login)
+	private.POST("/logout", userController.Logout)
+	private.POST("/logout/all", userController.LogoutAll)
+
+	public.POST("/password/forgot", userController.ForgotPassword)
+	public.POST("/password/reset", userController.ResetPassword)
+	private.POST("/user/verify/request", userController.RequestVerification)
+	private.POST("/user/verify/confirm", userController.ConfirmVerification)
 }
 
-func routeProduct(db *gorm.DB, v *gin.RouterGroup, requireAuth func(c *gin.Context)) {
+func routeSession(db *gorm.DB, public *gin.RouterGroup, private *gin.RouterGroup) {
+	sessionRepository := session.NewRepository(db)
+	sessionService := session.NewService(sessionRepository)
+	sessionController := session.NewController(sessionService)
+
+	public.POST("/token/refresh", sessionController.Refresh)
+}
+
+func routeProduct(db *gorm.DB, public *gin.RouterGroup, private *gin.RouterGroup) {
 	productRepository := product.NewRepository(db)
 	productService := product.NewService(productRepository)
-	productController := product.NewController(productService)
+	productController := product.NewController(productService, mediaStore())
 
-	v.GET("/products", productController.GetProducts)
-	v.GET("/products/:userId/", productController.GetProductByUser)
-	v.GET("/products/category/:categoryId", productController.GetProductByCategory)
-	v.GET("/products/:userId/:categoryId", productController.GetProductByUserIDAndCategoryID)
-	v.GET("/product/:id", productController.GetProduct)
-	v.POST("/product/create", productController.CreateProduct)
-	v.PUT("/product/update/:id", productController.UpdateProduct)
-	v.DELETE("/product/delete/:id", productController.DeleteProduct)
+	public.GET("/products", productController.GetProducts)
+	public.GET("/products/search", productController.SearchProducts)
+	public.GET("/products/:userId/", productController.GetProductByUser)
+	public.GET("/products/category/:categoryId", productController.GetProductByCategory)
+	public.GET("/products/:userId/:categoryId", productController.GetProductByUserIDAndCategoryID)
+	public.GET("/product/:id", productController.GetProduct)
+	private.POST("/product/create", productController.CreateProduct)
+	private.PUT("/product/update/:id", productController.UpdateProduct)
+	private.DELETE("/product/delete/:id", productController.DeleteProduct)
+	public.GET("/product/:id/variants", productController.GetProductVariants)
+	public.GET("/product/:id/options", productController.GetProductOptions)
+	private.POST("/product/:id/variant", productController.CreateVariant)
+	private.PUT("/product/variant/update/:id", productController.UpdateVariant)
+	private.DELETE("/product/variant/delete/:id", productController.DeleteVariant)
+	private.POST("/product/:id/option-group", productController.CreateOptionGroup)
+	private.PUT("/product/option-group/update/:id", productController.UpdateOptionGroup)
+	private.DELETE("/product/option-group/delete/:id", productController.DeleteOptionGroup)
+	public.GET("/product/:id/images", productController.GetProductImages)
+	private.POST("/product/:id/image", productController.CreateProductImage)
+	private.PUT("/product/:id/images/order", productController.ReorderProductImages)
+	private.PUT("/product/image/update/:id", productController.UpdateProductImage)
+	private.DELETE("/product/image/delete/:id", productController.DeleteProductImage)
 }
 
-func routeBank(db *gorm.DB, v *gin.RouterGroup, requireAuth func(c *gin.Context)) {
+func routeBank(db *gorm.DB, public *gin.RouterGroup, private *gin.RouterGroup) {
 	bankRepository := bank.NewRepository(db)
 	bankService := bank.NewService(bankRepository)
 	bankController := bank.NewController(bankService)
 
-	v.GET("/banks", bankController.GetBanks)
-	v.GET("/banks/admin", bankController.GetAdminBanks)
-	v.GET("/banks/:userId", bankController.GetBanksByUser)
-	v.GET("/bank/:id", bankController.GetBank)
-	v.POST("/bank/create", bankController.CreateBank)
-	v.PUT("/bank/update/:id", bankController.UpdateBank)
-	v.DELETE("/bank/delete/:id", bankController.DeleteBank)
+	private.GET("/banks", bankController.GetBanks)
+	public.GET("/banks/admin", bankController.GetAdminBanks)
+	private.GET("/banks/:userId", bankController.GetBanksByUser)
+	private.GET("/bank/:id", bankController.GetBank)
+	private.POST("/bank/create", bankController.CreateBank)
+	private.PUT("/bank/update/:id", bankController.UpdateBank)
+	private.DELETE("/bank/delete/:id", bankController.DeleteBank)
+}
+
+func routeAddress(db *gorm.DB, public *gin.RouterGroup, private *gin.RouterGroup) {
+	addressRepository := address.NewRepository(db)
+	addressService := address.NewService(addressRepository)
+	addressController := address.NewController(addressService)
+
+	private.GET("/addresses/:userId", addressController.GetAddressesByUser)
+	private.GET("/addresses/:userId/default", addressController.GetDefaultAddress)
+	private.GET("/address/:id", addressController.GetAddress)
+	private.POST("/address/create", addressController.CreateAddress)
+	private.PUT("/address/update/:id", addressController.UpdateAddress)
+	private.DELETE("/address/delete/:id", addressController.DeleteAddress)
 }
 
-func routeCategory(db *gorm.DB, v *gin.RouterGroup, requireAuth func(c *gin.Context)) {
+func routeCategory(db *gorm.DB, public *gin.RouterGroup, private *gin.RouterGroup) {
 	categoryRepository := category.NewRepository(db)
 	categoryService := category.NewService(categoryRepository)
 	categoryController := category.NewController(categoryService)
 
-	v.GET("/categories", categoryController.GetCategories)
-	v.GET("/category/:id", categoryController.GetCategory)
-	v.POST("/category/create", categoryController.CreateCategory)
-	v.PUT("/category/update/:id", categoryController.UpdateCategory)
-	v.DELETE("/category/delete/:id", categoryController.DeleteCategory)
+	public.GET("/categories", categoryController.GetCategories)
+	public.GET("/category/:id", categoryController.GetCategory)
+	private.POST("/category/create", categoryController.CreateCategory)
+	private.PUT("/category/update/:id", categoryController.UpdateCategory)
+	private.DELETE("/category/delete/:id", categoryController.DeleteCategory)
 }
 
-func routeDelivery(db *gorm.DB, v *gin.RouterGroup, requireAuth func(c *gin.Context)) {
+func routeDelivery(db *gorm.DB, public *gin.RouterGroup, private *gin.RouterGroup) {
 	deliveryRepository := delivery.NewRepository(db)
 	deliveryService := delivery.NewService(deliveryRepository)
 	deliveryController := delivery.NewController(deliveryService)
 
-	v.GET("/deliveries", deliveryController.GetDeliveries)
-	v.GET("/delivery/:id", deliveryController.GetDelivery)
-	v.POST("/delivery/create", deliveryController.CreateDelivery)
-	v.PUT("/delivery/update/:id", deliveryController.UpdateDelivery)
-	v.DELETE("/delivery/delete/:id", deliveryController.DeleteDelivery)
+	public.GET("/deliveries", deliveryController.GetDeliveries)
+	public.GET("/delivery/:id", deliveryController.GetDelivery)
+	private.POST("/delivery/create", deliveryController.CreateDelivery)
+	private.PUT("/delivery/update/:id", deliveryController.UpdateDelivery)
+	private.DELETE("/delivery/delete/:id", deliveryController.DeleteDelivery)
 }
 
-func routeCart(db *gorm.DB, v *gin.RouterGroup, requireAuth func(c *gin.Context)) {
+func routeCart(db *gorm.DB, public *gin.RouterGroup, private *gin.RouterGroup) {
 	cartRepository := cart.NewRepository(db)
-	cartService := cart.NewService(cartRepository)
+	cartService := cart.NewService(cartRepository, product.NewService(product.NewRepository(db)))
 	cartController := cart.NewController(cartService)
 
-	v.GET("/carts", cartController.GetCarts)
-	v.GET("/cart/:id", cartController.GetCart)
-	v.GET("/carts/payment/:paymentId", cartController.FindCartsByPaymentID)
-	v.GET("/carts/product/:productId", cartController.FindCartsByProductID)
-	v.GET("/carts/:isActived/:userId", cartController.FindStatusCardByUser)
-	v.GET("/carts/total/:isActived/:userId", cartController.SumTotalPriceByUser)
-	v.POST("/cart/create", cartController.CreateCart)
-	v.PUT("/cart/update/:id", cartController.UpdateCart)
-	v.DELETE("/cart/delete/:id", cartController.DeleteCart)
+	private.GET("/carts", cartController.GetCarts)
+	private.GET("/cart/:id", cartController.GetCart)
+	private.GET("/carts/payment/:paymentId", cartController.FindCartsByPaymentID)
+	private.GET("/carts/product/:productId", cartController.FindCartsByProductID)
+	private.GET("/carts/:isActived/:userId", cartController.FindStatusCardByUser)
+	private.GET("/carts/total/:isActived/:userId", cartController.SumTotalPriceByUser)
+	private.POST("/cart/create", cartController.CreateCart)
+	private.PUT("/cart/update/:id", cartController.UpdateCart)
+	private.DELETE("/cart/delete/:id", cartController.DeleteCart)
 }
 
-func routePayment(db *gorm.DB, v *gin.RouterGroup, requireAuth func(c *gin.Context)) {
+func routePayment(db *gorm.DB, public *gin.RouterGroup, private *gin.RouterGroup) {
 	paymentRepository := payment.NewRepository(db)
-	paymentService := payment.NewService(paymentRepository)
-	paymentController := payment.NewController(paymentService)
+	paymentService := payment.NewService(paymentRepository, checkout.NewService(checkout.NewRepository(db)))
+	paymentController := payment.NewController(paymentService, mediaStore())
+	paymentProvider := payment.NewMidtransProvider(goDotEnvVariable("PAYMENTGATEWAY_URL"), goDotEnvVariable("PAYMENTGATEWAY_SERVERKEY"))
+	gatewayService := payment.NewGatewayService(paymentRepository, paymentService, paymentProvider)
+	gatewayController := payment.NewGatewayController(gatewayService)
+	transferService := payment.NewTransferService(paymentRepository, paymentService, bank.NewService(bank.NewRepository(db)))
+	transferController := payment.NewTransferController(transferService)
+
+	private.GET("/payments", paymentController.GetPayments)
+	private.GET("/payments/:userId/:paymentStatus", paymentController.GetPaymentByUserAndStatus)
+	private.GET("/payments/status/:paymentStatus", paymentController.GetPaymentByStatus)
+	private.GET("/payment/:id", paymentController.GetPayment)
+	private.POST("/payment/create", paymentController.CreatePayment)
+	private.PUT("/payment/update/:id", paymentController.UpdatePayment)
+	private.PUT("/payment/:id/status", paymentController.UpdatePaymentStatus)
+	private.GET("/payment/:id/timeline", paymentController.GetPaymentTimeline)
+	private.GET("/payments/review", paymentController.GetReviewQueue)
+	private.GET("/payment/:id/proofs", paymentController.GetPaymentProofs)
+	private.POST("/payment/:id/proof", paymentController.UploadPaymentProof)
+	private.POST("/payment/:id/proof/approve", paymentController.ApprovePaymentProof)
+	private.POST("/payment/:id/proof/reject", paymentController.RejectPaymentProof)
+	private.POST("/payment/:id/charge", gatewayController.CreateCharge)
+	private.GET("/payment/:id/charges", gatewayController.GetCharges)
+	private.GET("/payments/charges/:status", gatewayController.GetChargesByStatus)
+	public.POST("/payment/webhook", gatewayController.Webhook)
+	private.POST("/payments/statement", transferController.UploadStatement)
+	private.GET("/payments/mutations/:status", transferController.GetMutationsByStatus)
+	private.POST("/payments/mutation/:id/match", transferController.MatchMutation)
+	private.DELETE("/payment/delete/:id", paymentController.DeletePayment)
+}
+
+func routeCheckout(db *gorm.DB, public *gin.RouterGroup, private *gin.RouterGroup) {
+	checkoutRepository := checkout.NewRepository(db)
+	checkoutService := checkout.NewService(checkoutRepository)
+	checkoutController := checkout.NewController(checkoutService)
+
+	private.POST("/checkout", checkoutController.Checkout)
+	private.PUT("/payment/:id/address", checkoutController.ChangeAddress)
+}
+
+func routeRefund(db *gorm.DB, public *gin.RouterGroup, private *gin.RouterGroup) {
+	refundRepository := refund.NewRepository(db)
+	refundService := refund.NewService(refundRepository, bank.NewService(bank.NewRepository(db)))
+	refundController := refund.NewController(refundService)
+
+	private.POST("/payment/:id/refund", refundController.RequestRefund)
+	private.GET("/payment/:id/refunds", refundController.GetPaymentRefunds)
+	private.GET("/refunds/status/:status", refundController.GetRefundsByStatus)
+	private.GET("/refunds/report", refundController.GetReport)
+	private.GET("/refund/:id", refundController.GetRefund)
+	private.POST("/refund/:id/approve", refundController.ApproveRefund)
+	private.POST("/refund/:id/reject", refundController.RejectRefund)
+	private.POST("/refund/:id/payout", refundController.ConfirmPayout)
+}
+
+func routeLedger(db *gorm.DB, public *gin.RouterGroup, private *gin.RouterGroup) {
+	ledgerRepository := ledger.NewRepository(db)
+	ledgerService := ledger.NewService(ledgerRepository, setting.NewService(setting.NewRepository(db)))
+	ledgerController := ledger.NewController(ledgerService)
+
+	private.GET("/ledger/balances", ledgerController.GetSellerBalances)
+	private.GET("/ledger/seller/:userId/balance", ledgerController.GetSellerBalance)
+	private.GET("/ledger/seller/:userId/statement", ledgerController.GetSellerStatement)
+	private.GET("/payouts/batches", ledgerController.GetPayoutBatches)
+	private.POST("/payouts/batch", ledgerController.CreatePayoutBatch)
+	private.GET("/payouts/batch/:id", ledgerController.GetPayoutBatch)
+	private.POST("/payouts/batch/:id/paid", ledgerController.MarkPayoutBatchPaid)
+}
+
+func routeShipment(db *gorm.DB, public *gin.RouterGroup, private *gin.RouterGroup) {
+	shipmentRepository := shipment.NewRepository(db)
+	paymentService := payment.NewService(payment.NewRepository(db), checkout.NewService(checkout.NewRepository(db)))
+	shipmentService := shipment.NewService(shipmentRepository, cart.NewRepository(db), paymentService)
+	shipmentController := shipment.NewController(shipmentService)
+
+	private.GET("/payment/:id/shipments", shipmentController.GetPaymentShipments)
+	private.GET("/shipments/seller/:userId", shipmentController.GetSellerShipments)
+	private.GET("/shipment/:id", shipmentController.GetShipment)
+	private.PUT("/shipment/:id/delivery", shipmentController.UpdateShipmentDelivery)
+	private.PUT("/shipment/:id/status", shipmentController.UpdateShipmentStatus)
+}
+
+func routeCash(db *gorm.DB, public *gin.RouterGroup, private *gin.RouterGroup) {
+	cashRepository := cash.NewRepository(db)
+	cashService := cash.NewService(cashRepository, delivery.NewService(delivery.NewRepository(db)))
+	cashController := cash.NewController(cashService)
+
+	private.POST("/payment/:id/cash", cashController.CollectCash)
+	private.GET("/payment/:id/cash", cashController.GetPaymentCash)
+	private.GET("/cash/daily", cashController.GetDailyCash)
+	private.GET("/cash/courier/:deliveryId", cashController.GetCourierCash)
+	private.POST("/cash/courier/:deliveryId/reconcile", cashController.ReconcileCourierCash)
+}
+
+func routeDispatch(db *gorm.DB, public *gin.RouterGroup, private *gin.RouterGroup) {
+	paymentService := payment.NewService(payment.NewRepository(db), checkout.NewService(checkout.NewRepository(db)))
+	shipmentService := shipment.NewService(shipment.NewRepository(db), cart.NewRepository(db), paymentService)
+	dispatchService := dispatch.NewService(dispatch.NewRepository(db), delivery.NewService(delivery.NewRepository(db)), paymentService, shipmentService)
+	dispatchController := dispatch.NewController(dispatchService, mediaStore())
+
+	private.POST("/dispatch/assign", dispatchController.AssignDelivery)
+	private.GET("/dispatch/:id", dispatchController.GetAssignment)
+	private.DELETE("/dispatch/:id", dispatchController.UnassignDelivery)
+	private.PUT("/dispatch/:id/status", dispatchController.UpdateDispatchStatus)
+	private.POST("/dispatch/:id/deliver", dispatchController.ConfirmDelivery)
+	private.GET("/dispatch/driver/:deliveryId", dispatchController.GetDriverRoute)
+	private.PUT("/dispatch/driver/:deliveryId/route", dispatchController.ReorderDriverRoute)
+	private.GET("/payment/:id/dispatch", dispatchController.GetPaymentDispatch)
+}
+
+func routeSlot(db *gorm.DB, public *gin.RouterGroup, private *gin.RouterGroup) {
+	slotService := slot.NewService(slot.NewRepository(db), cart.NewRepository(db), product.NewService(product.NewRepository(db)))
+	slotController := slot.NewController(slotService)
+
+	public.GET("/slots/available", slotController.GetAvailableSlots)
+	private.GET("/slots", slotController.GetSlots)
+	private.GET("/slots/schedule", slotController.GetSchedule)
+	private.GET("/slot/:id", slotController.GetSlot)
+	private.POST("/slot/create", slotController.CreateSlot)
+	private.PUT("/slot/update/:id", slotController.UpdateSlot)
+	private.DELETE("/slot/delete/:id", slotController.DeleteSlot)
+}
+
+func routeShipping(db *gorm.DB, public *gin.RouterGroup, private *gin.RouterGroup) {
+	shippingRepository := shipping.NewRepository(db)
+	providers := []shipping.RateProvider{shipping.NewTableProvider(shippingRepository)}
+
+	if baseURL := goDotEnvVariable("RAJAONGKIR_URL"); baseURL != "" {
+		couriers := strings.Split(goDotEnvVariable("RAJAONGKIR_COURIERS"), ",")
+		providers = append(providers, shipping.NewRajaOngkirProvider(baseURL, goDotEnvVariable("RAJAONGKIR_KEY"), goDotEnvVariable("RAJAONGKIR_ORIGIN"), couriers))
+	}
+
+	productService := product.NewService(product.NewRepository(db))
+	shippingService := shipping.NewService(
+		shippingRepository,
+		address.NewService(address.NewRepository(db)),
+		cart.NewService(cart.NewRepository(db), productService),
+		productService,
+		providers...,
+	)
+	shippingController := shipping.NewController(shippingService)
+
+	private.POST("/shipping/quote", shippingController.Quote)
+	private.GET("/shipping/zones", shippingController.GetZones)
+	private.GET("/shipping/zone/:id", shippingController.GetZone)
+	private.POST("/shipping/zone/create", shippingController.CreateZone)
+	private.PUT("/shipping/zone/update/:id", shippingController.UpdateZone)
+	private.DELETE("/shipping/zone/delete/:id", shippingController.DeleteZone)
+	private.POST("/shipping/zone/:id/tier", shippingController.CreateTier)
+	private.PUT("/shipping/tier/update/:id", shippingController.UpdateTier)
+	private.DELETE("/shipping/tier/delete/:id", shippingController.DeleteTier)
+}
+
+func routeTracking(db *gorm.DB, public *gin.RouterGroup, private *gin.RouterGroup) {
+	trackingController := tracking.NewController(trackingService(db))
+
+	private.GET("/payment/:id/tracking", trackingController.GetPaymentTracking)
+}
+
+func routeInvoice(db *gorm.DB, public *gin.RouterGroup, private *gin.RouterGroup) {
+	invoiceRepository := invoice.NewRepository(db)
+	productService := product.NewService(product.NewRepository(db))
+	invoiceService := invoice.NewService(
+		invoiceRepository,
+		payment.NewService(payment.NewRepository(db), checkout.NewService(checkout.NewRepository(db))),
+		cart.NewService(cart.NewRepository(db), productService),
+		productService,
+		setting.NewService(setting.NewRepository(db)),
+		user.NewService(user.NewRepository(db)),
+	)
+	invoiceController := invoice.NewController(invoiceService)
 
-	v.GET("/payments", paymentController.GetPayments)
-	v.GET("/payments/:userId/:paymentStatus", paymentController.GetPaymentByUserAndStatus)
-	v.GET("/payments/status/:paymentStatus", paymentController.GetPaymentByStatus)
-	v.GET("/payment/:id", paymentController.GetPayment)
-	v.POST("/payment/create", paymentController.CreatePayment)
-	v.PUT("/payment/update/:id", paymentController.UpdatePayment)
-	v.DELETE("/payment/delete/:id", paymentController.DeletePayment)
+	private.GET("/payment/:id/invoice", invoiceController.GetInvoice)
+	private.GET("/payment/:id/invoice.pdf", invoiceController.GetInvoicePDF)
+	private.GET("/payment/:id/invoice.html", invoiceController.GetInvoiceHTML)
+	public.GET("/invoice/share/:token", invoiceController.GetSharedInvoice)
 }
 
-func routeSetting(db *gorm.DB, v *gin.RouterGroup, requireAuth func(c *gin.Context)) {
+func routeSetting(db *gorm.DB, public *gin.RouterGroup, private *gin.RouterGroup) {
 	settingRepository := setting.NewRepository(db)
 	settingService := setting.NewService(settingRepository)
-	settingController := setting.NewController(settingService)
+	settingController := setting.NewController(settingService, mediaStore())
 
-	v.GET("/setting/:id", settingController.GetSetting)
-	v.PUT("/setting/update/:id", settingController.UpdateSetting)
+	public.GET("/setting/:id", settingController.GetSetting)
+	private.PUT("/setting/update/:id", settingController.UpdateSetting)
 }
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"taman-pempek/user"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OwnerResolver func(c *gin.Context) (int, error)
+
+type Policy struct {
+	Roles []string
+	Owner OwnerResolver
+}
+
+type Policies map[string]Policy
+
+func (m *middleware) Authorize(policies Policies) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		policy, ok := policies[c.Request.Method+" "+c.FullPath()]
+
+		if !ok {
+			abortForbidden(c, "Access denied")
+			return
+		}
+
+		role := c.GetString("UserRole")
+
+		if len(policy.Roles) > 0 && !hasRole(policy.Roles, role) {
+			abortForbidden(c, "Access denied")
+			return
+		}
+
+		if policy.Owner != nil && !strings.EqualFold(role, user.RoleAdmin) {
+			ownerID, err := policy.Owner(c)
+
+			if err != nil {
+				statusCode := http.StatusBadRequest
+				if strings.HasSuffix(err.Error(), "not found") {
+					statusCode = http.StatusNotFound
+				}
+				c.AbortWithStatusJSON(statusCode, gin.H{
+					"error": true,
+					"data":  nil,
+					"msg":   err.Error(),
+				})
+				return
+			}
+
+			if uint64(ownerID) != c.GetUint64("UserID") {
+				abortForbidden(c, "You do not own this resource")
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+func ParamOwner(param string) OwnerResolver {
+	return func(c *gin.Context) (int, error) {
+		return strconv.Atoi(c.Param(param))
+	}
+}
+
+func FormOwner(field string) OwnerResolver {
+	return func(c *gin.Context) (int, error) {
+		return strconv.Atoi(c.PostForm(field))
+	}
+}
+
+func JSONOwner(field string) OwnerResolver {
+	return func(c *gin.Context) (int, error) {
+		body, err := io.ReadAll(c.Request.Body)
+
+		if err != nil {
+			return 0, err
+		}
+
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		var fields map[string]any
+
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+
+		if err := decoder.Decode(&fields); err != nil {
+			return 0, errors.New("Invalid request body")
+		}
+
+		return strconv.Atoi(fmt.Sprint(fields[field]))
+	}
+}
+
+func LookupOwner(param string, lookup func(ID int) (int, error)) OwnerResolver {
+	return func(c *gin.Context) (int, error) {
+		ID, err := strconv.Atoi(c.Param(param))
+
+		if err != nil {
+			return 0, err
+		}
+
+		return lookup(ID)
+	}
+}
+
+func hasRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if strings.EqualFold(r, role) {
+			return true
+		}
+	}
+	return false
+}
+
+func abortForbidden(c *gin.Context, msg string) {
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+		"error": true,
+		"data":  nil,
+		"msg":   msg,
+	})
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"taman-pempek/user"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	ownerID    = 7
+	strangerID = 8
+	adminID    = 1
+)
+
+func newTestRouter(policies Policies) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	m := NewMiddleware(nil, nil)
+	authenticate := func(c *gin.Context) {
+		switch c.GetHeader("X-Test-User") {
+		case "owner":
+			c.Set("UserID", uint64(ownerID))
+			c.Set("UserRole", user.RoleBuyer)
+		case "stranger":
+			c.Set("UserID", uint64(strangerID))
+			c.Set("UserRole", user.RoleBuyer)
+		case "admin":
+			c.Set("UserID", uint64(adminID))
+			c.Set("UserRole", user.RoleAdmin)
+		case "seller":
+			c.Set("UserID", uint64(ownerID))
+			c.Set("UserRole", user.RoleSeller)
+		}
+	}
+
+	private := router.Group("/v1", authenticate, m.Authorize(policies))
+	echo := func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, string(body))
+	}
+
+	private.GET("/users/:userId/carts", echo)
+	private.POST("/carts", echo)
+	private.GET("/payment/:id", echo)
+	private.GET("/unlisted", echo)
+
+	return router
+}
+
+func lookupPayment(ID int) (int, error) {
+	if ID == 99 {
+		return 0, errors.New("Payment not found")
+	}
+	return ownerID, nil
+}
+
+var testPolicies = Policies{
+	"GET /v1/users/:userId/carts": {Owner: ParamOwner("userId")},
+	"POST /v1/carts":              {Roles: []string{user.RoleBuyer, user.RoleAdmin}, Owner: JSONOwner("user_id")},
+	"GET /v1/payment/:id":         {Owner: LookupOwner("id", lookupPayment)},
+}
+
+func request(router *gin.Engine, method string, path string, as string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("X-Test-User", as)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestAuthorizeOwnership(t *testing.T) {
+	router := newTestRouter(testPolicies)
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		as     string
+		body   string
+		want   int
+	}{
+		{"param owner", "GET", "/v1/users/7/carts", "owner", "", http.StatusOK},
+		{"param stranger", "GET", "/v1/users/7/carts", "stranger", "", http.StatusForbidden},
+		{"param admin", "GET", "/v1/users/7/carts", "admin", "", http.StatusOK},
+		{"param not a number", "GET", "/v1/users/abc/carts", "owner", "", http.StatusBadRequest},
+		{"json owner", "POST", "/v1/carts", "owner", `{"user_id": 7, "quantity": 1}`, http.StatusOK},
+		{"json stranger", "POST", "/v1/carts", "stranger", `{"user_id": 7, "quantity": 1}`, http.StatusForbidden},
+		{"json admin", "POST", "/v1/carts", "admin", `{"user_id": 7, "quantity": 1}`, http.StatusOK},
+		{"json wrong role", "POST", "/v1/carts", "seller", `{"user_id": 7, "quantity": 1}`, http.StatusForbidden},
+		{"json invalid body", "POST", "/v1/carts", "owner", `not json`, http.StatusBadRequest},
+		{"lookup owner", "GET", "/v1/payment/5", "owner", "", http.StatusOK},
+		{"lookup stranger", "GET", "/v1/payment/5", "stranger", "", http.StatusForbidden},
+		{"lookup admin", "GET", "/v1/payment/5", "admin", "", http.StatusOK},
+		{"lookup not found", "GET", "/v1/payment/99", "owner", "", http.StatusNotFound},
+	}
+
+	for _, c := range cases {
+		if got := request(router, c.method, c.path, c.as, c.body).Code; got != c.want {
+			t.Errorf("%s: status = %d, want %d", c.name, got, c.want)
+		}
+	}
+}
+
+func TestAuthorizeKeepsJSONBodyForHandler(t *testing.T) {
+	router := newTestRouter(testPolicies)
+	body := `{"user_id": 7, "quantity": 2}`
+
+	recorder := request(router, "POST", "/v1/carts", "owner", body)
+
+	if recorder.Code != http.StatusOK || recorder.Body.String() != body {
+		t.Errorf("handler got %d %q", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestAuthorizeDeniesRoutesWithoutPolicy(t *testing.T) {
+	router := newTestRouter(testPolicies)
+
+	for _, as := range []string{"owner", "admin"} {
+		if got := request(router, "GET", "/v1/unlisted", as, "").Code; got != http.StatusForbidden {
+			t.Errorf("%s on a route without policy: status = %d", as, got)
+		}
+	}
+}
@@ -1,83 +1,74 @@
 package middleware
 
 import (
-	"fmt"
 	"net/http"
-	"os"
+	"strings"
+	"taman-pempek/session"
 	"taman-pempek/user"
-	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 )
 
 type middleware struct {
-	userService user.UserService
+	userService    user.UserService
+	sessionService session.SessionService
 }
 
-func NewMiddleware(userService user.UserService) *middleware {
-	return &middleware{userService}
+func NewMiddleware(userService user.UserService, sessionService session.SessionService) *middleware {
+	return &middleware{userService, sessionService}
 }
 
 func (m *middleware) RequireAuth(c *gin.Context) {
-	tokenString, err := c.Cookie("Authorization")
+	tokenString := bearerToken(c)
+
+	if tokenString == "" {
+		abortUnauthorized(c)
+		return
+	}
+
+	session, err := m.sessionService.ValidateAccessToken(tokenString)
 
 	if err != nil {
-		c.AbortWithStatus(http.StatusUnauthorized)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": true,
-			"data":  nil,
-			"msg":   "Login first!",
-		})
+		abortUnauthorized(c)
+		return
 	}
 
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
-		}
-		if err != nil {
-			c.AbortWithStatus(http.StatusUnauthorized)
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": true,
-				"data":  nil,
-				"msg":   "Login first!",
-			})
-		}
-
-		return []byte(os.Getenv("SECRET")), nil
-	})
+	user, err := m.userService.FindUserByID(session.UserID)
+
+	if user.ID == 0 || err != nil {
+		abortUnauthorized(c)
+		return
+	}
+
+	c.Set("UserID", user.ID)
+	c.Set("UserName", user.Name)
+	c.Set("UserEmail", user.Email)
+	c.Set("UserRole", user.Role)
+	c.Set("SessionID", session.ID)
+
+	c.Next()
+}
 
-	if claims, ok := token.Claims.(jwt.MapClaims); ok {
-		if float64(time.Now().Unix()) > claims["exp"].(float64) {
-			c.AbortWithStatus(http.StatusUnauthorized)
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": true,
-				"data":  nil,
-				"msg":   "Login first!",
-			})
-		}
-		user, err := m.userService.FindUserByID(claims["foo"])
-
-		if user.ID == 0 || err != nil {
-			c.AbortWithStatus(http.StatusUnauthorized)
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": true,
-				"data":  nil,
-				"msg":   "Login first!",
-			})
-		}
-
-		c.Set("UserID", user.ID)
-		c.Set("UserName", user.Name)
-		c.Set("UserEmail", user.Email)
-
-		c.Next()
-	} else {
-		c.AbortWithStatus(http.StatusUnauthorized)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": true,
-			"data":  nil,
-			"msg":   "Login first!",
-		})
+func bearerToken(c *gin.Context) string {
+	header := c.GetHeader("Authorization")
+
+	if len(header) > 7 && strings.EqualFold(header[:7], "Bearer ") {
+		return strings.TrimSpace(header[7:])
+	}
+
+	tokenString, err := c.Cookie(session.AccessTokenCookie)
+
+	if err != nil {
+		return ""
 	}
+
+	return tokenString
+}
+
+func abortUnauthorized(c *gin.Context) {
+	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+		"error": true,
+		"data":  nil,
+		"msg":   "Login first!",
+	})
 }
@@ -0,0 +1,42 @@
+package notification
+
+import (
+	"fmt"
+	"net/smtp"
+	"strings"
+)
+
+type emailNotifier struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+func NewEmailNotifier(host string, port string, username string, password string, from string) *emailNotifier {
+	return &emailNotifier{host, port, username, password, from}
+}
+
+func (n *emailNotifier) Send(message Message) error {
+	headers := []string{
+		"From: " + n.from,
+		"To: " + message.Destination,
+		"Subject: " + message.Subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=\"utf-8\"",
+	}
+
+	body := strings.Join(headers, "\r\n") + "\r\n\r\n" + message.Body
+
+	var auth smtp.Auth
+	if n.username != "" {
+		auth = smtp.PlainAuth("", n.username, n.password, n.host)
+	}
+
+	err := smtp.SendMail(n.host+":"+n.port, auth, n.from, []string{message.Destination}, []byte(body))
+	if err != nil {
+		return fmt.Errorf("Failed to send email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package notification
+
+import "sync"
+
+type FakeNotifier struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewFakeNotifier() *FakeNotifier {
+	return &FakeNotifier{}
+}
+
+func (n *FakeNotifier) Send(message Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.messages = append(n.messages, message)
+	return nil
+}
+
+func (n *FakeNotifier) Messages() []Message {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return append([]Message{}, n.messages...)
+}
+
+func (n *FakeNotifier) LastMessage(destination string) (Message, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for i := len(n.messages) - 1; i >= 0; i-- {
+		if n.messages[i].Destination == destination {
+			return n.messages[i], true
+		}
+	}
+	return Message{}, false
+}
@@ -0,0 +1,16 @@
+package notification
+
+const (
+	ChannelEmail    = "email"
+	ChannelWhatsapp = "whatsapp"
+)
+
+type Message struct {
+	Destination string
+	Subject     string
+	Body        string
+}
+
+type Notifier interface {
+	Send(message Message) error
+}
@@ -0,0 +1,44 @@
+package notification
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type whatsappNotifier struct {
+	endpoint string
+	token    string
+	client   *http.Client
+}
+
+func NewWhatsappNotifier(endpoint string, token string) *whatsappNotifier {
+	return &whatsappNotifier{endpoint, token, &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (n *whatsappNotifier) Send(message Message) error {
+	form := url.Values{}
+	form.Set("target", message.Destination)
+	form.Set("message", message.Body)
+
+	request, err := http.NewRequest(http.MethodPost, n.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Authorization", n.token)
+
+	response, err := n.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("Failed to send WhatsApp message: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("Failed to send WhatsApp message: status %d", response.StatusCode)
+	}
+	return nil
+}
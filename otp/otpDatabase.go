@@ -0,0 +1,27 @@
+package otp
+
+import "time"
+
+const (
+	PurposeResetPassword  = "reset_password"
+	PurposeVerifyEmail    = "verify_email"
+	PurposeVerifyWhatsapp = "verify_whatsapp"
+)
+
+type OTP struct {
+	ID          uint64     `gorm:"column:id;primaryKey;autoIncrement"`
+	UserID      uint64     `gorm:"column:user_id;index"`
+	Purpose     string     `gorm:"column:purpose;type:varchar(255)"`
+	Channel     string     `gorm:"column:channel;type:varchar(255)"`
+	Destination string     `gorm:"column:destination;type:varchar(255)"`
+	CodeHash    string     `gorm:"column:code_hash;type:varchar(64)"`
+	Attempts    int        `gorm:"column:attempts"`
+	ExpiresAt   time.Time  `gorm:"column:expires_at"`
+	ConsumedAt  *time.Time `gorm:"column:consumed_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (OTP) TableName() string {
+	return "otps"
+}
@@ -0,0 +1,59 @@
+package otp
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type OTPRepository interface {
+	FindActiveOTP(userID uint64, purpose string) (OTP, error)
+	CreateOTP(otp OTP) (OTP, error)
+	ConsumeOTP(ID uint64, consumedAt time.Time) (bool, error)
+	IncrementAttempts(ID uint64) error
+	InvalidateOTPs(userID uint64, purpose string, consumedAt time.Time) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) FindActiveOTP(userID uint64, purpose string) (OTP, error) {
+	var otp OTP
+	err := r.db.Where("user_id = ? AND purpose = ? AND consumed_at IS NULL", userID, purpose).
+		Order("id DESC").
+		First(&otp).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return OTP{}, errors.New("OTP not found")
+	}
+	return otp, err
+}
+
+func (r *repository) CreateOTP(otp OTP) (OTP, error) {
+	err := r.db.Create(&otp).Error
+	return otp, err
+}
+
+func (r *repository) ConsumeOTP(ID uint64, consumedAt time.Time) (bool, error) {
+	result := r.db.Model(&OTP{}).
+		Where("id = ? AND consumed_at IS NULL", ID).
+		Update("consumed_at", consumedAt)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *repository) IncrementAttempts(ID uint64) error {
+	return r.db.Model(&OTP{}).
+		Where("id = ?", ID).
+		Update("attempts", gorm.Expr("attempts + 1")).Error
+}
+
+func (r *repository) InvalidateOTPs(userID uint64, purpose string, consumedAt time.Time) error {
+	return r.db.Model(&OTP{}).
+		Where("user_id = ? AND purpose = ? AND consumed_at IS NULL", userID, purpose).
+		Update("consumed_at", consumedAt).Error
+}
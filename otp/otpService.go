@@ -0,0 +1,130 @@
+package otp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"taman-pempek/notification"
+	"time"
+)
+
+const (
+	CodeLength  = 6
+	CodeTTL     = 10 * time.Minute
+	MaxAttempts = 5
+	ResendDelay = time.Minute
+)
+
+type OTPService interface {
+	Issue(userID uint64, purpose string, channel string, destination string) error
+	Verify(userID uint64, purpose string, destination string, code string) error
+}
+
+type service struct {
+	otpRepository OTPRepository
+	notifiers     map[string]notification.Notifier
+}
+
+func NewService(otpRepository OTPRepository, notifiers map[string]notification.Notifier) *service {
+	return &service{otpRepository, notifiers}
+}
+
+func (s *service) Issue(userID uint64, purpose string, channel string, destination string) error {
+	notifier, ok := s.notifiers[channel]
+
+	if !ok {
+		return errors.New("Unsupported channel")
+	}
+
+	now := time.Now()
+
+	if latest, err := s.otpRepository.FindActiveOTP(userID, purpose); err == nil && now.Sub(latest.CreatedAt) < ResendDelay {
+		return errors.New("Please wait before requesting a new code")
+	}
+
+	code, err := generateCode()
+
+	if err != nil {
+		return err
+	}
+
+	if err := s.otpRepository.InvalidateOTPs(userID, purpose, now); err != nil {
+		return err
+	}
+
+	otpData := OTP{
+		UserID:      userID,
+		Purpose:     purpose,
+		Channel:     channel,
+		Destination: destination,
+		CodeHash:    hashCode(code),
+		ExpiresAt:   now.Add(CodeTTL),
+	}
+
+	if _, err := s.otpRepository.CreateOTP(otpData); err != nil {
+		return err
+	}
+
+	return notifier.Send(notification.Message{
+		Destination: destination,
+		Subject:     "Taman Pempek verification code",
+		Body:        fmt.Sprintf("Your Taman Pempek code is %s. It expires in %d minutes. Do not share this code with anyone.", code, int(CodeTTL.Minutes())),
+	})
+}
+
+func (s *service) Verify(userID uint64, purpose string, destination string, code string) error {
+	otp, err := s.otpRepository.FindActiveOTP(userID, purpose)
+
+	if err != nil {
+		return errors.New("Invalid or expired code")
+	}
+
+	if time.Now().After(otp.ExpiresAt) || otp.Attempts >= MaxAttempts || otp.Destination != destination {
+		return errors.New("Invalid or expired code")
+	}
+
+	if !hmac.Equal([]byte(otp.CodeHash), []byte(hashCode(code))) {
+		if err := s.otpRepository.IncrementAttempts(otp.ID); err != nil {
+			return err
+		}
+		return errors.New("Invalid or expired code")
+	}
+
+	consumed, err := s.otpRepository.ConsumeOTP(otp.ID, time.Now())
+
+	if err != nil {
+		return err
+	}
+
+	if !consumed {
+		return errors.New("Invalid or expired code")
+	}
+
+	return nil
+}
+
+func generateCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < CodeLength; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+
+	n, err := rand.Int(rand.Reader, max)
+
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%0*d", CodeLength, n), nil
+}
+
+func hashCode(code string) string {
+	mac := hmac.New(sha256.New, []byte(os.Getenv("SECRET")))
+	mac.Write([]byte(code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,174 @@
+package otp
+
+import (
+	"errors"
+	"regexp"
+	"taman-pempek/notification"
+	"testing"
+	"time"
+)
+
+type memoryRepository struct {
+	otps []OTP
+}
+
+func (r *memoryRepository) FindActiveOTP(userID uint64, purpose string) (OTP, error) {
+	for i := len(r.otps) - 1; i >= 0; i-- {
+		otp := r.otps[i]
+		if otp.UserID == userID && otp.Purpose == purpose && otp.ConsumedAt == nil {
+			return otp, nil
+		}
+	}
+	return OTP{}, errors.New("OTP not found")
+}
+
+func (r *memoryRepository) CreateOTP(otp OTP) (OTP, error) {
+	otp.ID = uint64(len(r.otps) + 1)
+	otp.CreatedAt = time.Now()
+	r.otps = append(r.otps, otp)
+	return otp, nil
+}
+
+func (r *memoryRepository) ConsumeOTP(ID uint64, consumedAt time.Time) (bool, error) {
+	otp := &r.otps[ID-1]
+	if otp.ConsumedAt != nil {
+		return false, nil
+	}
+	otp.ConsumedAt = &consumedAt
+	return true, nil
+}
+
+func (r *memoryRepository) IncrementAttempts(ID uint64) error {
+	r.otps[ID-1].Attempts++
+	return nil
+}
+
+func (r *memoryRepository) InvalidateOTPs(userID uint64, purpose string, consumedAt time.Time) error {
+	for i := range r.otps {
+		if r.otps[i].UserID == userID && r.otps[i].Purpose == purpose && r.otps[i].ConsumedAt == nil {
+			r.otps[i].ConsumedAt = &consumedAt
+		}
+	}
+	return nil
+}
+
+var codePattern = regexp.MustCompile(`\d{6}`)
+
+func newTestService() (*service, *memoryRepository, *notification.FakeNotifier) {
+	repository := &memoryRepository{}
+	notifier := notification.NewFakeNotifier()
+	return NewService(repository, map[string]notification.Notifier{notification.ChannelEmail: notifier}), repository, notifier
+}
+
+func sentCode(t *testing.T, notifier *notification.FakeNotifier, destination string) string {
+	message, ok := notifier.LastMessage(destination)
+	if !ok {
+		t.Fatalf("no message sent to %s", destination)
+	}
+
+	code := codePattern.FindString(message.Body)
+	if code == "" {
+		t.Fatalf("no code in message %q", message.Body)
+	}
+	return code
+}
+
+func TestIssueAndVerify(t *testing.T) {
+	s, repository, notifier := newTestService()
+
+	if err := s.Issue(1, PurposeResetPassword, notification.ChannelEmail, "buyer@example.com"); err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	code := sentCode(t, notifier, "buyer@example.com")
+
+	if repository.otps[0].CodeHash == code {
+		t.Fatal("code is stored in plain text")
+	}
+
+	if err := s.Verify(1, PurposeResetPassword, "buyer@example.com", code); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+
+	if err := s.Verify(1, PurposeResetPassword, "buyer@example.com", code); err == nil {
+		t.Fatal("code was accepted twice")
+	}
+}
+
+func TestVerifyRejectsOtherPurpose(t *testing.T) {
+	s, _, notifier := newTestService()
+
+	if err := s.Issue(1, PurposeVerifyEmail, notification.ChannelEmail, "buyer@example.com"); err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	if err := s.Verify(1, PurposeResetPassword, "buyer@example.com", sentCode(t, notifier, "buyer@example.com")); err == nil {
+		t.Fatal("code was accepted for another purpose")
+	}
+}
+
+func TestIssueThrottlesResend(t *testing.T) {
+	s, _, notifier := newTestService()
+
+	if err := s.Issue(1, PurposeResetPassword, notification.ChannelEmail, "buyer@example.com"); err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	if err := s.Issue(1, PurposeResetPassword, notification.ChannelEmail, "buyer@example.com"); err == nil {
+		t.Fatal("second code was issued within the resend delay")
+	}
+
+	if got := len(notifier.Messages()); got != 1 {
+		t.Fatalf("sent %d messages, want 1", got)
+	}
+}
+
+func TestVerifyRejectsOtherDestination(t *testing.T) {
+	s, _, notifier := newTestService()
+
+	if err := s.Issue(1, PurposeVerifyEmail, notification.ChannelEmail, "old@example.com"); err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	code := sentCode(t, notifier, "old@example.com")
+
+	if err := s.Verify(1, PurposeVerifyEmail, "new@example.com", code); err == nil {
+		t.Fatal("code sent to the old address verified the new one")
+	}
+
+	if err := s.Verify(1, PurposeVerifyEmail, "old@example.com", code); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+}
+
+func TestVerifyLocksAfterMaxAttempts(t *testing.T) {
+	s, _, notifier := newTestService()
+
+	if err := s.Issue(1, PurposeResetPassword, notification.ChannelEmail, "buyer@example.com"); err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	code := sentCode(t, notifier, "buyer@example.com")
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+
+	for i := 0; i < MaxAttempts; i++ {
+		if err := s.Verify(1, PurposeResetPassword, "buyer@example.com", wrong); err == nil {
+			t.Fatal("wrong code was accepted")
+		}
+	}
+
+	if err := s.Verify(1, PurposeResetPassword, "buyer@example.com", code); err == nil {
+		t.Fatal("code was accepted after too many attempts")
+	}
+}
+
+func TestIssueRejectsUnknownChannel(t *testing.T) {
+	s, _, _ := newTestService()
+
+	if err := s.Issue(1, PurposeResetPassword, "pigeon", "buyer@example.com"); err == nil {
+		t.Fatal("code was issued on an unsupported channel")
+	}
+}
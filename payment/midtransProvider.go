@@ -0,0 +1,185 @@
+package payment
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type midtransProvider struct {
+	baseURL   string
+	serverKey string
+	client    *http.Client
+}
+
+func NewMidtransProvider(baseURL string, serverKey string) *midtransProvider {
+	return &midtransProvider{baseURL, serverKey, &http.Client{Timeout: 15 * time.Second}}
+}
+
+type midtransChargeResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	TransactionID     string `json:"transaction_id"`
+	OrderID           string `json:"order_id"`
+	TransactionStatus string `json:"transaction_status"`
+	QRString          string `json:"qr_string"`
+	ExpiryTime        string `json:"expiry_time"`
+	VANumbers         []struct {
+		Bank     string `json:"bank"`
+		VANumber string `json:"va_number"`
+	} `json:"va_numbers"`
+	Actions []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"actions"`
+}
+
+type midtransNotification struct {
+	TransactionID     string `json:"transaction_id"`
+	OrderID           string `json:"order_id"`
+	StatusCode        string `json:"status_code"`
+	GrossAmount       string `json:"gross_amount"`
+	TransactionStatus string `json:"transaction_status"`
+	FraudStatus       string `json:"fraud_status"`
+	SignatureKey      string `json:"signature_key"`
+}
+
+func (p *midtransProvider) Name() string {
+	return "midtrans"
+}
+
+func (p *midtransProvider) CreateCharge(request ChargeRequest) (ChargeResult, error) {
+	body := map[string]any{
+		"payment_type": request.Method,
+		"transaction_details": map[string]any{
+			"order_id":     request.OrderID,
+			"gross_amount": request.Amount,
+		},
+		"customer_details": map[string]any{
+			"first_name": request.Name,
+			"phone":      request.Whatsapp,
+		},
+	}
+
+	switch request.Method {
+	case MethodBankTransfer:
+		if request.Bank == "" {
+			return ChargeResult{}, errors.New("Bank is required for bank transfer")
+		}
+		body["bank_transfer"] = map[string]any{"bank": request.Bank}
+	case MethodQRIS, MethodGopay, MethodShopeepay:
+	default:
+		return ChargeResult{}, errors.New("Unsupported payment method")
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return ChargeResult{}, err
+	}
+
+	httpRequest, err := http.NewRequest(http.MethodPost, p.baseURL+"/v2/charge", bytes.NewReader(payload))
+	if err != nil {
+		return ChargeResult{}, err
+	}
+
+	httpRequest.SetBasicAuth(p.serverKey, "")
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Accept", "application/json")
+
+	httpResponse, err := p.client.Do(httpRequest)
+	if err != nil {
+		return ChargeResult{}, fmt.Errorf("Payment gateway unavailable: %w", err)
+	}
+	defer httpResponse.Body.Close()
+
+	var response midtransChargeResponse
+
+	if err := json.NewDecoder(httpResponse.Body).Decode(&response); err != nil {
+		return ChargeResult{}, fmt.Errorf("Invalid payment gateway response: %w", err)
+	}
+
+	if httpResponse.StatusCode >= http.StatusBadRequest || (response.StatusCode != "200" && response.StatusCode != "201") {
+		return ChargeResult{}, fmt.Errorf("Payment gateway rejected charge: %s", response.StatusMessage)
+	}
+
+	result := ChargeResult{
+		Reference: response.TransactionID,
+		Status:    mapMidtransStatus(response.TransactionStatus, ""),
+		QRString:  response.QRString,
+	}
+
+	if len(response.VANumbers) > 0 {
+		result.VABank = response.VANumbers[0].Bank
+		result.VANumber = response.VANumbers[0].VANumber
+	}
+
+	for _, action := range response.Actions {
+		if action.Name == "deeplink-redirect" || (action.Name == "generate-qr-code" && result.RedirectURL == "") {
+			result.RedirectURL = action.URL
+		}
+	}
+
+	if expiresAt, err := time.ParseInLocation("2006-01-02 15:04:05", response.ExpiryTime, time.Local); err == nil {
+		result.ExpiresAt = &expiresAt
+	}
+
+	return result, nil
+}
+
+func (p *midtransProvider) ParseWebhook(header http.Header, body []byte) (WebhookEvent, error) {
+	var notification midtransNotification
+
+	if err := json.Unmarshal(body, &notification); err != nil {
+		return WebhookEvent{}, errors.New("Invalid notification payload")
+	}
+
+	expected := MidtransSignature(notification.OrderID, notification.StatusCode, notification.GrossAmount, p.serverKey)
+
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(notification.SignatureKey)) != 1 {
+		return WebhookEvent{}, errors.New("Invalid notification signature")
+	}
+
+	amount, err := strconv.ParseFloat(notification.GrossAmount, 64)
+	if err != nil {
+		return WebhookEvent{}, errors.New("Invalid notification amount")
+	}
+
+	return WebhookEvent{
+		EventKey:  notification.TransactionID + ":" + notification.TransactionStatus,
+		OrderID:   notification.OrderID,
+		Reference: notification.TransactionID,
+		Status:    mapMidtransStatus(notification.TransactionStatus, notification.FraudStatus),
+		Amount:    int(math.Round(amount)),
+	}, nil
+}
+
+func MidtransSignature(orderID string, statusCode string, grossAmount string, serverKey string) string {
+	sum := sha512.Sum512([]byte(orderID + statusCode + grossAmount + serverKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func mapMidtransStatus(transactionStatus string, fraudStatus string) string {
+	switch transactionStatus {
+	case "settlement":
+		return ChargeSettled
+	case "capture":
+		if fraudStatus == "" || fraudStatus == "accept" {
+			return ChargeSettled
+		}
+		return ChargePending
+	case "expire":
+		return ChargeExpired
+	case "cancel", "deny", "failure":
+		return ChargeCancelled
+	default:
+		return ChargePending
+	}
+}
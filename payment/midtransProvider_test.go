@@ -0,0 +1,142 @@
+package payment
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testServerKey = "SB-Mid-server-test"
+
+type capturedWebhooks struct {
+	bodies chan []byte
+}
+
+func (c *capturedWebhooks) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	c.bodies <- body
+	w.WriteHeader(http.StatusOK)
+}
+
+func newTestGateway(t *testing.T) (*midtransProvider, *httptest.Server, *capturedWebhooks) {
+	webhooks := &capturedWebhooks{bodies: make(chan []byte, 1)}
+	notify := httptest.NewServer(webhooks)
+	t.Cleanup(notify.Close)
+
+	gateway := httptest.NewServer(NewMockGatewayServer(testServerKey, notify.URL))
+	t.Cleanup(gateway.Close)
+
+	return NewMidtransProvider(gateway.URL, testServerKey), gateway, webhooks
+}
+
+func simulate(t *testing.T, gateway *httptest.Server, orderID string, status string) {
+	payload, _ := json.Marshal(map[string]string{"order_id": orderID, "transaction_status": status})
+
+	response, err := http.Post(gateway.URL+"/simulate", "application/json", bytes.NewReader(payload))
+	if err != nil {
+		t.Fatalf("simulate: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("simulate returned %d", response.StatusCode)
+	}
+}
+
+func TestCreateChargeBankTransfer(t *testing.T) {
+	provider, _, _ := newTestGateway(t)
+
+	result, err := provider.CreateCharge(ChargeRequest{OrderID: "TP-1-1", Amount: 150000, Method: MethodBankTransfer, Bank: "bca"})
+	if err != nil {
+		t.Fatalf("CreateCharge: %v", err)
+	}
+
+	if result.Status != ChargePending {
+		t.Errorf("status = %s, want %s", result.Status, ChargePending)
+	}
+	if result.VABank != "bca" || len(result.VANumber) != 12 {
+		t.Errorf("virtual account = %s %s", result.VABank, result.VANumber)
+	}
+	if result.Reference == "" || result.ExpiresAt == nil {
+		t.Errorf("reference = %q, expires at = %v", result.Reference, result.ExpiresAt)
+	}
+}
+
+func TestCreateChargeQRIS(t *testing.T) {
+	provider, _, _ := newTestGateway(t)
+
+	result, err := provider.CreateCharge(ChargeRequest{OrderID: "TP-2-1", Amount: 50000, Method: MethodQRIS})
+	if err != nil {
+		t.Fatalf("CreateCharge: %v", err)
+	}
+
+	if !strings.HasSuffix(result.QRString, "TP-2-1") {
+		t.Errorf("qr string = %s", result.QRString)
+	}
+	if !strings.HasSuffix(result.RedirectURL, "/qr/TP-2-1") {
+		t.Errorf("redirect url = %s", result.RedirectURL)
+	}
+}
+
+func TestCreateChargeValidatesRequest(t *testing.T) {
+	provider, _, _ := newTestGateway(t)
+
+	if _, err := provider.CreateCharge(ChargeRequest{OrderID: "TP-3-1", Amount: 1000, Method: MethodBankTransfer}); err == nil {
+		t.Error("bank transfer without a bank was accepted")
+	}
+
+	if _, err := provider.CreateCharge(ChargeRequest{OrderID: "TP-3-2", Amount: 1000, Method: "cheque"}); err == nil {
+		t.Error("unsupported method was accepted")
+	}
+}
+
+func TestCreateChargeRejectsWrongServerKey(t *testing.T) {
+	_, gateway, _ := newTestGateway(t)
+	provider := NewMidtransProvider(gateway.URL, "wrong-key")
+
+	if _, err := provider.CreateCharge(ChargeRequest{OrderID: "TP-4-1", Amount: 1000, Method: MethodQRIS}); err == nil {
+		t.Fatal("charge with a wrong server key was accepted")
+	}
+}
+
+func TestWebhookFromGateway(t *testing.T) {
+	provider, gateway, webhooks := newTestGateway(t)
+
+	result, err := provider.CreateCharge(ChargeRequest{OrderID: "TP-5-1", Amount: 150123, Method: MethodGopay})
+	if err != nil {
+		t.Fatalf("CreateCharge: %v", err)
+	}
+
+	for status, want := range map[string]string{"settlement": ChargeSettled, "expire": ChargeExpired, "deny": ChargeCancelled} {
+		simulate(t, gateway, "TP-5-1", status)
+
+		event, err := provider.ParseWebhook(http.Header{}, <-webhooks.bodies)
+		if err != nil {
+			t.Fatalf("ParseWebhook %s: %v", status, err)
+		}
+
+		if event.Status != want || event.Amount != 150123 || event.OrderID != "TP-5-1" || event.Reference != result.Reference {
+			t.Errorf("%s event = %+v", status, event)
+		}
+	}
+}
+
+func TestParseWebhookRejectsTamperedPayload(t *testing.T) {
+	provider, gateway, webhooks := newTestGateway(t)
+
+	if _, err := provider.CreateCharge(ChargeRequest{OrderID: "TP-6-1", Amount: 1000, Method: MethodQRIS}); err != nil {
+		t.Fatalf("CreateCharge: %v", err)
+	}
+
+	simulate(t, gateway, "TP-6-1", "settlement")
+
+	body := bytes.Replace(<-webhooks.bodies, []byte(`"1000.00"`), []byte(`"1.00"`), 1)
+
+	if _, err := provider.ParseWebhook(http.Header{}, body); err == nil {
+		t.Fatal("tampered notification was accepted")
+	}
+}
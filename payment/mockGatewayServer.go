@@ -0,0 +1,167 @@
+package payment
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+type mockTransaction struct {
+	TransactionID string
+	OrderID       string
+	GrossAmount   string
+	PaymentType   string
+}
+
+type MockGatewayServer struct {
+	serverKey string
+	notifyURL string
+	client    *http.Client
+
+	mu           sync.Mutex
+	transactions map[string]mockTransaction
+}
+
+func NewMockGatewayServer(serverKey string, notifyURL string) *MockGatewayServer {
+	return &MockGatewayServer{
+		serverKey:    serverKey,
+		notifyURL:    notifyURL,
+		client:       &http.Client{Timeout: 10 * time.Second},
+		transactions: map[string]mockTransaction{},
+	}
+}
+
+func (s *MockGatewayServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/v2/charge":
+		s.charge(w, r)
+	case r.Method == http.MethodPost && r.URL.Path == "/simulate":
+		s.simulate(w, r)
+	default:
+		writeMockJSON(w, http.StatusNotFound, map[string]any{"status_code": "404", "status_message": "Not found"})
+	}
+}
+
+func (s *MockGatewayServer) charge(w http.ResponseWriter, r *http.Request) {
+	username, _, ok := r.BasicAuth()
+
+	if !ok || username != s.serverKey {
+		writeMockJSON(w, http.StatusUnauthorized, map[string]any{"status_code": "401", "status_message": "Unauthorized"})
+		return
+	}
+
+	var request struct {
+		PaymentType        string `json:"payment_type"`
+		TransactionDetails struct {
+			OrderID     string `json:"order_id"`
+			GrossAmount int    `json:"gross_amount"`
+		} `json:"transaction_details"`
+		BankTransfer struct {
+			Bank string `json:"bank"`
+		} `json:"bank_transfer"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeMockJSON(w, http.StatusBadRequest, map[string]any{"status_code": "400", "status_message": "Invalid body"})
+		return
+	}
+
+	transaction := mockTransaction{
+		TransactionID: fmt.Sprintf("mock-%d", time.Now().UnixNano()),
+		OrderID:       request.TransactionDetails.OrderID,
+		GrossAmount:   fmt.Sprintf("%d.00", request.TransactionDetails.GrossAmount),
+		PaymentType:   request.PaymentType,
+	}
+
+	s.mu.Lock()
+	s.transactions[transaction.OrderID] = transaction
+	s.mu.Unlock()
+
+	response := map[string]any{
+		"status_code":        "201",
+		"status_message":     "Success, transaction is created",
+		"transaction_id":     transaction.TransactionID,
+		"order_id":           transaction.OrderID,
+		"gross_amount":       transaction.GrossAmount,
+		"payment_type":       transaction.PaymentType,
+		"transaction_status": "pending",
+		"expiry_time":        time.Now().Add(24 * time.Hour).Format("2006-01-02 15:04:05"),
+	}
+
+	switch request.PaymentType {
+	case MethodBankTransfer:
+		response["va_numbers"] = []map[string]string{{
+			"bank":      request.BankTransfer.Bank,
+			"va_number": fmt.Sprintf("8%011d", rand.Int63n(1e11)),
+		}}
+	case MethodQRIS:
+		response["qr_string"] = "00020101021226MOCKQRIS" + transaction.OrderID
+		response["actions"] = []map[string]string{{"name": "generate-qr-code", "url": "http://" + r.Host + "/qr/" + transaction.OrderID}}
+	default:
+		response["actions"] = []map[string]string{{"name": "deeplink-redirect", "url": "http://" + r.Host + "/pay/" + transaction.OrderID}}
+	}
+
+	writeMockJSON(w, http.StatusOK, response)
+}
+
+func (s *MockGatewayServer) simulate(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		OrderID           string `json:"order_id"`
+		TransactionStatus string `json:"transaction_status"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeMockJSON(w, http.StatusBadRequest, map[string]any{"status_message": "Invalid body"})
+		return
+	}
+
+	s.mu.Lock()
+	transaction, ok := s.transactions[request.OrderID]
+	s.mu.Unlock()
+
+	if !ok {
+		writeMockJSON(w, http.StatusNotFound, map[string]any{"status_message": "Transaction not found"})
+		return
+	}
+
+	statusCode := "200"
+	if strings.EqualFold(request.TransactionStatus, "pending") {
+		statusCode = "201"
+	} else if request.TransactionStatus != "settlement" && request.TransactionStatus != "capture" {
+		statusCode = "202"
+	}
+
+	notification := map[string]string{
+		"transaction_id":     transaction.TransactionID,
+		"order_id":           transaction.OrderID,
+		"status_code":        statusCode,
+		"gross_amount":       transaction.GrossAmount,
+		"payment_type":       transaction.PaymentType,
+		"transaction_status": request.TransactionStatus,
+		"fraud_status":       "accept",
+		"signature_key":      MidtransSignature(transaction.OrderID, statusCode, transaction.GrossAmount, s.serverKey),
+	}
+
+	payload, _ := json.Marshal(notification)
+
+	response, err := s.client.Post(s.notifyURL, "application/json", bytes.NewReader(payload))
+
+	if err != nil {
+		writeMockJSON(w, http.StatusBadGateway, map[string]any{"status_message": err.Error()})
+		return
+	}
+	defer response.Body.Close()
+
+	writeMockJSON(w, http.StatusOK, map[string]any{"status_message": "Notification sent", "notify_status": response.StatusCode})
+}
+
+func writeMockJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
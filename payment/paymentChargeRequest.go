@@ -0,0 +1,6 @@
+package payment
+
+type PaymentChargeRequest struct {
+	Method string `json:"method" binding:"required,oneof=bank_transfer qris gopay shopeepay"`
+	Bank   string `json:"bank"`
+}
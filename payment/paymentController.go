@@ -1,26 +1,25 @@
 package payment
 
 import (
-	"context"
 	"fmt"
-	"log"
 	"net/http"
-	"os"
 	"strconv"
+	"strings"
+	"taman-pempek/storage"
 
-	"github.com/cloudinary/cloudinary-go/v2"
-	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
-	"github.com/joho/godotenv"
 )
 
+const proofFolder = "payment/proof"
+
 type controller struct {
 	paymentService PaymentService
+	mediaStore     storage.MediaStore
 }
 
-func NewController(paymentService PaymentService) *controller {
-	return &controller{paymentService}
+func NewController(paymentService PaymentService, mediaStore storage.MediaStore) *controller {
+	return &controller{paymentService, mediaStore}
 }
 
 func (cn *controller) GetPayments(c *gin.Context) {
@@ -100,6 +99,15 @@ func (cn *controller) GetPaymentByUserAndStatus(c *gin.Context) {
 
 	paymentStatus := c.Param("paymentStatus")
 
+	if !IsValidStatus(paymentStatus) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid payment status",
+		})
+		return
+	}
+
 	payments, err := cn.paymentService.FindPaymentByUserAndStatus(userId, paymentStatus)
 
 	if err != nil {
@@ -129,6 +137,15 @@ func (cn *controller) GetPaymentByUserAndStatus(c *gin.Context) {
 func (cn *controller) GetPaymentByStatus(c *gin.Context) {
 	paymentStatus := c.Param("paymentStatus")
 
+	if !IsValidStatus(paymentStatus) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid payment status",
+		})
+		return
+	}
+
 	payments, err := cn.paymentService.FindPaymentByStatus(paymentStatus)
 
 	if err != nil {
@@ -158,11 +175,6 @@ func (cn *controller) GetPaymentByStatus(c *gin.Context) {
 func (cn *controller) CreatePayment(c *gin.Context) {
 	var paymentRequest PaymentCreateRequest
 
-	apiKey := goDotEnvVariable("APIKEY")
-	apiSecret := goDotEnvVariable("APISECRET")
-
-	urlCloudinary := "cloudinary://" + apiKey + ":" + apiSecret + "@dqudegiey"
-
 	err := c.ShouldBind(&paymentRequest)
 
 	if err != nil {
@@ -179,7 +191,22 @@ func (cn *controller) CreatePayment(c *gin.Context) {
 		return
 	}
 
-	file, err := paymentRequest.Image.Open()
+	if paymentRequest.Image.Filename != "" {
+		media, err := storage.Upload(cn.mediaStore, proofFolder, &paymentRequest.Image, storage.PaymentProof)
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": true,
+				"data":  nil,
+				"msg":   err.Error(),
+			})
+			return
+		}
+
+		paymentRequest.Image.Filename = media.URL
+	}
+
+	payment, err := cn.paymentService.CreatePayment(paymentRequest)
 
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -190,23 +217,58 @@ func (cn *controller) CreatePayment(c *gin.Context) {
 		return
 	}
 
-	ctx := context.Background()
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToPaymentResponse(payment),
+	})
+}
+
+func (cn *controller) UpdatePayment(c *gin.Context) {
+	var paymentRequest PaymentUpdateRequest
 
-	cldService, err := cloudinary.NewFromURL(urlCloudinary)
+	err := c.ShouldBind(&paymentRequest)
 
 	if err != nil {
+		errorMessages := []string{}
+		for _, e := range err.(validator.ValidationErrors) {
+			errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+			errorMessages = append(errorMessages, errorMessage)
+		}
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": true,
 			"data":  nil,
-			"msg":   err.Error(),
+			"msg":   errorMessages,
 		})
 		return
 	}
 
-	imageResponse, err := cldService.Upload.Upload(ctx, file, uploader.UploadParams{})
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
 
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid payment ID",
+		})
+		return
+	}
+
+	payment, err := cn.paymentService.UpdatePayment(id, paymentRequest, actorFromContext(c))
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "Payment not found" {
+			statusCode = http.StatusNotFound
+		}
+		if err.Error() == "Invalid payment status" {
+			statusCode = http.StatusBadRequest
+		}
+		if strings.HasPrefix(err.Error(), "Cannot change payment status") {
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, gin.H{
 			"error": true,
 			"data":  nil,
 			"msg":   err.Error(),
@@ -214,12 +276,34 @@ func (cn *controller) CreatePayment(c *gin.Context) {
 		return
 	}
 
-	paymentRequest.Image.Filename = imageResponse.SecureURL
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToPaymentResponse(payment),
+	})
+}
 
-	payment, err := cn.paymentService.CreatePayment(paymentRequest)
+func (ch *controller) DeletePayment(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
 
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid payment ID",
+		})
+		return
+	}
+
+	payment, err := ch.paymentService.DeletePayment(id)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "Payment not found" {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
 			"error": true,
 			"data":  nil,
 			"msg":   err.Error(),
@@ -234,10 +318,10 @@ func (cn *controller) CreatePayment(c *gin.Context) {
 	})
 }
 
-func (cn *controller) UpdatePayment(c *gin.Context) {
-	var paymentRequest PaymentUpdateRequest
+func (cn *controller) UpdatePaymentStatus(c *gin.Context) {
+	var statusRequest PaymentStatusRequest
 
-	err := c.ShouldBind(&paymentRequest)
+	err := c.ShouldBindJSON(&statusRequest)
 
 	if err != nil {
 		errorMessages := []string{}
@@ -265,13 +349,19 @@ func (cn *controller) UpdatePayment(c *gin.Context) {
 		return
 	}
 
-	payment, err := cn.paymentService.UpdatePayment(id, paymentRequest)
+	payment, err := cn.paymentService.TransitionStatus(id, statusRequest.Status, actorFromContext(c), statusRequest.Note)
 
 	if err != nil {
 		statusCode := http.StatusInternalServerError
 		if err.Error() == "Payment not found" {
 			statusCode = http.StatusNotFound
 		}
+		if err.Error() == "Invalid payment status" {
+			statusCode = http.StatusBadRequest
+		}
+		if strings.HasPrefix(err.Error(), "Cannot change payment status") || err.Error() == "Payment status has been changed by another request" {
+			statusCode = http.StatusConflict
+		}
 		c.JSON(statusCode, gin.H{
 			"error": true,
 			"data":  nil,
@@ -287,7 +377,7 @@ func (cn *controller) UpdatePayment(c *gin.Context) {
 	})
 }
 
-func (ch *controller) DeletePayment(c *gin.Context) {
+func (cn *controller) GetPaymentTimeline(c *gin.Context) {
 	idString := c.Param("id")
 	id, err := strconv.Atoi(idString)
 
@@ -300,7 +390,7 @@ func (ch *controller) DeletePayment(c *gin.Context) {
 		return
 	}
 
-	payment, err := ch.paymentService.DeletePayment(id)
+	histories, err := cn.paymentService.FindStatusHistory(id)
 
 	if err != nil {
 		statusCode := http.StatusInternalServerError
@@ -315,6 +405,99 @@ func (ch *controller) DeletePayment(c *gin.Context) {
 		return
 	}
 
+	historiesResponse := []PaymentStatusHistoryResponse{}
+
+	for _, history := range histories {
+		historiesResponse = append(historiesResponse, convertToPaymentStatusHistoryResponse(history))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  historiesResponse,
+	})
+}
+
+func (cn *controller) GetReviewQueue(c *gin.Context) {
+	reviews, err := cn.paymentService.FindPendingReviews()
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	reviewsResponse := []PaymentReviewResponse{}
+
+	for _, review := range reviews {
+		reviewsResponse = append(reviewsResponse, PaymentReviewResponse{
+			Payment: convertToPaymentResponse(review.Payment),
+			Proof:   convertToPaymentProofResponse(review.Proof),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  reviewsResponse,
+	})
+}
+
+func (cn *controller) ApprovePaymentProof(c *gin.Context) {
+	cn.reviewPaymentProof(c, true)
+}
+
+func (cn *controller) RejectPaymentProof(c *gin.Context) {
+	cn.reviewPaymentProof(c, false)
+}
+
+func (cn *controller) reviewPaymentProof(c *gin.Context, approve bool) {
+	var reviewRequest PaymentReviewRequest
+
+	c.ShouldBindJSON(&reviewRequest)
+
+	if !approve && strings.TrimSpace(reviewRequest.Note) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Rejection reason is required",
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid payment ID",
+		})
+		return
+	}
+
+	payment, err := cn.paymentService.ReviewProof(id, approve, reviewRequest.Note, actorFromContext(c))
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "Payment not found" || err.Error() == "Payment proof not found" {
+			statusCode = http.StatusNotFound
+		}
+		if strings.HasPrefix(err.Error(), "Cannot change payment status") || err.Error() == "Payment status has been changed by another request" {
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"error": false,
 		"msg":   "Success!",
@@ -322,25 +505,182 @@ func (ch *controller) DeletePayment(c *gin.Context) {
 	})
 }
 
+func (cn *controller) UploadPaymentProof(c *gin.Context) {
+	var proofRequest PaymentProofRequest
+
+	err := c.ShouldBind(&proofRequest)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Error on Image field, condition required",
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid payment ID",
+		})
+		return
+	}
+
+	media, err := storage.Upload(cn.mediaStore, proofFolder, &proofRequest.Image, storage.PaymentProof)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	payment, err := cn.paymentService.SubmitProof(id, media.URL, actorFromContext(c))
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "Payment not found" {
+			statusCode = http.StatusNotFound
+		}
+		if strings.HasPrefix(err.Error(), "Cannot") || err.Error() == "Payment status has been changed by another request" {
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToPaymentResponse(payment),
+	})
+}
+
+func (cn *controller) GetPaymentProofs(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid payment ID",
+		})
+		return
+	}
+
+	proofs, err := cn.paymentService.FindProofs(id)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "Payment not found" {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	proofsResponse := []PaymentProofResponse{}
+
+	for _, proof := range proofs {
+		proofsResponse = append(proofsResponse, convertToPaymentProofResponse(proof))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  proofsResponse,
+	})
+}
+
+func actorFromContext(c *gin.Context) Actor {
+	return Actor{
+		ID:   c.GetUint64("UserID"),
+		Role: c.GetString("UserRole"),
+	}
+}
+
+func convertToPaymentStatusHistoryResponse(history PaymentStatusHistory) PaymentStatusHistoryResponse {
+	return PaymentStatusHistoryResponse{
+		ID:         history.ID,
+		PaymentID:  history.PaymentID,
+		FromStatus: history.FromStatus,
+		ToStatus:   history.ToStatus,
+		ActorID:    history.ActorID,
+		ActorRole:  history.ActorRole,
+		Note:       history.Note,
+		CreatedAt:  history.CreatedAt,
+	}
+}
+
+func convertToPaymentProofResponse(proof PaymentProof) PaymentProofResponse {
+	return PaymentProofResponse{
+		ID:         proof.ID,
+		PaymentID:  proof.PaymentID,
+		Image:      proof.Image,
+		Status:     proof.Status,
+		ReviewerID: proof.ReviewerID,
+		Note:       proof.Note,
+		ReviewedAt: proof.ReviewedAt,
+		CreatedAt:  proof.CreatedAt,
+	}
+}
+
 func convertToPaymentResponse(payment Payment) PaymentResponse {
 	return PaymentResponse{
-		ID:            payment.ID,
-		UserID:        payment.UserID,
-		DeliveryID:    payment.DeliveryID,
-		TotalPrice:    payment.TotalPrice,
-		Image:         payment.Image,
-		Address:       payment.Address,
-		Whatsapp:      payment.Whatsapp,
-		PaymentStatus: payment.PaymentStatus,
-		DeliveryName:  payment.DeliveryName,
-		Resi:          payment.Resi,
+		ID:              payment.ID,
+		UserID:          payment.UserID,
+		DeliveryID:      payment.DeliveryID,
+		TotalPrice:      payment.TotalPrice,
+		Image:           payment.Image,
+		Address:         payment.Address,
+		Whatsapp:        payment.Whatsapp,
+		PaymentStatus:   payment.PaymentStatus,
+		PaymentMethod:   payment.PaymentMethod,
+		DeliveryName:    payment.DeliveryName,
+		Resi:            payment.Resi,
+		ShippingFee:     payment.ShippingFee,
+		ShippingCourier: payment.ShippingCourier,
+		ShippingService: payment.ShippingService,
+		SlotID:          payment.SlotID,
+		ScheduledDate:   payment.ScheduledDate,
+		ScheduledWindow: payment.ScheduledWindow,
+		UniqueCode:      payment.UniqueCode,
+		TransferAmount:  payment.TransferAmount,
+		RefundedAmount:  payment.RefundedAmount,
+		ShippingAddress: convertToAddressSnapshotResponse(payment.AddressSnapshot),
 	}
 }
 
-func goDotEnvVariable(key string) string {
-	err := godotenv.Load(".env")
-	if err != nil {
-		log.Fatalf("Error loading .env file")
+func convertToAddressSnapshotResponse(snapshot AddressSnapshot) *AddressSnapshotResponse {
+	if snapshot.AddressID == 0 {
+		return nil
+	}
+
+	return &AddressSnapshotResponse{
+		AddressID:   snapshot.AddressID,
+		Recipient:   snapshot.Recipient,
+		Province:    snapshot.Province,
+		City:        snapshot.City,
+		District:    snapshot.District,
+		SubDistrict: snapshot.SubDistrict,
+		PostalCode:  snapshot.PostalCode,
+		Latitude:    snapshot.Latitude,
+		Longitude:   snapshot.Longitude,
 	}
-	return os.Getenv(key)
 }
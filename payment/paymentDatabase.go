@@ -5,16 +5,42 @@ import (
 )
 
 type Payment struct {
-	ID            uint64    `gorm:"column:id;primaryKey;autoIncrement"`
-	UserID        int       `gorm:"column:user_id;type:varchar(255)"`
-	DeliveryID    int       `gorm:"column:delivery_id;type:varchar(255)"`
-	TotalPrice    int       `gorm:"column:total_price;type:varchar(255)"`
-	Image         string    `gorm:"column:image;type:varchar(255)"`
-	Address       string    `gorm:"column:address;type:varchar(255)"`
-	Whatsapp      string    `gorm:"column:whatsapp;type:varchar(255)"`
-	PaymentStatus string    `gorm:"column:payment_status;type:varchar(255)"`
-	DeliveryName  string    `gorm:"column:delivery_name;type:varchar(255)"`
-	Resi          string    `gorm:"column:resi;type:varchar(255)"`
-	CreatedAt     time.Time `gorm:"column:created_at;autoCreateTime"`
-	UpdatedAt     time.Time `gorm:"column:updated_at;autoUpdateTime"`
+	ID              uint64 `gorm:"column:id;primaryKey;autoIncrement"`
+	UserID          int    `gorm:"column:user_id;type:varchar(255)"`
+	DeliveryID      int    `gorm:"column:delivery_id;type:varchar(255)"`
+	TotalPrice      int    `gorm:"column:total_price;type:varchar(255)"`
+	Image           string `gorm:"column:image;type:varchar(255)"`
+	Address         string `gorm:"column:address;type:varchar(255)"`
+	AddressSnapshot `gorm:"embedded"`
+	Whatsapp        string     `gorm:"column:whatsapp;type:varchar(255)"`
+	PaymentStatus   string     `gorm:"column:payment_status;type:varchar(255)"`
+	PaymentMethod   string     `gorm:"column:payment_method;type:varchar(255);default:transfer"`
+	DeliveryName    string     `gorm:"column:delivery_name;type:varchar(255)"`
+	Resi            string     `gorm:"column:resi;type:varchar(255)"`
+	ShippingFee     int        `gorm:"column:shipping_fee"`
+	ShippingCourier string     `gorm:"column:shipping_courier;type:varchar(255)"`
+	ShippingService string     `gorm:"column:shipping_service;type:varchar(255)"`
+	SlotID          uint64     `gorm:"column:slot_id;index"`
+	ScheduledDate   string     `gorm:"column:scheduled_date;type:varchar(10);index"`
+	ScheduledWindow string     `gorm:"column:scheduled_window;type:varchar(11)"`
+	StockReserved   bool       `gorm:"column:stock_reserved"`
+	UniqueCode      int        `gorm:"column:unique_code"`
+	TransferAmount  int        `gorm:"column:transfer_amount;index"`
+	OpenTransfer    *int       `gorm:"column:open_transfer_amount;uniqueIndex"`
+	RefundedAmount  int        `gorm:"column:refunded_amount"`
+	SettledAt       *time.Time `gorm:"column:settled_at"`
+	CreatedAt       time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt       time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+type AddressSnapshot struct {
+	AddressID   uint64   `gorm:"column:address_id;index"`
+	Recipient   string   `gorm:"column:recipient;type:varchar(255)"`
+	Province    string   `gorm:"column:province;type:varchar(255)"`
+	City        string   `gorm:"column:city;type:varchar(255)"`
+	District    string   `gorm:"column:district;type:varchar(255)"`
+	SubDistrict string   `gorm:"column:sub_district;type:varchar(255)"`
+	PostalCode  string   `gorm:"column:postal_code;type:varchar(10)"`
+	Latitude    *float64 `gorm:"column:latitude"`
+	Longitude   *float64 `gorm:"column:longitude"`
 }
@@ -0,0 +1,54 @@
+package payment
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	MethodBankTransfer = "bank_transfer"
+	MethodQRIS         = "qris"
+	MethodGopay        = "gopay"
+	MethodShopeepay    = "shopeepay"
+)
+
+const (
+	ChargePending   = "pending"
+	ChargeSettled   = "settled"
+	ChargeExpired   = "expired"
+	ChargeCancelled = "cancelled"
+	ChargeRefundDue = "refund_due"
+)
+
+type ChargeRequest struct {
+	OrderID  string
+	Amount   int
+	Method   string
+	Bank     string
+	Name     string
+	Whatsapp string
+}
+
+type ChargeResult struct {
+	Reference   string
+	Status      string
+	VABank      string
+	VANumber    string
+	QRString    string
+	RedirectURL string
+	ExpiresAt   *time.Time
+}
+
+type WebhookEvent struct {
+	EventKey  string
+	OrderID   string
+	Reference string
+	Status    string
+	Amount    int
+}
+
+type PaymentProvider interface {
+	Name() string
+	CreateCharge(request ChargeRequest) (ChargeResult, error)
+	ParseWebhook(header http.Header, body []byte) (WebhookEvent, error)
+}
@@ -0,0 +1,202 @@
+package payment
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type gatewayController struct {
+	gatewayService GatewayService
+}
+
+func NewGatewayController(gatewayService GatewayService) *gatewayController {
+	return &gatewayController{gatewayService}
+}
+
+func (cn *gatewayController) CreateCharge(c *gin.Context) {
+	var chargeRequest PaymentChargeRequest
+
+	err := c.ShouldBindJSON(&chargeRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		for _, e := range err.(validator.ValidationErrors) {
+			errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+			errorMessages = append(errorMessages, errorMessage)
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid payment ID",
+		})
+		return
+	}
+
+	charge, err := cn.gatewayService.CreateCharge(id, chargeRequest)
+
+	if err != nil {
+		statusCode := http.StatusBadGateway
+		if err.Error() == "Payment not found" {
+			statusCode = http.StatusNotFound
+		}
+		if strings.HasPrefix(err.Error(), "Cannot charge") {
+			statusCode = http.StatusConflict
+		}
+		if err.Error() == "Bank is required for bank transfer" || err.Error() == "Unsupported payment method" {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToPaymentChargeResponse(charge),
+	})
+}
+
+func (cn *gatewayController) GetCharges(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid payment ID",
+		})
+		return
+	}
+
+	charges, err := cn.gatewayService.FindCharges(id)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "Payment not found" {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	chargesResponse := []PaymentChargeResponse{}
+
+	for _, charge := range charges {
+		chargesResponse = append(chargesResponse, convertToPaymentChargeResponse(charge))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  chargesResponse,
+	})
+}
+
+func (cn *gatewayController) Webhook(c *gin.Context) {
+	body, err := io.ReadAll(c.Request.Body)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Failed to read request",
+		})
+		return
+	}
+
+	err = cn.gatewayService.HandleWebhook(c.Request.Header, body)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "Invalid notification signature" {
+			statusCode = http.StatusUnauthorized
+		}
+		if err.Error() == "Invalid notification payload" || err.Error() == "Invalid notification amount" || err.Error() == "Notification amount does not match charge" {
+			statusCode = http.StatusBadRequest
+		}
+		if err.Error() == "Payment charge not found" {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"data":  nil,
+		"msg":   "Success!",
+	})
+}
+
+func convertToPaymentChargeResponse(charge PaymentCharge) PaymentChargeResponse {
+	return PaymentChargeResponse{
+		ID:          charge.ID,
+		PaymentID:   charge.PaymentID,
+		Provider:    charge.Provider,
+		Method:      charge.Method,
+		OrderID:     charge.OrderID,
+		Amount:      charge.Amount,
+		Status:      charge.Status,
+		VABank:      charge.VABank,
+		VANumber:    charge.VANumber,
+		QRString:    charge.QRString,
+		RedirectURL: charge.RedirectURL,
+		ExpiresAt:   charge.ExpiresAt,
+	}
+}
+
+func (cn *gatewayController) GetChargesByStatus(c *gin.Context) {
+	charges, err := cn.gatewayService.FindChargesByStatus(c.Param("status"))
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	chargesResponse := []PaymentChargeResponse{}
+
+	for _, charge := range charges {
+		chargesResponse = append(chargesResponse, convertToPaymentChargeResponse(charge))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  chargesResponse,
+	})
+}
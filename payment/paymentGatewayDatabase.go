@@ -0,0 +1,31 @@
+package payment
+
+import "time"
+
+type PaymentCharge struct {
+	ID          uint64     `gorm:"column:id;primaryKey;autoIncrement"`
+	PaymentID   uint64     `gorm:"column:payment_id;index"`
+	Provider    string     `gorm:"column:provider;type:varchar(255)"`
+	Method      string     `gorm:"column:method;type:varchar(255)"`
+	OrderID     string     `gorm:"column:order_id;type:varchar(255);uniqueIndex"`
+	Reference   string     `gorm:"column:reference;type:varchar(255)"`
+	Amount      int        `gorm:"column:amount"`
+	Status      string     `gorm:"column:status;type:varchar(255)"`
+	VABank      string     `gorm:"column:va_bank;type:varchar(255)"`
+	VANumber    string     `gorm:"column:va_number;type:varchar(255)"`
+	QRString    string     `gorm:"column:qr_string;type:text"`
+	RedirectURL string     `gorm:"column:redirect_url;type:varchar(255)"`
+	ExpiresAt   *time.Time `gorm:"column:expires_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+type PaymentGatewayEvent struct {
+	ID        uint64    `gorm:"column:id;primaryKey;autoIncrement"`
+	Provider  string    `gorm:"column:provider;type:varchar(64);uniqueIndex:idx_gateway_event"`
+	EventKey  string    `gorm:"column:event_key;type:varchar(191);uniqueIndex:idx_gateway_event"`
+	OrderID   string    `gorm:"column:order_id;type:varchar(255)"`
+	Status    string    `gorm:"column:status;type:varchar(255)"`
+	Payload   string    `gorm:"column:payload;type:text"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
@@ -0,0 +1,155 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type GatewayService interface {
+	CreateCharge(ID int, request PaymentChargeRequest) (PaymentCharge, error)
+	FindCharges(ID int) ([]PaymentCharge, error)
+	FindChargesByStatus(status string) ([]PaymentCharge, error)
+	HandleWebhook(header http.Header, body []byte) error
+}
+
+type gatewayService struct {
+	paymentRepository PaymentRepository
+	paymentService    PaymentService
+	provider          PaymentProvider
+}
+
+func NewGatewayService(paymentRepository PaymentRepository, paymentService PaymentService, provider PaymentProvider) *gatewayService {
+	return &gatewayService{paymentRepository, paymentService, provider}
+}
+
+func (s *gatewayService) CreateCharge(ID int, request PaymentChargeRequest) (PaymentCharge, error) {
+	payment, err := s.paymentRepository.FindPaymentByID(ID)
+
+	if err != nil {
+		return PaymentCharge{}, err
+	}
+
+	if payment.PaymentStatus != StatusAwaitingPayment {
+		return PaymentCharge{}, fmt.Errorf("Cannot charge payment with status %s", payment.PaymentStatus)
+	}
+
+	orderID := fmt.Sprintf("TP-%d-%d", payment.ID, time.Now().UnixMilli())
+
+	result, err := s.provider.CreateCharge(ChargeRequest{
+		OrderID:  orderID,
+		Amount:   payment.TotalPrice,
+		Method:   request.Method,
+		Bank:     strings.ToLower(request.Bank),
+		Whatsapp: payment.Whatsapp,
+	})
+
+	if err != nil {
+		return PaymentCharge{}, err
+	}
+
+	return s.paymentRepository.CreateCharge(PaymentCharge{
+		PaymentID:   payment.ID,
+		Provider:    s.provider.Name(),
+		Method:      request.Method,
+		OrderID:     orderID,
+		Reference:   result.Reference,
+		Amount:      payment.TotalPrice,
+		Status:      result.Status,
+		VABank:      result.VABank,
+		VANumber:    result.VANumber,
+		QRString:    result.QRString,
+		RedirectURL: result.RedirectURL,
+		ExpiresAt:   result.ExpiresAt,
+	})
+}
+
+func (s *gatewayService) FindCharges(ID int) ([]PaymentCharge, error) {
+	if _, err := s.paymentRepository.FindPaymentByID(ID); err != nil {
+		return nil, err
+	}
+
+	return s.paymentRepository.FindChargesByPayment(ID)
+}
+
+func (s *gatewayService) FindChargesByStatus(status string) ([]PaymentCharge, error) {
+	return s.paymentRepository.FindChargesByStatus(status)
+}
+
+func (s *gatewayService) HandleWebhook(header http.Header, body []byte) error {
+	event, err := s.provider.ParseWebhook(header, body)
+
+	if err != nil {
+		return err
+	}
+
+	exists, err := s.paymentRepository.GatewayEventExists(s.provider.Name(), event.EventKey)
+
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return nil
+	}
+
+	charge, err := s.paymentRepository.FindChargeByOrderID(event.OrderID)
+
+	if err != nil {
+		return err
+	}
+
+	if event.Amount != charge.Amount {
+		return errors.New("Notification amount does not match charge")
+	}
+
+	settled := event.Status == ChargeSettled && charge.Status != ChargeSettled && charge.Status != ChargeRefundDue
+
+	if charge.Status != ChargeSettled && charge.Status != ChargeRefundDue {
+		charge.Status = event.Status
+		charge.Reference = event.Reference
+
+		if charge, err = s.paymentRepository.UpdateCharge(charge); err != nil {
+			return err
+		}
+	}
+
+	if settled {
+		note := fmt.Sprintf("Paid via %s %s (%s)", s.provider.Name(), charge.Method, event.Reference)
+		_, err := s.paymentService.TransitionStatus(int(charge.PaymentID), StatusVerified, SystemActor, note)
+
+		if err != nil && !strings.HasPrefix(err.Error(), "Cannot change payment status") && err.Error() != "Payment status has been changed by another request" {
+			return err
+		}
+
+		if err != nil {
+			charge.Status = ChargeRefundDue
+
+			if _, err := s.paymentRepository.UpdateCharge(charge); err != nil {
+				return err
+			}
+
+			log.Printf("gateway: charge %s for payment %d settled but could not be applied (%v), marked for manual refund", charge.OrderID, charge.PaymentID, err)
+		}
+	}
+
+	_, err = s.paymentRepository.CreateGatewayEvent(PaymentGatewayEvent{
+		Provider: s.provider.Name(),
+		EventKey: event.EventKey,
+		OrderID:  event.OrderID,
+		Status:   event.Status,
+		Payload:  string(body),
+	})
+
+	if err != nil {
+		if exists, _ := s.paymentRepository.GatewayEventExists(s.provider.Name(), event.EventKey); exists {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
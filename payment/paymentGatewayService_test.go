@@ -0,0 +1,110 @@
+package payment
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+type gatewayRepository struct {
+	*memoryRepository
+	charges []PaymentCharge
+	events  []PaymentGatewayEvent
+}
+
+func (r *gatewayRepository) FindChargeByOrderID(orderID string) (PaymentCharge, error) {
+	for _, charge := range r.charges {
+		if charge.OrderID == orderID {
+			return charge, nil
+		}
+	}
+	return PaymentCharge{}, errors.New("Payment charge not found")
+}
+
+func (r *gatewayRepository) UpdateCharge(charge PaymentCharge) (PaymentCharge, error) {
+	r.charges[charge.ID-1] = charge
+	return charge, nil
+}
+
+func (r *gatewayRepository) GatewayEventExists(provider string, eventKey string) (bool, error) {
+	for _, event := range r.events {
+		if event.Provider == provider && event.EventKey == eventKey {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *gatewayRepository) CreateGatewayEvent(event PaymentGatewayEvent) (PaymentGatewayEvent, error) {
+	r.events = append(r.events, event)
+	return event, nil
+}
+
+type stubProvider struct {
+	event WebhookEvent
+}
+
+func (p *stubProvider) Name() string {
+	return "stub"
+}
+
+func (p *stubProvider) CreateCharge(request ChargeRequest) (ChargeResult, error) {
+	return ChargeResult{}, errors.New("not implemented")
+}
+
+func (p *stubProvider) ParseWebhook(header http.Header, body []byte) (WebhookEvent, error) {
+	return p.event, nil
+}
+
+func newTestGatewayService(status string) (*gatewayService, *gatewayRepository, *stubProvider) {
+	s, repository, _ := newStatusService(Payment{ID: 1, PaymentMethod: MethodTransfer, PaymentStatus: status, TotalPrice: 50000})
+	gateway := &gatewayRepository{
+		memoryRepository: repository,
+		charges:          []PaymentCharge{{ID: 1, PaymentID: 1, OrderID: "TP-1-1", Amount: 50000, Status: ChargePending}},
+	}
+	provider := &stubProvider{event: WebhookEvent{EventKey: "TP-1-1:settlement", OrderID: "TP-1-1", Reference: "ref-1", Status: ChargeSettled, Amount: 50000}}
+	return NewGatewayService(gateway, s, provider), gateway, provider
+}
+
+func TestWebhookSettlementVerifiesPayment(t *testing.T) {
+	s, repository, _ := newTestGatewayService(StatusAwaitingPayment)
+
+	if err := s.HandleWebhook(http.Header{}, []byte("{}")); err != nil {
+		t.Fatalf("HandleWebhook: %v", err)
+	}
+
+	if repository.payments[1].PaymentStatus != StatusVerified {
+		t.Errorf("status = %s", repository.payments[1].PaymentStatus)
+	}
+	if repository.charges[0].Status != ChargeSettled {
+		t.Errorf("charge status = %s", repository.charges[0].Status)
+	}
+}
+
+func TestWebhookSettlementAfterExpiryIsMarkedForRefund(t *testing.T) {
+	s, repository, provider := newTestGatewayService(StatusExpired)
+
+	if err := s.HandleWebhook(http.Header{}, []byte("{}")); err != nil {
+		t.Fatalf("HandleWebhook: %v", err)
+	}
+
+	if repository.payments[1].PaymentStatus != StatusExpired {
+		t.Errorf("status = %s", repository.payments[1].PaymentStatus)
+	}
+	if repository.charges[0].Status != ChargeRefundDue {
+		t.Errorf("charge status = %s, want %s", repository.charges[0].Status, ChargeRefundDue)
+	}
+	if len(repository.events) != 1 {
+		t.Errorf("got %d events, want 1", len(repository.events))
+	}
+
+	provider.event.EventKey = "TP-1-1:settlement:retry"
+
+	if err := s.HandleWebhook(http.Header{}, []byte("{}")); err != nil {
+		t.Fatalf("HandleWebhook retry: %v", err)
+	}
+
+	if repository.charges[0].Status != ChargeRefundDue {
+		t.Errorf("charge status after retry = %s", repository.charges[0].Status)
+	}
+}
@@ -0,0 +1,18 @@
+package payment
+
+import "time"
+
+type PaymentStatusHistory struct {
+	ID         uint64    `gorm:"column:id;primaryKey;autoIncrement"`
+	PaymentID  uint64    `gorm:"column:payment_id;index"`
+	FromStatus string    `gorm:"column:from_status;type:varchar(255)"`
+	ToStatus   string    `gorm:"column:to_status;type:varchar(255)"`
+	ActorID    uint64    `gorm:"column:actor_id"`
+	ActorRole  string    `gorm:"column:actor_role;type:varchar(255)"`
+	Note       string    `gorm:"column:note;type:text"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (PaymentStatusHistory) TableName() string {
+	return "payment_status_history"
+}
@@ -0,0 +1,25 @@
+package payment
+
+import "time"
+
+const (
+	MutationMatched   = "matched"
+	MutationAmbiguous = "ambiguous"
+	MutationUnmatched = "unmatched"
+)
+
+type BankMutation struct {
+	ID           uint64    `gorm:"column:id;primaryKey;autoIncrement"`
+	BankID       uint64    `gorm:"column:bank_id;index"`
+	Fingerprint  string    `gorm:"column:fingerprint;type:varchar(64);uniqueIndex"`
+	MutationDate string    `gorm:"column:mutation_date;type:varchar(255)"`
+	Description  string    `gorm:"column:description;type:varchar(255)"`
+	Amount       int       `gorm:"column:amount"`
+	Status       string    `gorm:"column:status;type:varchar(255);index"`
+	PaymentID    *uint64   `gorm:"column:payment_id"`
+	CandidateIDs string    `gorm:"column:candidate_ids;type:varchar(255)"`
+	Note         string    `gorm:"column:note;type:text"`
+	ReviewerID   uint64    `gorm:"column:reviewer_id"`
+	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt    time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
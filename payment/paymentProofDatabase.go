@@ -0,0 +1,21 @@
+package payment
+
+import "time"
+
+const (
+	ProofPending  = "pending"
+	ProofApproved = "approved"
+	ProofRejected = "rejected"
+)
+
+type PaymentProof struct {
+	ID         uint64     `gorm:"column:id;primaryKey;autoIncrement"`
+	PaymentID  uint64     `gorm:"column:payment_id;index"`
+	Image      string     `gorm:"column:image;type:varchar(255)"`
+	Status     string     `gorm:"column:status;type:varchar(255)"`
+	ReviewerID *uint64    `gorm:"column:reviewer_id"`
+	Note       string     `gorm:"column:note;type:text"`
+	ReviewedAt *time.Time `gorm:"column:reviewed_at"`
+	CreatedAt  time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt  time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
@@ -2,18 +2,45 @@ package payment
 
 import (
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PaymentRepository interface {
 	FindAll() ([]Payment, error)
 	FindPaymentByID(ID int) (Payment, error)
+	LockPaymentByID(ID int) (Payment, error)
 	FindPaymentByUserAndStatus(userID int, paymentStatus string) ([]Payment, error)
 	FindPaymentByStatus(paymentStatus string) ([]Payment, error)
+	FindPaymentsByStatusBefore(paymentStatus string, before time.Time) ([]Payment, error)
 	CreatePayment(payment Payment) (Payment, error)
 	UpdatePayment(payment Payment) (Payment, error)
 	DeletePayment(payment Payment) (Payment, error)
+	TransitionStatus(ID uint64, from string, history PaymentStatusHistory) (bool, error)
+	AddRefundedAmount(ID uint64, amount int) error
+	CreateStatusHistory(history PaymentStatusHistory) (PaymentStatusHistory, error)
+	FindStatusHistory(paymentID int) ([]PaymentStatusHistory, error)
+	CreateProof(proof PaymentProof) (PaymentProof, error)
+	UpdateProof(proof PaymentProof) (PaymentProof, error)
+	FindProofsByPayment(paymentID int) ([]PaymentProof, error)
+	FindLatestProof(paymentID int, status string) (PaymentProof, error)
+	CreateCharge(charge PaymentCharge) (PaymentCharge, error)
+	UpdateCharge(charge PaymentCharge) (PaymentCharge, error)
+	FindChargeByOrderID(orderID string) (PaymentCharge, error)
+	FindChargesByPayment(paymentID int) ([]PaymentCharge, error)
+	FindChargesByStatus(status string) ([]PaymentCharge, error)
+	GatewayEventExists(provider string, eventKey string) (bool, error)
+	CreateGatewayEvent(event PaymentGatewayEvent) (PaymentGatewayEvent, error)
+	FindOpenTransferAmounts() ([]int, error)
+	CreateTransferPayment(payment Payment) (Payment, error)
+	FindOpenPaymentsByTransferAmount(amount int) ([]Payment, error)
+	BankMutationExists(fingerprint string) (bool, error)
+	CreateBankMutation(mutation BankMutation) (BankMutation, error)
+	UpdateBankMutation(mutation BankMutation) (BankMutation, error)
+	FindBankMutationByID(ID int) (BankMutation, error)
+	FindBankMutationsByStatus(status string) ([]BankMutation, error)
 }
 
 type repository struct {
@@ -35,6 +62,15 @@ func (r *repository) FindPaymentByID(ID int) (Payment, error) {
 	return payment, err
 }
 
+func (r *repository) LockPaymentByID(ID int) (Payment, error) {
+	var payment Payment
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&payment, ID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Payment{}, errors.New("Payment not found")
+	}
+	return payment, err
+}
+
 func (r *repository) FindPaymentByUserAndStatus(userID int, paymentStatus string) ([]Payment, error) {
 	var payments []Payment
 	err := r.db.Where("user_id = ? AND payment_status = ?", userID, paymentStatus).Find(&payments).Error
@@ -63,7 +99,7 @@ func (r *repository) CreatePayment(payment Payment) (Payment, error) {
 }
 
 func (r *repository) UpdatePayment(payment Payment) (Payment, error) {
-	err := r.db.Save(&payment).Error
+	err := r.db.Omit("payment_status", "open_transfer_amount", "refunded_amount", "settled_at").Save(&payment).Error
 	return payment, err
 }
 
@@ -71,3 +107,192 @@ func (r *repository) DeletePayment(payment Payment) (Payment, error) {
 	err := r.db.Delete(&payment).Error
 	return payment, err
 }
+
+func (r *repository) TransitionStatus(ID uint64, from string, history PaymentStatusHistory) (bool, error) {
+	transitioned := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{"payment_status": history.ToStatus}
+
+		if !IsOpenStatus(history.ToStatus) {
+			updates["open_transfer_amount"] = nil
+		}
+
+		result := tx.Model(&Payment{}).
+			Where("id = ? AND payment_status = ?", ID, from).
+			Updates(updates)
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		transitioned = true
+
+		return tx.Create(&history).Error
+	})
+
+	return transitioned, err
+}
+
+func (r *repository) AddRefundedAmount(ID uint64, amount int) error {
+	return r.db.Model(&Payment{}).Where("id = ?", ID).Update("refunded_amount", gorm.Expr("refunded_amount + ?", amount)).Error
+}
+
+func (r *repository) CreateStatusHistory(history PaymentStatusHistory) (PaymentStatusHistory, error) {
+	err := r.db.Create(&history).Error
+	return history, err
+}
+
+func (r *repository) FindStatusHistory(paymentID int) ([]PaymentStatusHistory, error) {
+	var histories []PaymentStatusHistory
+	err := r.db.Where("payment_id = ?", paymentID).Order("id ASC").Find(&histories).Error
+	return histories, err
+}
+
+func (r *repository) CreateProof(proof PaymentProof) (PaymentProof, error) {
+	err := r.db.Create(&proof).Error
+	return proof, err
+}
+
+func (r *repository) UpdateProof(proof PaymentProof) (PaymentProof, error) {
+	err := r.db.Save(&proof).Error
+	return proof, err
+}
+
+func (r *repository) FindProofsByPayment(paymentID int) ([]PaymentProof, error) {
+	var proofs []PaymentProof
+	err := r.db.Where("payment_id = ?", paymentID).Order("id ASC").Find(&proofs).Error
+	return proofs, err
+}
+
+func (r *repository) FindLatestProof(paymentID int, status string) (PaymentProof, error) {
+	var proof PaymentProof
+	err := r.db.Where("payment_id = ? AND status = ?", paymentID, status).Order("id DESC").First(&proof).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return PaymentProof{}, errors.New("Payment proof not found")
+	}
+	return proof, err
+}
+
+func (r *repository) CreateCharge(charge PaymentCharge) (PaymentCharge, error) {
+	err := r.db.Create(&charge).Error
+	return charge, err
+}
+
+func (r *repository) UpdateCharge(charge PaymentCharge) (PaymentCharge, error) {
+	err := r.db.Save(&charge).Error
+	return charge, err
+}
+
+func (r *repository) FindChargeByOrderID(orderID string) (PaymentCharge, error) {
+	var charge PaymentCharge
+	err := r.db.Where("order_id = ?", orderID).First(&charge).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return PaymentCharge{}, errors.New("Payment charge not found")
+	}
+	return charge, err
+}
+
+func (r *repository) FindChargesByPayment(paymentID int) ([]PaymentCharge, error) {
+	var charges []PaymentCharge
+	err := r.db.Where("payment_id = ?", paymentID).Order("id ASC").Find(&charges).Error
+	return charges, err
+}
+
+func (r *repository) FindChargesByStatus(status string) ([]PaymentCharge, error) {
+	var charges []PaymentCharge
+	err := r.db.Where("status = ?", status).Order("id").Find(&charges).Error
+	return charges, err
+}
+
+func (r *repository) GatewayEventExists(provider string, eventKey string) (bool, error) {
+	var count int64
+	err := r.db.Model(&PaymentGatewayEvent{}).Where("provider = ? AND event_key = ?", provider, eventKey).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repository) CreateGatewayEvent(event PaymentGatewayEvent) (PaymentGatewayEvent, error) {
+	err := r.db.Create(&event).Error
+	return event, err
+}
+
+func (r *repository) FindPaymentsByStatusBefore(paymentStatus string, before time.Time) ([]Payment, error) {
+	var payments []Payment
+	enteredAt := r.db.Model(&PaymentStatusHistory{}).
+		Select("MAX(created_at)").
+		Where("payment_id = payments.id AND to_status = ?", paymentStatus)
+
+	err := r.db.Where("payment_status = ? AND COALESCE((?), payments.created_at) < ?", paymentStatus, enteredAt, before).Find(&payments).Error
+	return payments, err
+}
+
+func (r *repository) FindOpenTransferAmounts() ([]int, error) {
+	var amounts []int
+	err := r.db.Model(&Payment{}).Where("payment_status IN ? AND transfer_amount > 0", OpenStatuses).Pluck("transfer_amount", &amounts).Error
+	return amounts, err
+}
+
+func (r *repository) CreateTransferPayment(payment Payment) (Payment, error) {
+	openAmounts, err := r.FindOpenTransferAmounts()
+
+	if err != nil {
+		return Payment{}, err
+	}
+
+	for i := 0; i < maxUniqueAttempts; i++ {
+		if err := AssignUniqueCode(&payment, openAmounts); err != nil {
+			return Payment{}, err
+		}
+
+		err = r.db.Create(&payment).Error
+
+		if !errors.Is(err, gorm.ErrDuplicatedKey) {
+			return payment, err
+		}
+
+		openAmounts = append(openAmounts, payment.TransferAmount)
+	}
+
+	return Payment{}, errors.New("No unique transfer code available")
+}
+
+func (r *repository) FindOpenPaymentsByTransferAmount(amount int) ([]Payment, error) {
+	var payments []Payment
+	err := r.db.Where("payment_status IN ? AND transfer_amount = ?", OpenStatuses, amount).Find(&payments).Error
+	return payments, err
+}
+
+func (r *repository) BankMutationExists(fingerprint string) (bool, error) {
+	var count int64
+	err := r.db.Model(&BankMutation{}).Where("fingerprint = ?", fingerprint).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repository) CreateBankMutation(mutation BankMutation) (BankMutation, error) {
+	err := r.db.Create(&mutation).Error
+	return mutation, err
+}
+
+func (r *repository) UpdateBankMutation(mutation BankMutation) (BankMutation, error) {
+	err := r.db.Save(&mutation).Error
+	return mutation, err
+}
+
+func (r *repository) FindBankMutationByID(ID int) (BankMutation, error) {
+	var mutation BankMutation
+	err := r.db.First(&mutation, ID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return BankMutation{}, errors.New("Bank mutation not found")
+	}
+	return mutation, err
+}
+
+func (r *repository) FindBankMutationsByStatus(status string) ([]BankMutation, error) {
+	var mutations []BankMutation
+	err := r.db.Where("status = ?", status).Order("id").Find(&mutations).Error
+	return mutations, err
+}
@@ -1,14 +1,102 @@
 package payment
 
+import "time"
+
 type PaymentResponse struct {
-	ID            uint64 `json:"id"`
-	UserID        int    `json:"user_id"`
-	DeliveryID    int    `json:"delivery_id"`
-	TotalPrice    int    `json:"total_price"`
-	Image         string `json:"image"`
-	Address       string `json:"address"`
-	Whatsapp      string `json:"whatsapp"`
-	PaymentStatus string `json:"payment_status"`
-	DeliveryName  string `json:"delivery_name"`
-	Resi          string `json:"resi"`
+	ID              uint64                   `json:"id"`
+	UserID          int                      `json:"user_id"`
+	DeliveryID      int                      `json:"delivery_id"`
+	TotalPrice      int                      `json:"total_price"`
+	Image           string                   `json:"image"`
+	Address         string                   `json:"address"`
+	ShippingAddress *AddressSnapshotResponse `json:"shipping_address"`
+	Whatsapp        string                   `json:"whatsapp"`
+	PaymentStatus   string                   `json:"payment_status"`
+	PaymentMethod   string                   `json:"payment_method"`
+	DeliveryName    string                   `json:"delivery_name"`
+	Resi            string                   `json:"resi"`
+	ShippingFee     int                      `json:"shipping_fee"`
+	ShippingCourier string                   `json:"shipping_courier"`
+	ShippingService string                   `json:"shipping_service"`
+	SlotID          uint64                   `json:"slot_id"`
+	ScheduledDate   string                   `json:"scheduled_date"`
+	ScheduledWindow string                   `json:"scheduled_window"`
+	UniqueCode      int                      `json:"unique_code"`
+	TransferAmount  int                      `json:"transfer_amount"`
+	RefundedAmount  int                      `json:"refunded_amount"`
+}
+
+type PaymentStatusHistoryResponse struct {
+	ID         uint64    `json:"id"`
+	PaymentID  uint64    `json:"payment_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	ActorID    uint64    `json:"actor_id"`
+	ActorRole  string    `json:"actor_role"`
+	Note       string    `json:"note"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type PaymentProofResponse struct {
+	ID         uint64     `json:"id"`
+	PaymentID  uint64     `json:"payment_id"`
+	Image      string     `json:"image"`
+	Status     string     `json:"status"`
+	ReviewerID *uint64    `json:"reviewer_id"`
+	Note       string     `json:"note"`
+	ReviewedAt *time.Time `json:"reviewed_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type PaymentReviewResponse struct {
+	Payment PaymentResponse      `json:"payment"`
+	Proof   PaymentProofResponse `json:"proof"`
+}
+
+type PaymentChargeResponse struct {
+	ID          uint64     `json:"id"`
+	PaymentID   uint64     `json:"payment_id"`
+	Provider    string     `json:"provider"`
+	Method      string     `json:"method"`
+	OrderID     string     `json:"order_id"`
+	Amount      int        `json:"amount"`
+	Status      string     `json:"status"`
+	VABank      string     `json:"va_bank"`
+	VANumber    string     `json:"va_number"`
+	QRString    string     `json:"qr_string"`
+	RedirectURL string     `json:"redirect_url"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+type BankMutationResponse struct {
+	ID           uint64    `json:"id"`
+	BankID       uint64    `json:"bank_id"`
+	MutationDate string    `json:"mutation_date"`
+	Description  string    `json:"description"`
+	Amount       int       `json:"amount"`
+	Status       string    `json:"status"`
+	PaymentID    *uint64   `json:"payment_id"`
+	CandidateIDs []uint64  `json:"candidate_ids"`
+	Note         string    `json:"note"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ReconciliationResponse struct {
+	Matched    int                    `json:"matched"`
+	Ambiguous  int                    `json:"ambiguous"`
+	Unmatched  int                    `json:"unmatched"`
+	Duplicates int                    `json:"duplicates"`
+	Mutations  []BankMutationResponse `json:"mutations"`
+}
+
+type AddressSnapshotResponse struct {
+	AddressID   uint64   `json:"address_id"`
+	Recipient   string   `json:"recipient"`
+	Province    string   `json:"province"`
+	City        string   `json:"city"`
+	District    string   `json:"district"`
+	SubDistrict string   `json:"sub_district"`
+	PostalCode  string   `json:"postal_code"`
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
 }
@@ -0,0 +1,11 @@
+package payment
+
+import "mime/multipart"
+
+type PaymentReviewRequest struct {
+	Note string `json:"note"`
+}
+
+type PaymentProofRequest struct {
+	Image multipart.FileHeader `form:"image" binding:"required"`
+}
@@ -2,6 +2,9 @@ package payment
 
 import (
 	"errors"
+	"fmt"
+	"taman-pempek/user"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -12,16 +15,33 @@ type PaymentService interface {
 	FindPaymentByUserAndStatus(userID int, paymentStatus string) ([]Payment, error)
 	FindPaymentByStatus(paymentStatus string) ([]Payment, error)
 	CreatePayment(payment PaymentCreateRequest) (Payment, error)
-	UpdatePayment(ID int, payment PaymentUpdateRequest) (Payment, error)
+	UpdatePayment(ID int, payment PaymentUpdateRequest, actor Actor) (Payment, error)
 	DeletePayment(ID int) (Payment, error)
+	TransitionStatus(ID int, status string, actor Actor, note string) (Payment, error)
+	FindStatusHistory(ID int) ([]PaymentStatusHistory, error)
+	SubmitProof(ID int, image string, actor Actor) (Payment, error)
+	ReviewProof(ID int, approve bool, note string, actor Actor) (Payment, error)
+	FindPendingReviews() ([]PaymentReview, error)
+	FindProofs(ID int) ([]PaymentProof, error)
+	CollectCash(ID int, actor Actor, note string) (Payment, error)
+}
+
+type PaymentReview struct {
+	Payment Payment
+	Proof   PaymentProof
+}
+
+type OrderCanceller interface {
+	CancelOrder(payment Payment, history PaymentStatusHistory) (bool, error)
 }
 
 type service struct {
 	paymentRepository PaymentRepository
+	orderCanceller    OrderCanceller
 }
 
-func NewService(paymentRepository PaymentRepository) *service {
-	return &service{paymentRepository}
+func NewService(paymentRepository PaymentRepository, orderCanceller OrderCanceller) *service {
+	return &service{paymentRepository, orderCanceller}
 }
 
 func (s *service) FindAll() ([]Payment, error) {
@@ -41,6 +61,26 @@ func (s *service) FindPaymentByStatus(paymentStatus string) ([]Payment, error) {
 }
 
 func (s *service) CreatePayment(paymentRequest PaymentCreateRequest) (Payment, error) {
+	method := paymentRequest.PaymentMethod
+
+	if method == "" {
+		method = MethodTransfer
+	}
+
+	if !IsValidMethod(method) {
+		return Payment{}, errors.New("Invalid payment method")
+	}
+
+	if method == MethodTransfer && paymentRequest.Image.Filename == "" {
+		return Payment{}, errors.New("Transfer proof image is required")
+	}
+
+	status := StatusProofUploaded
+
+	if IsCashMethod(method) {
+		status = StatusAwaitingCash
+	}
+
 	paymentData := Payment{
 		UserID:        paymentRequest.UserID,
 		DeliveryID:    paymentRequest.DeliveryID,
@@ -48,17 +88,43 @@ func (s *service) CreatePayment(paymentRequest PaymentCreateRequest) (Payment, e
 		Image:         paymentRequest.Image.Filename,
 		Address:       paymentRequest.Address,
 		Whatsapp:      paymentRequest.Whatsapp,
-		PaymentStatus: paymentRequest.PaymentStatus,
+		PaymentStatus: status,
+		PaymentMethod: method,
 		DeliveryName:  paymentRequest.DeliveryName,
 		Resi:          paymentRequest.Resi,
 	}
 
 	payment, err := s.paymentRepository.CreatePayment(paymentData)
 
+	if err != nil {
+		return Payment{}, err
+	}
+
+	_, err = s.paymentRepository.CreateStatusHistory(PaymentStatusHistory{
+		PaymentID: payment.ID,
+		ToStatus:  payment.PaymentStatus,
+		ActorID:   uint64(payment.UserID),
+		ActorRole: user.RoleBuyer,
+	})
+
+	if err != nil {
+		return Payment{}, err
+	}
+
+	if IsCashMethod(method) {
+		return payment, nil
+	}
+
+	_, err = s.paymentRepository.CreateProof(PaymentProof{
+		PaymentID: payment.ID,
+		Image:     payment.Image,
+		Status:    ProofPending,
+	})
+
 	return payment, err
 }
 
-func (s *service) UpdatePayment(ID int, paymentRequest PaymentUpdateRequest) (Payment, error) {
+func (s *service) UpdatePayment(ID int, paymentRequest PaymentUpdateRequest, actor Actor) (Payment, error) {
 	payment, err := s.paymentRepository.FindPaymentByID(ID)
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -68,21 +134,22 @@ func (s *service) UpdatePayment(ID int, paymentRequest PaymentUpdateRequest) (Pa
 	if err != nil {
 		return Payment{}, err
 	}
+
+	if paymentRequest.PaymentStatus != "" && paymentRequest.PaymentStatus != payment.PaymentStatus {
+		if err := checkTransition(payment, paymentRequest.PaymentStatus, actor); err != nil {
+			return Payment{}, err
+		}
+	}
+
 	if paymentRequest.DeliveryID != 0 {
 		payment.DeliveryID = paymentRequest.DeliveryID
 	}
-	if paymentRequest.TotalPrice != 0 {
-		payment.TotalPrice = paymentRequest.TotalPrice
-	}
 	if paymentRequest.Address != "" {
 		payment.Address = paymentRequest.Address
 	}
 	if paymentRequest.Whatsapp != "" {
 		payment.Whatsapp = paymentRequest.Whatsapp
 	}
-	if paymentRequest.PaymentStatus != "" {
-		payment.PaymentStatus = paymentRequest.PaymentStatus
-	}
 	if paymentRequest.DeliveryName != "" {
 		payment.DeliveryName = paymentRequest.DeliveryName
 	}
@@ -90,7 +157,17 @@ func (s *service) UpdatePayment(ID int, paymentRequest PaymentUpdateRequest) (Pa
 		payment.Resi = paymentRequest.Resi
 	}
 
-	return s.paymentRepository.UpdatePayment(payment)
+	payment, err = s.paymentRepository.UpdatePayment(payment)
+
+	if err != nil {
+		return Payment{}, err
+	}
+
+	if paymentRequest.PaymentStatus != "" && paymentRequest.PaymentStatus != payment.PaymentStatus {
+		return s.TransitionStatus(ID, paymentRequest.PaymentStatus, actor, "")
+	}
+
+	return payment, nil
 }
 
 func (s *service) DeletePayment(ID int) (Payment, error) {
@@ -106,3 +183,190 @@ func (s *service) DeletePayment(ID int) (Payment, error) {
 
 	return s.paymentRepository.DeletePayment(payment)
 }
+
+func (s *service) TransitionStatus(ID int, status string, actor Actor, note string) (Payment, error) {
+	payment, err := s.paymentRepository.FindPaymentByID(ID)
+
+	if err != nil {
+		return Payment{}, err
+	}
+
+	if err := checkTransition(payment, status, actor); err != nil {
+		return Payment{}, err
+	}
+
+	return s.applyTransition(payment, status, actor, note)
+}
+
+func (s *service) applyTransition(payment Payment, status string, actor Actor, note string) (Payment, error) {
+	if !CanTransition(payment.PaymentMethod, payment.PaymentStatus, status) {
+		return Payment{}, fmt.Errorf("Cannot change payment status from %s to %s", payment.PaymentStatus, status)
+	}
+
+	history := PaymentStatusHistory{
+		PaymentID:  payment.ID,
+		FromStatus: payment.PaymentStatus,
+		ToStatus:   status,
+		ActorID:    actor.ID,
+		ActorRole:  actor.Role,
+		Note:       note,
+	}
+
+	var transitioned bool
+	var err error
+
+	if status == StatusCancelled {
+		transitioned, err = s.orderCanceller.CancelOrder(payment, history)
+	} else {
+		transitioned, err = s.paymentRepository.TransitionStatus(payment.ID, payment.PaymentStatus, history)
+	}
+
+	if err != nil {
+		return Payment{}, err
+	}
+
+	if !transitioned {
+		return Payment{}, errors.New("Payment status has been changed by another request")
+	}
+
+	payment.PaymentStatus = status
+
+	return payment, nil
+}
+
+func (s *service) FindStatusHistory(ID int) ([]PaymentStatusHistory, error) {
+	if _, err := s.paymentRepository.FindPaymentByID(ID); err != nil {
+		return nil, err
+	}
+
+	return s.paymentRepository.FindStatusHistory(ID)
+}
+
+func checkTransition(payment Payment, to string, actor Actor) error {
+	if !IsValidStatus(to) {
+		return errors.New("Invalid payment status")
+	}
+
+	if !CanActorTransition(actor, payment.PaymentMethod, payment.PaymentStatus, to) {
+		return fmt.Errorf("Cannot change payment status from %s to %s", payment.PaymentStatus, to)
+	}
+
+	return nil
+}
+
+func (s *service) SubmitProof(ID int, image string, actor Actor) (Payment, error) {
+	payment, err := s.paymentRepository.FindPaymentByID(ID)
+
+	if err != nil {
+		return Payment{}, err
+	}
+
+	if payment.PaymentStatus != StatusAwaitingPayment {
+		return Payment{}, fmt.Errorf("Cannot upload proof for payment with status %s", payment.PaymentStatus)
+	}
+
+	payment, err = s.applyTransition(payment, StatusProofUploaded, actor, "")
+
+	if err != nil {
+		return Payment{}, err
+	}
+
+	_, err = s.paymentRepository.CreateProof(PaymentProof{
+		PaymentID: payment.ID,
+		Image:     image,
+		Status:    ProofPending,
+	})
+
+	if err != nil {
+		return Payment{}, err
+	}
+
+	payment.Image = image
+
+	return s.paymentRepository.UpdatePayment(payment)
+}
+
+func (s *service) ReviewProof(ID int, approve bool, note string, actor Actor) (Payment, error) {
+	proof, err := s.paymentRepository.FindLatestProof(ID, ProofPending)
+
+	if err != nil {
+		return Payment{}, err
+	}
+
+	status := StatusAwaitingPayment
+	proof.Status = ProofRejected
+
+	if approve {
+		status = StatusVerified
+		proof.Status = ProofApproved
+	}
+
+	payment, err := s.TransitionStatus(ID, status, actor, note)
+
+	if err != nil {
+		return Payment{}, err
+	}
+
+	now := time.Now()
+	proof.ReviewerID = &actor.ID
+	proof.Note = note
+	proof.ReviewedAt = &now
+
+	if _, err := s.paymentRepository.UpdateProof(proof); err != nil {
+		return Payment{}, err
+	}
+
+	return payment, nil
+}
+
+func (s *service) FindPendingReviews() ([]PaymentReview, error) {
+	payments, err := s.paymentRepository.FindPaymentByStatus(StatusProofUploaded)
+
+	if err != nil {
+		return nil, err
+	}
+
+	reviews := []PaymentReview{}
+
+	for _, payment := range payments {
+		proof, err := s.paymentRepository.FindLatestProof(int(payment.ID), ProofPending)
+
+		if err != nil {
+			continue
+		}
+
+		reviews = append(reviews, PaymentReview{Payment: payment, Proof: proof})
+	}
+
+	return reviews, nil
+}
+
+func (s *service) FindProofs(ID int) ([]PaymentProof, error) {
+	if _, err := s.paymentRepository.FindPaymentByID(ID); err != nil {
+		return nil, err
+	}
+
+	return s.paymentRepository.FindProofsByPayment(ID)
+}
+
+func (s *service) CollectCash(ID int, actor Actor, note string) (Payment, error) {
+	payment, err := s.paymentRepository.FindPaymentByID(ID)
+
+	if err != nil {
+		return Payment{}, err
+	}
+
+	if !IsCashMethod(payment.PaymentMethod) {
+		return Payment{}, errors.New("Payment is not a cash payment")
+	}
+
+	if payment.PaymentMethod == MethodCOD && payment.PaymentStatus != StatusShipped {
+		return Payment{}, fmt.Errorf("Cannot collect cash for payment with status %s", payment.PaymentStatus)
+	}
+
+	if payment.PaymentMethod == MethodPickup && payment.PaymentStatus != StatusReadyForPickup {
+		return Payment{}, fmt.Errorf("Cannot collect cash for payment with status %s", payment.PaymentStatus)
+	}
+
+	return s.applyTransition(payment, StatusDelivered, actor, note)
+}
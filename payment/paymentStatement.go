@@ -0,0 +1,134 @@
+package payment
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type StatementLine struct {
+	Date        string
+	Description string
+	Amount      int
+}
+
+var (
+	dateColumns        = []string{"date", "tanggal", "tgl"}
+	descriptionColumns = []string{"description", "keterangan", "remark"}
+	amountColumns      = []string{"amount", "nominal", "mutasi"}
+	creditColumns      = []string{"credit", "kredit"}
+	typeColumns        = []string{"type", "db/cr", "jenis"}
+	creditTypes        = []string{"cr", "c", "k", "kr", "credit", "kredit"}
+)
+
+func ParseStatement(reader io.Reader) ([]StatementLine, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+
+	if err != nil {
+		return nil, errors.New("Statement is empty or not a valid CSV")
+	}
+
+	dateIndex := columnIndex(header, dateColumns)
+	descriptionIndex := columnIndex(header, descriptionColumns)
+	amountIndex := columnIndex(header, amountColumns)
+	creditIndex := columnIndex(header, creditColumns)
+	typeIndex := columnIndex(header, typeColumns)
+
+	if amountIndex < 0 && creditIndex < 0 {
+		return nil, errors.New("Statement must have an amount or credit column")
+	}
+
+	lines := []StatementLine{}
+
+	for {
+		record, err := csvReader.Read()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, errors.New("Statement is not a valid CSV")
+		}
+
+		var raw string
+
+		if creditIndex >= 0 {
+			raw = field(record, creditIndex)
+		} else {
+			raw = field(record, amountIndex)
+
+			if typeIndex >= 0 && !contains(creditTypes, strings.ToLower(field(record, typeIndex))) {
+				continue
+			}
+		}
+
+		amount, ok := parseAmount(raw)
+
+		if !ok || amount <= 0 {
+			continue
+		}
+
+		lines = append(lines, StatementLine{
+			Date:        field(record, dateIndex),
+			Description: field(record, descriptionIndex),
+			Amount:      amount,
+		})
+	}
+
+	return lines, nil
+}
+
+func parseAmount(raw string) (int, bool) {
+	raw = strings.TrimSpace(strings.ToUpper(raw))
+	raw = strings.TrimSuffix(strings.TrimSuffix(raw, " CR"), "CR")
+	raw = strings.TrimPrefix(strings.TrimPrefix(raw, "RP"), ".")
+	raw = strings.ReplaceAll(raw, " ", "")
+
+	if raw == "" || strings.HasPrefix(raw, "-") {
+		return 0, false
+	}
+
+	if separator := strings.LastIndexAny(raw, ".,"); separator >= 0 && len(raw)-separator-1 == 2 {
+		if raw[separator+1:] != "00" {
+			return 0, false
+		}
+		raw = raw[:separator]
+	}
+
+	raw = strings.NewReplacer(".", "", ",", "").Replace(raw)
+	amount, err := strconv.Atoi(raw)
+
+	return amount, err == nil
+}
+
+func columnIndex(header []string, names []string) int {
+	for i, column := range header {
+		if contains(names, strings.ToLower(strings.TrimSpace(column))) {
+			return i
+		}
+	}
+	return -1
+}
+
+func field(record []string, index int) string {
+	if index < 0 || index >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[index])
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,12 @@
+package payment
+
+import "mime/multipart"
+
+type PaymentStatementRequest struct {
+	BankID    int                  `form:"bank_id" binding:"required"`
+	Statement multipart.FileHeader `form:"statement" binding:"required"`
+}
+
+type BankMutationMatchRequest struct {
+	PaymentID int `json:"payment_id" binding:"required"`
+}
@@ -0,0 +1,131 @@
+package payment
+
+import "taman-pempek/user"
+
+const (
+	StatusAwaitingPayment = "awaiting_payment"
+	StatusProofUploaded   = "proof_uploaded"
+	StatusVerified        = "verified"
+	StatusProcessing      = "processing"
+	StatusShipped         = "shipped"
+	StatusDelivered       = "delivered"
+	StatusCompleted       = "completed"
+	StatusCancelled       = "cancelled"
+	StatusRefunded        = "refunded"
+	StatusExpired         = "expired"
+	StatusAwaitingCash    = "awaiting_cash"
+	StatusReadyForPickup  = "ready_for_pickup"
+)
+
+const (
+	MethodTransfer = "transfer"
+	MethodCOD      = "cod"
+	MethodPickup   = "pickup"
+)
+
+const RoleSystem = "system"
+
+type Actor struct {
+	ID   uint64
+	Role string
+}
+
+var SystemActor = Actor{Role: RoleSystem}
+
+var transitions = map[string][]string{
+	StatusAwaitingPayment: {StatusProofUploaded, StatusVerified, StatusCancelled, StatusExpired},
+	StatusProofUploaded:   {StatusVerified, StatusAwaitingPayment, StatusCancelled},
+	StatusVerified:        {StatusProcessing, StatusCancelled, StatusRefunded},
+	StatusProcessing:      {StatusShipped, StatusCancelled, StatusRefunded},
+	StatusShipped:         {StatusDelivered, StatusRefunded},
+	StatusDelivered:       {StatusCompleted, StatusRefunded},
+	StatusCompleted:       {StatusRefunded},
+}
+
+var codTransitions = map[string][]string{
+	StatusAwaitingCash: {StatusProcessing, StatusCancelled},
+	StatusProcessing:   {StatusShipped, StatusCancelled},
+	StatusShipped:      {StatusDelivered, StatusCancelled},
+	StatusDelivered:    {StatusCompleted, StatusRefunded},
+	StatusCompleted:    {StatusRefunded},
+}
+
+var pickupTransitions = map[string][]string{
+	StatusAwaitingCash:   {StatusProcessing, StatusCancelled},
+	StatusProcessing:     {StatusReadyForPickup, StatusCancelled},
+	StatusReadyForPickup: {StatusDelivered, StatusCancelled},
+	StatusDelivered:      {StatusCompleted, StatusRefunded},
+	StatusCompleted:      {StatusRefunded},
+}
+
+var actorTargets = map[string][]string{
+	user.RoleBuyer: {StatusCancelled, StatusCompleted},
+}
+
+func IsValidMethod(method string) bool {
+	return method == MethodTransfer || method == MethodCOD || method == MethodPickup
+}
+
+func IsCashMethod(method string) bool {
+	return method == MethodCOD || method == MethodPickup
+}
+
+func InitialStatus(method string) string {
+	if IsCashMethod(method) {
+		return StatusAwaitingCash
+	}
+	return StatusAwaitingPayment
+}
+
+func IsValidStatus(status string) bool {
+	for _, table := range []map[string][]string{transitions, codTransitions, pickupTransitions} {
+		if _, ok := table[status]; ok {
+			return true
+		}
+	}
+	return status == StatusCancelled || status == StatusRefunded || status == StatusExpired
+}
+
+func transitionsFor(method string) map[string][]string {
+	switch method {
+	case MethodCOD:
+		return codTransitions
+	case MethodPickup:
+		return pickupTransitions
+	}
+	return transitions
+}
+
+func CanTransition(method string, from string, to string) bool {
+	for _, status := range transitionsFor(method)[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+func CanActorTransition(actor Actor, method string, from string, to string) bool {
+	if !CanTransition(method, from, to) {
+		return false
+	}
+
+	if IsCashMethod(method) && to == StatusDelivered {
+		return false
+	}
+
+	if actor.Role == user.RoleAdmin || actor.Role == RoleSystem {
+		return true
+	}
+
+	if actor.Role == user.RoleBuyer && to == StatusCancelled && from != StatusAwaitingPayment && from != StatusProofUploaded && from != StatusAwaitingCash {
+		return false
+	}
+
+	for _, status := range actorTargets[actor.Role] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,6 @@
+package payment
+
+type PaymentStatusRequest struct {
+	Status string `json:"status" binding:"required"`
+	Note   string `json:"note"`
+}
@@ -0,0 +1,229 @@
+package payment
+
+import (
+	"errors"
+	"taman-pempek/user"
+	"testing"
+)
+
+var (
+	adminActor  = Actor{ID: 1, Role: user.RoleAdmin}
+	buyerActor  = Actor{ID: 2, Role: user.RoleBuyer}
+	sellerActor = Actor{ID: 3, Role: user.RoleSeller}
+)
+
+func TestTransferLifecycle(t *testing.T) {
+	path := []string{StatusAwaitingPayment, StatusProofUploaded, StatusVerified, StatusProcessing, StatusShipped, StatusDelivered, StatusCompleted, StatusRefunded}
+
+	for i := 0; i < len(path)-1; i++ {
+		if !CanTransition(MethodTransfer, path[i], path[i+1]) {
+			t.Errorf("transfer cannot go from %s to %s", path[i], path[i+1])
+		}
+	}
+
+	for _, skip := range [][2]string{
+		{StatusAwaitingPayment, StatusProcessing},
+		{StatusProofUploaded, StatusShipped},
+		{StatusShipped, StatusCancelled},
+		{StatusCancelled, StatusAwaitingPayment},
+		{StatusExpired, StatusVerified},
+		{StatusRefunded, StatusCompleted},
+	} {
+		if CanTransition(MethodTransfer, skip[0], skip[1]) {
+			t.Errorf("transfer can go from %s to %s", skip[0], skip[1])
+		}
+	}
+}
+
+func TestCashLifecycles(t *testing.T) {
+	paths := map[string][]string{
+		MethodCOD:    {StatusAwaitingCash, StatusProcessing, StatusShipped, StatusDelivered, StatusCompleted},
+		MethodPickup: {StatusAwaitingCash, StatusProcessing, StatusReadyForPickup, StatusDelivered, StatusCompleted},
+	}
+
+	for method, path := range paths {
+		if InitialStatus(method) != StatusAwaitingCash {
+			t.Errorf("%s starts at %s", method, InitialStatus(method))
+		}
+
+		for i := 0; i < len(path)-1; i++ {
+			if !CanTransition(method, path[i], path[i+1]) {
+				t.Errorf("%s cannot go from %s to %s", method, path[i], path[i+1])
+			}
+		}
+	}
+
+	if CanTransition(MethodCOD, StatusAwaitingCash, StatusVerified) {
+		t.Error("cash on delivery can be verified like a transfer")
+	}
+	if CanTransition(MethodPickup, StatusProcessing, StatusShipped) {
+		t.Error("pickup orders can be shipped")
+	}
+	if InitialStatus(MethodTransfer) != StatusAwaitingPayment {
+		t.Errorf("transfer starts at %s", InitialStatus(MethodTransfer))
+	}
+}
+
+func TestActorTransitions(t *testing.T) {
+	cases := []struct {
+		actor  Actor
+		method string
+		from   string
+		to     string
+		want   bool
+	}{
+		{buyerActor, MethodTransfer, StatusAwaitingPayment, StatusCancelled, true},
+		{buyerActor, MethodTransfer, StatusProofUploaded, StatusCancelled, true},
+		{buyerActor, MethodTransfer, StatusVerified, StatusCancelled, false},
+		{buyerActor, MethodCOD, StatusAwaitingCash, StatusCancelled, true},
+		{buyerActor, MethodCOD, StatusProcessing, StatusCancelled, false},
+		{buyerActor, MethodTransfer, StatusDelivered, StatusCompleted, true},
+		{buyerActor, MethodTransfer, StatusProofUploaded, StatusVerified, false},
+		{sellerActor, MethodTransfer, StatusVerified, StatusProcessing, false},
+		{sellerActor, MethodTransfer, StatusProcessing, StatusShipped, false},
+		{adminActor, MethodTransfer, StatusProofUploaded, StatusVerified, true},
+		{adminActor, MethodTransfer, StatusVerified, StatusCancelled, true},
+		{SystemActor, MethodTransfer, StatusShipped, StatusDelivered, true},
+		{adminActor, MethodCOD, StatusShipped, StatusDelivered, false},
+		{SystemActor, MethodPickup, StatusReadyForPickup, StatusDelivered, false},
+		{adminActor, MethodTransfer, StatusCompleted, StatusProcessing, false},
+	}
+
+	for _, c := range cases {
+		if got := CanActorTransition(c.actor, c.method, c.from, c.to); got != c.want {
+			t.Errorf("%s on %s from %s to %s = %v, want %v", c.actor.Role, c.method, c.from, c.to, got, c.want)
+		}
+	}
+}
+
+func TestIsValidStatus(t *testing.T) {
+	for _, status := range []string{StatusAwaitingPayment, StatusAwaitingCash, StatusReadyForPickup, StatusCancelled, StatusRefunded, StatusExpired} {
+		if !IsValidStatus(status) {
+			t.Errorf("%s is not valid", status)
+		}
+	}
+
+	if IsValidStatus("paid") {
+		t.Error("unknown status is valid")
+	}
+}
+
+type memoryRepository struct {
+	PaymentRepository
+	payments map[uint64]Payment
+	history  []PaymentStatusHistory
+}
+
+func (r *memoryRepository) FindPaymentByID(ID int) (Payment, error) {
+	payment, ok := r.payments[uint64(ID)]
+	if !ok {
+		return Payment{}, errors.New("Payment not found")
+	}
+	return payment, nil
+}
+
+func (r *memoryRepository) TransitionStatus(ID uint64, from string, history PaymentStatusHistory) (bool, error) {
+	payment := r.payments[ID]
+	if payment.PaymentStatus != from {
+		return false, nil
+	}
+	payment.PaymentStatus = history.ToStatus
+	r.payments[ID] = payment
+	r.history = append(r.history, history)
+	return true, nil
+}
+
+type recordingCanceller struct {
+	repository *memoryRepository
+	cancelled  []uint64
+}
+
+func (c *recordingCanceller) CancelOrder(payment Payment, history PaymentStatusHistory) (bool, error) {
+	c.cancelled = append(c.cancelled, payment.ID)
+	return c.repository.TransitionStatus(payment.ID, history.FromStatus, history)
+}
+
+func newStatusService(payments ...Payment) (*service, *memoryRepository, *recordingCanceller) {
+	repository := &memoryRepository{payments: map[uint64]Payment{}}
+	for _, payment := range payments {
+		repository.payments[payment.ID] = payment
+	}
+	canceller := &recordingCanceller{repository: repository}
+	return NewService(repository, canceller), repository, canceller
+}
+
+func TestTransitionStatusRecordsHistory(t *testing.T) {
+	s, repository, canceller := newStatusService(Payment{ID: 1, UserID: 2, PaymentMethod: MethodTransfer, PaymentStatus: StatusProofUploaded})
+
+	payment, err := s.TransitionStatus(1, StatusVerified, adminActor, "Transfer received")
+	if err != nil {
+		t.Fatalf("TransitionStatus: %v", err)
+	}
+
+	if payment.PaymentStatus != StatusVerified || repository.payments[1].PaymentStatus != StatusVerified {
+		t.Errorf("status = %s", payment.PaymentStatus)
+	}
+
+	if len(repository.history) != 1 {
+		t.Fatalf("got %d history rows, want 1", len(repository.history))
+	}
+
+	history := repository.history[0]
+	if history.FromStatus != StatusProofUploaded || history.ToStatus != StatusVerified || history.ActorID != adminActor.ID || history.ActorRole != adminActor.Role || history.Note != "Transfer received" {
+		t.Errorf("history = %+v", history)
+	}
+
+	if len(canceller.cancelled) != 0 {
+		t.Errorf("cancelled = %v", canceller.cancelled)
+	}
+}
+
+func TestTransitionStatusCancelsThroughOrderCanceller(t *testing.T) {
+	s, repository, canceller := newStatusService(Payment{ID: 1, UserID: 2, PaymentMethod: MethodTransfer, PaymentStatus: StatusAwaitingPayment, StockReserved: true})
+
+	if _, err := s.TransitionStatus(1, StatusCancelled, buyerActor, ""); err != nil {
+		t.Fatalf("TransitionStatus: %v", err)
+	}
+
+	if len(canceller.cancelled) != 1 || canceller.cancelled[0] != 1 {
+		t.Errorf("cancelled = %v", canceller.cancelled)
+	}
+	if repository.payments[1].PaymentStatus != StatusCancelled {
+		t.Errorf("status = %s", repository.payments[1].PaymentStatus)
+	}
+}
+
+func TestTransitionStatusRejectsInvalidChanges(t *testing.T) {
+	s, repository, _ := newStatusService(
+		Payment{ID: 1, PaymentMethod: MethodTransfer, PaymentStatus: StatusVerified},
+		Payment{ID: 2, PaymentMethod: MethodTransfer, PaymentStatus: StatusAwaitingPayment},
+	)
+
+	if _, err := s.TransitionStatus(1, StatusCancelled, buyerActor, ""); err == nil {
+		t.Error("buyer cancelled a verified payment")
+	}
+	if _, err := s.TransitionStatus(2, StatusShipped, adminActor, ""); err == nil {
+		t.Error("awaiting payment was shipped")
+	}
+	if _, err := s.TransitionStatus(2, "paid", adminActor, ""); err == nil || err.Error() != "Invalid payment status" {
+		t.Errorf("unknown status error = %v", err)
+	}
+
+	if len(repository.history) != 0 {
+		t.Errorf("history = %+v", repository.history)
+	}
+}
+
+func TestApplyTransitionDetectsConcurrentChange(t *testing.T) {
+	s, repository, _ := newStatusService(Payment{ID: 1, PaymentMethod: MethodTransfer, PaymentStatus: StatusProofUploaded})
+
+	stale := repository.payments[1]
+
+	if _, err := s.TransitionStatus(1, StatusAwaitingPayment, adminActor, "Proof rejected"); err != nil {
+		t.Fatalf("TransitionStatus: %v", err)
+	}
+
+	if _, err := s.applyTransition(stale, StatusVerified, adminActor, ""); err == nil || err.Error() != "Payment status has been changed by another request" {
+		t.Errorf("stale transition error = %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package payment
+
+import (
+	"errors"
+	"math/rand"
+)
+
+const (
+	maxUniqueCode     = 999
+	maxUniqueAttempts = 5
+)
+
+var OpenStatuses = []string{StatusAwaitingPayment, StatusProofUploaded}
+
+func AssignUniqueCode(payment *Payment, openAmounts []int) error {
+	taken := map[int]bool{}
+
+	for _, amount := range openAmounts {
+		taken[amount] = true
+	}
+
+	start := rand.Intn(maxUniqueCode)
+
+	for i := 0; i < maxUniqueCode; i++ {
+		code := (start+i)%maxUniqueCode + 1
+
+		if !taken[payment.TotalPrice+code] {
+			amount := payment.TotalPrice + code
+			payment.UniqueCode = code
+			payment.TransferAmount = amount
+			payment.OpenTransfer = &amount
+			return nil
+		}
+	}
+
+	return errors.New("No unique transfer code available")
+}
+
+func IsOpenStatus(status string) bool {
+	for _, s := range OpenStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
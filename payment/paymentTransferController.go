@@ -0,0 +1,187 @@
+package payment
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type transferController struct {
+	transferService TransferService
+}
+
+func NewTransferController(transferService TransferService) *transferController {
+	return &transferController{transferService}
+}
+
+func (cn *transferController) UploadStatement(c *gin.Context) {
+	var statementRequest PaymentStatementRequest
+
+	err := c.ShouldBind(&statementRequest)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Error on BankID or Statement field, condition required",
+		})
+		return
+	}
+
+	statement, err := statementRequest.Statement.Open()
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Failed to read statement",
+		})
+		return
+	}
+	defer statement.Close()
+
+	reconciliation, err := cn.transferService.ReconcileStatement(statementRequest.BankID, statement, actorFromContext(c))
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.HasPrefix(err.Error(), "Statement") || err.Error() == "Bank is not an admin bank" {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	mutationsResponse := []BankMutationResponse{}
+
+	for _, mutation := range reconciliation.Mutations {
+		mutationsResponse = append(mutationsResponse, convertToBankMutationResponse(mutation))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data": ReconciliationResponse{
+			Matched:    reconciliation.Matched,
+			Ambiguous:  reconciliation.Ambiguous,
+			Unmatched:  reconciliation.Unmatched,
+			Duplicates: reconciliation.Duplicates,
+			Mutations:  mutationsResponse,
+		},
+	})
+}
+
+func (cn *transferController) GetMutationsByStatus(c *gin.Context) {
+	mutations, err := cn.transferService.FindMutationsByStatus(c.Param("status"))
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "Invalid mutation status" {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	mutationsResponse := []BankMutationResponse{}
+
+	for _, mutation := range mutations {
+		mutationsResponse = append(mutationsResponse, convertToBankMutationResponse(mutation))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  mutationsResponse,
+	})
+}
+
+func (cn *transferController) MatchMutation(c *gin.Context) {
+	var matchRequest BankMutationMatchRequest
+
+	err := c.ShouldBindJSON(&matchRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		for _, e := range err.(validator.ValidationErrors) {
+			errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+			errorMessages = append(errorMessages, errorMessage)
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid mutation ID",
+		})
+		return
+	}
+
+	mutation, err := cn.transferService.MatchMutation(id, matchRequest.PaymentID, actorFromContext(c))
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		if strings.HasPrefix(err.Error(), "Cannot change payment status") || err.Error() == "Payment status has been changed by another request" || err.Error() == "Bank mutation is already matched" {
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToBankMutationResponse(mutation),
+	})
+}
+
+func convertToBankMutationResponse(mutation BankMutation) BankMutationResponse {
+	candidateIDs := []uint64{}
+
+	for _, id := range strings.Split(mutation.CandidateIDs, ",") {
+		if candidateID, err := strconv.ParseUint(id, 10, 64); err == nil {
+			candidateIDs = append(candidateIDs, candidateID)
+		}
+	}
+
+	return BankMutationResponse{
+		ID:           mutation.ID,
+		BankID:       mutation.BankID,
+		MutationDate: mutation.MutationDate,
+		Description:  mutation.Description,
+		Amount:       mutation.Amount,
+		Status:       mutation.Status,
+		PaymentID:    mutation.PaymentID,
+		CandidateIDs: candidateIDs,
+		Note:         mutation.Note,
+		CreatedAt:    mutation.CreatedAt,
+	}
+}
@@ -0,0 +1,220 @@
+package payment
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"taman-pempek/bank"
+	"time"
+)
+
+type TransferService interface {
+	ReconcileStatement(bankID int, statement io.Reader, actor Actor) (Reconciliation, error)
+	FindMutationsByStatus(status string) ([]BankMutation, error)
+	MatchMutation(ID int, paymentID int, actor Actor) (BankMutation, error)
+}
+
+type Reconciliation struct {
+	Matched    int
+	Ambiguous  int
+	Unmatched  int
+	Duplicates int
+	Mutations  []BankMutation
+}
+
+type transferService struct {
+	paymentRepository PaymentRepository
+	paymentService    PaymentService
+	bankService       bank.BankService
+}
+
+func NewTransferService(paymentRepository PaymentRepository, paymentService PaymentService, bankService bank.BankService) *transferService {
+	return &transferService{paymentRepository, paymentService, bankService}
+}
+
+func (s *transferService) ReconcileStatement(bankID int, statement io.Reader, actor Actor) (Reconciliation, error) {
+	adminBank, err := s.findAdminBank(bankID)
+
+	if err != nil {
+		return Reconciliation{}, err
+	}
+
+	lines, err := ParseStatement(statement)
+
+	if err != nil {
+		return Reconciliation{}, err
+	}
+
+	result := Reconciliation{Mutations: []BankMutation{}}
+	occurrences := map[string]int{}
+
+	for _, line := range lines {
+		key := fmt.Sprintf("%d|%s|%s|%d", adminBank.ID, line.Date, line.Description, line.Amount)
+		occurrences[key]++
+		sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d", key, occurrences[key])))
+		fingerprint := hex.EncodeToString(sum[:])
+
+		exists, err := s.paymentRepository.BankMutationExists(fingerprint)
+
+		if err != nil {
+			return result, err
+		}
+
+		if exists {
+			result.Duplicates++
+			continue
+		}
+
+		mutation, err := s.paymentRepository.CreateBankMutation(BankMutation{
+			BankID:       adminBank.ID,
+			Fingerprint:  fingerprint,
+			MutationDate: line.Date,
+			Description:  line.Description,
+			Amount:       line.Amount,
+			Status:       MutationUnmatched,
+			ReviewerID:   actor.ID,
+		})
+
+		if err != nil {
+			return result, err
+		}
+
+		mutation, err = s.matchMutation(mutation, adminBank, actor)
+
+		if err != nil {
+			return result, err
+		}
+
+		switch mutation.Status {
+		case MutationMatched:
+			result.Matched++
+		case MutationAmbiguous:
+			result.Ambiguous++
+		default:
+			result.Unmatched++
+		}
+
+		result.Mutations = append(result.Mutations, mutation)
+	}
+
+	return result, nil
+}
+
+func (s *transferService) FindMutationsByStatus(status string) ([]BankMutation, error) {
+	if status != MutationMatched && status != MutationAmbiguous && status != MutationUnmatched {
+		return nil, errors.New("Invalid mutation status")
+	}
+
+	return s.paymentRepository.FindBankMutationsByStatus(status)
+}
+
+func (s *transferService) MatchMutation(ID int, paymentID int, actor Actor) (BankMutation, error) {
+	mutation, err := s.paymentRepository.FindBankMutationByID(ID)
+
+	if err != nil {
+		return BankMutation{}, err
+	}
+
+	if mutation.Status == MutationMatched {
+		return BankMutation{}, errors.New("Bank mutation is already matched")
+	}
+
+	adminBank, err := s.bankService.FindBankByID(int(mutation.BankID))
+
+	if err != nil {
+		return BankMutation{}, err
+	}
+
+	payment, err := s.paymentRepository.FindPaymentByID(paymentID)
+
+	if err != nil {
+		return BankMutation{}, err
+	}
+
+	if err := s.verifyPayment(payment, mutation, adminBank, actor); err != nil {
+		return BankMutation{}, err
+	}
+
+	mutation.Status = MutationMatched
+	mutation.PaymentID = &payment.ID
+	mutation.ReviewerID = actor.ID
+	mutation.Note = "Matched manually"
+
+	return s.paymentRepository.UpdateBankMutation(mutation)
+}
+
+func (s *transferService) matchMutation(mutation BankMutation, adminBank bank.Bank, actor Actor) (BankMutation, error) {
+	candidates, err := s.paymentRepository.FindOpenPaymentsByTransferAmount(mutation.Amount)
+
+	if err != nil {
+		return mutation, err
+	}
+
+	switch len(candidates) {
+	case 0:
+		mutation.Note = "No open payment with this transfer amount"
+	case 1:
+		if err := s.verifyPayment(candidates[0], mutation, adminBank, actor); err != nil {
+			mutation.Status = MutationAmbiguous
+			mutation.CandidateIDs = strconv.FormatUint(candidates[0].ID, 10)
+			mutation.Note = err.Error()
+			break
+		}
+		mutation.Status = MutationMatched
+		mutation.PaymentID = &candidates[0].ID
+	default:
+		ids := []string{}
+		for _, candidate := range candidates {
+			ids = append(ids, strconv.FormatUint(candidate.ID, 10))
+		}
+		mutation.Status = MutationAmbiguous
+		mutation.CandidateIDs = strings.Join(ids, ",")
+		mutation.Note = "Multiple open payments share this transfer amount"
+	}
+
+	return s.paymentRepository.UpdateBankMutation(mutation)
+}
+
+func (s *transferService) verifyPayment(payment Payment, mutation BankMutation, adminBank bank.Bank, actor Actor) error {
+	note := fmt.Sprintf("Bank transfer of %d matched on %s %s (%s)", mutation.Amount, adminBank.Name, adminBank.Number, mutation.MutationDate)
+
+	if _, err := s.paymentService.TransitionStatus(int(payment.ID), StatusVerified, actor, note); err != nil {
+		return err
+	}
+
+	proof, err := s.paymentRepository.FindLatestProof(int(payment.ID), ProofPending)
+
+	if err != nil {
+		return nil
+	}
+
+	now := time.Now()
+	proof.Status = ProofApproved
+	proof.ReviewerID = &actor.ID
+	proof.Note = note
+	proof.ReviewedAt = &now
+
+	_, err = s.paymentRepository.UpdateProof(proof)
+
+	return err
+}
+
+func (s *transferService) findAdminBank(bankID int) (bank.Bank, error) {
+	adminBanks, err := s.bankService.FindAdminBanks()
+
+	if err != nil {
+		return bank.Bank{}, err
+	}
+
+	for _, adminBank := range adminBanks {
+		if adminBank.ID == uint64(bankID) {
+			return adminBank, nil
+		}
+	}
+
+	return bank.Bank{}, errors.New("Bank is not an admin bank")
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"taman-pempek/address"
+	"taman-pempek/bank"
+	"taman-pempek/cart"
+	"taman-pempek/checkout"
+	"taman-pempek/delivery"
+	"taman-pempek/dispatch"
+	"taman-pempek/middleware"
+	"taman-pempek/payment"
+	"taman-pempek/product"
+	"taman-pempek/refund"
+	"taman-pempek/shipment"
+	"taman-pempek/user"
+
+	"gorm.io/gorm"
+)
+
+func policies(db *gorm.DB) middleware.Policies {
+	productService := product.NewService(product.NewRepository(db))
+	bankService := bank.NewService(bank.NewRepository(db))
+	addressService := address.NewService(address.NewRepository(db))
+	cartService := cart.NewService(cart.NewRepository(db), productService)
+	paymentService := payment.NewService(payment.NewRepository(db), checkout.NewService(checkout.NewRepository(db)))
+	refundService := refund.NewService(refund.NewRepository(db), bankService)
+	shipmentRepository := shipment.NewRepository(db)
+	deliveryService := delivery.NewService(delivery.NewRepository(db))
+	dispatchRepository := dispatch.NewRepository(db)
+
+	admin := []string{user.RoleAdmin}
+	seller := []string{user.RoleSeller, user.RoleAdmin}
+	buyer := []string{user.RoleBuyer, user.RoleAdmin}
+	collector := []string{user.RoleCourier, user.RoleSeller, user.RoleAdmin}
+	courier := []string{user.RoleCourier, user.RoleAdmin}
+
+	productOwner := func(ID int) (int, error) {
+		product, err := productService.FindProductByID(ID)
+		return product.UserID, err
+	}
+	variantOwner := func(ID int) (int, error) {
+		variant, err := productService.FindVariantByID(ID)
+		if err != nil {
+			return 0, err
+		}
+		return productOwner(int(variant.ProductID))
+	}
+	optionGroupOwner := func(ID int) (int, error) {
+		group, err := productService.FindOptionGroupByID(ID)
+		if err != nil {
+			return 0, err
+		}
+		return productOwner(int(group.ProductID))
+	}
+	imageOwner := func(ID int) (int, error) {
+		image, err := productService.FindImageByID(ID)
+		if err != nil {
+			return 0, err
+		}
+		return productOwner(int(image.ProductID))
+	}
+	bankOwner := func(ID int) (int, error) {
+		bank, err := bankService.FindBankByID(ID)
+		return bank.UserID, err
+	}
+	addressOwner := func(ID int) (int, error) {
+		address, err := addressService.FindAddressByID(ID)
+		return address.UserID, err
+	}
+	cartOwner := func(ID int) (int, error) {
+		cart, err := cartService.FindCartByID(ID)
+		return cart.UserID, err
+	}
+	paymentOwner := func(ID int) (int, error) {
+		payment, err := paymentService.FindPaymentByID(ID)
+		return payment.UserID, err
+	}
+	shipmentOwner := func(ID int) (int, error) {
+		shipment, err := shipmentRepository.FindShipmentByID(ID)
+		return shipment.SellerID, err
+	}
+	deliveryOwner := func(ID int) (int, error) {
+		delivery, err := deliveryService.FindDeliveryByID(ID)
+		return delivery.CourierID, err
+	}
+	assignmentOwner := func(ID int) (int, error) {
+		assignment, err := dispatchRepository.FindAssignmentByID(ID)
+		if err != nil {
+			return 0, err
+		}
+		return deliveryOwner(assignment.DeliveryID)
+	}
+	refundOwner := func(ID int) (int, error) {
+		refund, err := refundService.FindRefundByID(ID)
+		return refund.UserID, err
+	}
+
+	return middleware.Policies{
+		"GET /v1/users":                {Roles: admin},
+		"GET /v1/users/role/:role":     {Roles: admin},
+		"GET /v1/user/:id":             {Owner: middleware.ParamOwner("id")},
+		"PUT /v1/user/update/:id":      {Owner: middleware.ParamOwner("id")},
+		"DELETE /v1/user/delete/:id":   {Roles: admin},
+		"POST /v1/logout":              {},
+		"POST /v1/logout/all":          {},
+		"POST /v1/user/verify/request": {},
+		"POST /v1/user/verify/confirm": {},
+
+		"POST /v1/product/create":                    {Roles: seller, Owner: middleware.FormOwner("user_id")},
+		"PUT /v1/product/update/:id":                 {Roles: seller, Owner: middleware.LookupOwner("id", productOwner)},
+		"DELETE /v1/product/delete/:id":              {Roles: seller, Owner: middleware.LookupOwner("id", productOwner)},
+		"POST /v1/product/:id/variant":               {Roles: seller, Owner: middleware.LookupOwner("id", productOwner)},
+		"PUT /v1/product/variant/update/:id":         {Roles: seller, Owner: middleware.LookupOwner("id", variantOwner)},
+		"DELETE /v1/product/variant/delete/:id":      {Roles: seller, Owner: middleware.LookupOwner("id", variantOwner)},
+		"POST /v1/product/:id/option-group":          {Roles: seller, Owner: middleware.LookupOwner("id", productOwner)},
+		"PUT /v1/product/option-group/update/:id":    {Roles: seller, Owner: middleware.LookupOwner("id", optionGroupOwner)},
+		"DELETE /v1/product/option-group/delete/:id": {Roles: seller, Owner: middleware.LookupOwner("id", optionGroupOwner)},
+		"POST /v1/product/:id/image":                 {Roles: seller, Owner: middleware.LookupOwner("id", productOwner)},
+		"PUT /v1/product/:id/images/order":           {Roles: seller, Owner: middleware.LookupOwner("id", productOwner)},
+		"PUT /v1/product/image/update/:id":           {Roles: seller, Owner: middleware.LookupOwner("id", imageOwner)},
+		"DELETE /v1/product/image/delete/:id":        {Roles: seller, Owner: middleware.LookupOwner("id", imageOwner)},
+
+		"GET /v1/banks":              {Roles: admin},
+		"GET /v1/banks/:userId":      {Owner: middleware.ParamOwner("userId")},
+		"GET /v1/bank/:id":           {Owner: middleware.LookupOwner("id", bankOwner)},
+		"POST /v1/bank/create":       {Owner: middleware.JSONOwner("user_id")},
+		"PUT /v1/bank/update/:id":    {Owner: middleware.LookupOwner("id", bankOwner)},
+		"DELETE /v1/bank/delete/:id": {Owner: middleware.LookupOwner("id", bankOwner)},
+
+		"GET /v1/addresses/:userId":         {Owner: middleware.ParamOwner("userId")},
+		"GET /v1/addresses/:userId/default": {Owner: middleware.ParamOwner("userId")},
+		"GET /v1/address/:id":               {Owner: middleware.LookupOwner("id", addressOwner)},
+		"POST /v1/address/create":           {Owner: middleware.JSONOwner("user_id")},
+		"PUT /v1/address/update/:id":        {Owner: middleware.LookupOwner("id", addressOwner)},
+		"DELETE /v1/address/delete/:id":     {Owner: middleware.LookupOwner("id", addressOwner)},
+
+		"POST /v1/category/create":       {Roles: admin},
+		"PUT /v1/category/update/:id":    {Roles: admin},
+		"DELETE /v1/category/delete/:id": {Roles: admin},
+
+		"POST /v1/delivery/create":       {Roles: admin},
+		"PUT /v1/delivery/update/:id":    {Roles: admin},
+		"DELETE /v1/delivery/delete/:id": {Roles: admin},
+
+		"GET /v1/carts":                          {Roles: admin},
+		"GET /v1/cart/:id":                       {Owner: middleware.LookupOwner("id", cartOwner)},
+		"GET /v1/carts/payment/:paymentId":       {Owner: middleware.LookupOwner("paymentId", paymentOwner)},
+		"GET /v1/carts/product/:productId":       {Roles: seller, Owner: middleware.LookupOwner("productId", productOwner)},
+		"GET /v1/carts/:isActived/:userId":       {Owner: middleware.ParamOwner("userId")},
+		"GET /v1/carts/total/:isActived/:userId": {Owner: middleware.ParamOwner("userId")},
+		"POST /v1/cart/create":                   {Roles: buyer, Owner: middleware.JSONOwner("user_id")},
+		"PUT /v1/cart/update/:id":                {Roles: buyer, Owner: middleware.LookupOwner("id", cartOwner)},
+		"DELETE /v1/cart/delete/:id":             {Roles: buyer, Owner: middleware.LookupOwner("id", cartOwner)},
+
+		"GET /v1/payments":                        {Roles: admin},
+		"GET /v1/payments/:userId/:paymentStatus": {Owner: middleware.ParamOwner("userId")},
+		"GET /v1/payments/status/:paymentStatus":  {Roles: admin},
+		"GET /v1/payment/:id":                     {Owner: middleware.LookupOwner("id", paymentOwner)},
+		"POST /v1/payment/create":                 {Roles: admin},
+		"PUT /v1/payment/update/:id":              {Roles: admin},
+		"PUT /v1/payment/:id/status":              {Owner: middleware.LookupOwner("id", paymentOwner)},
+		"GET /v1/payment/:id/timeline":            {Owner: middleware.LookupOwner("id", paymentOwner)},
+		"GET /v1/payments/review":                 {Roles: admin},
+		"GET /v1/payment/:id/proofs":              {Owner: middleware.LookupOwner("id", paymentOwner)},
+		"POST /v1/payment/:id/proof":              {Roles: buyer, Owner: middleware.LookupOwner("id", paymentOwner)},
+		"POST /v1/payment/:id/proof/approve":      {Roles: admin},
+		"POST /v1/payment/:id/proof/reject":       {Roles: admin},
+		"POST /v1/payment/:id/charge":             {Roles: buyer, Owner: middleware.LookupOwner("id", paymentOwner)},
+		"GET /v1/payment/:id/charges":             {Owner: middleware.LookupOwner("id", paymentOwner)},
+		"GET /v1/payments/charges/:status":        {Roles: admin},
+		"POST /v1/payments/statement":             {Roles: admin},
+		"GET /v1/payments/mutations/:status":      {Roles: admin},
+		"POST /v1/payments/mutation/:id/match":    {Roles: admin},
+		"DELETE /v1/payment/delete/:id":           {Roles: admin},
+
+		"POST /v1/checkout":           {Roles: buyer},
+		"PUT /v1/payment/:id/address": {Roles: buyer, Owner: middleware.LookupOwner("id", paymentOwner)},
+
+		"POST /v1/payment/:id/refund":    {Roles: buyer, Owner: middleware.LookupOwner("id", paymentOwner)},
+		"GET /v1/payment/:id/refunds":    {Owner: middleware.LookupOwner("id", paymentOwner)},
+		"GET /v1/refunds/status/:status": {Roles: admin},
+		"GET /v1/refunds/report":         {Roles: admin},
+		"GET /v1/refund/:id":             {Owner: middleware.LookupOwner("id", refundOwner)},
+		"POST /v1/refund/:id/approve":    {Roles: admin},
+		"POST /v1/refund/:id/reject":     {Roles: admin},
+		"POST /v1/refund/:id/payout":     {Roles: admin},
+
+		"GET /v1/payment/:id/invoice":      {Owner: middleware.LookupOwner("id", paymentOwner)},
+		"GET /v1/payment/:id/invoice.pdf":  {Owner: middleware.LookupOwner("id", paymentOwner)},
+		"GET /v1/payment/:id/invoice.html": {Owner: middleware.LookupOwner("id", paymentOwner)},
+
+		"GET /v1/payment/:id/tracking":     {Owner: middleware.LookupOwner("id", paymentOwner)},
+		"GET /v1/payment/:id/shipments":    {Owner: middleware.LookupOwner("id", paymentOwner)},
+		"GET /v1/shipments/seller/:userId": {Roles: seller, Owner: middleware.ParamOwner("userId")},
+		"GET /v1/shipment/:id":             {Roles: seller, Owner: middleware.LookupOwner("id", shipmentOwner)},
+		"PUT /v1/shipment/:id/delivery":    {Roles: seller, Owner: middleware.LookupOwner("id", shipmentOwner)},
+		"PUT /v1/shipment/:id/status":      {Roles: seller, Owner: middleware.LookupOwner("id", shipmentOwner)},
+
+		"POST /v1/payment/:id/cash":                   {Roles: collector},
+		"GET /v1/payment/:id/cash":                    {Owner: middleware.LookupOwner("id", paymentOwner)},
+		"GET /v1/cash/daily":                          {Roles: admin},
+		"GET /v1/cash/courier/:deliveryId":            {Roles: courier, Owner: middleware.LookupOwner("deliveryId", deliveryOwner)},
+		"POST /v1/cash/courier/:deliveryId/reconcile": {Roles: admin},
+
+		"POST /v1/dispatch/assign":                  {Roles: admin},
+		"GET /v1/dispatch/:id":                      {Roles: courier, Owner: middleware.LookupOwner("id", assignmentOwner)},
+		"DELETE /v1/dispatch/:id":                   {Roles: admin},
+		"PUT /v1/dispatch/:id/status":               {Roles: courier, Owner: middleware.LookupOwner("id", assignmentOwner)},
+		"POST /v1/dispatch/:id/deliver":             {Roles: courier, Owner: middleware.LookupOwner("id", assignmentOwner)},
+		"GET /v1/dispatch/driver/:deliveryId":       {Roles: courier, Owner: middleware.LookupOwner("deliveryId", deliveryOwner)},
+		"PUT /v1/dispatch/driver/:deliveryId/route": {Roles: courier, Owner: middleware.LookupOwner("deliveryId", deliveryOwner)},
+		"GET /v1/payment/:id/dispatch":              {Owner: middleware.LookupOwner("id", paymentOwner)},
+
+		"GET /v1/slots":              {Roles: admin},
+		"GET /v1/slots/schedule":     {Roles: admin},
+		"GET /v1/slot/:id":           {Roles: admin},
+		"POST /v1/slot/create":       {Roles: admin},
+		"PUT /v1/slot/update/:id":    {Roles: admin},
+		"DELETE /v1/slot/delete/:id": {Roles: admin},
+
+		"POST /v1/shipping/quote":             {Roles: buyer},
+		"GET /v1/shipping/zones":              {Roles: admin},
+		"GET /v1/shipping/zone/:id":           {Roles: admin},
+		"POST /v1/shipping/zone/create":       {Roles: admin},
+		"PUT /v1/shipping/zone/update/:id":    {Roles: admin},
+		"DELETE /v1/shipping/zone/delete/:id": {Roles: admin},
+		"POST /v1/shipping/zone/:id/tier":     {Roles: admin},
+		"PUT /v1/shipping/tier/update/:id":    {Roles: admin},
+		"DELETE /v1/shipping/tier/delete/:id": {Roles: admin},
+
+		"GET /v1/ledger/balances":                 {Roles: admin},
+		"GET /v1/ledger/seller/:userId/balance":   {Roles: seller, Owner: middleware.ParamOwner("userId")},
+		"GET /v1/ledger/seller/:userId/statement": {Roles: seller, Owner: middleware.ParamOwner("userId")},
+		"GET /v1/payouts/batches":                 {Roles: admin},
+		"POST /v1/payouts/batch":                  {Roles: admin},
+		"GET /v1/payouts/batch/:id":               {Roles: admin},
+		"POST /v1/payouts/batch/:id/paid":         {Roles: admin},
+
+		"PUT /v1/setting/update/:id": {Roles: admin},
+	}
+}
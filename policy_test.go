@@ -0,0 +1,89 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"taman-pempek/user"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func registeredRoutes(t *testing.T) (gin.RoutesInfo, gin.RoutesInfo) {
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte("NOTIFIER=fake\nCLOUDINARY_CLOUD=test\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	gin.SetMode(gin.TestMode)
+	publicRouter := gin.New()
+	privateRouter := gin.New()
+	public := publicRouter.Group("/v1")
+	private := privateRouter.Group("/v1")
+
+	for _, route := range []func(*gorm.DB, *gin.RouterGroup, *gin.RouterGroup){
+		routeUser, routeSession, routeProduct, routeBank, routeAddress, routeCategory, routeDelivery,
+		routeCart, routePayment, routeCheckout, routeRefund, routeLedger, routeShipment, routeCash,
+		routeDispatch, routeSlot, routeShipping, routeTracking, routeInvoice, routeSetting,
+	} {
+		route(nil, public, private)
+	}
+
+	return publicRouter.Routes(), privateRouter.Routes()
+}
+
+func TestEveryPrivateRouteHasPolicy(t *testing.T) {
+	publicRoutes, privateRoutes := registeredRoutes(t)
+	table := policies(nil)
+	registered := map[string]bool{}
+
+	for _, route := range privateRoutes {
+		key := route.Method + " " + route.Path
+		registered[key] = true
+
+		if _, ok := table[key]; !ok {
+			t.Errorf("%s has no policy and is denied to everyone", key)
+		}
+	}
+
+	for _, route := range publicRoutes {
+		if _, ok := table[route.Method+" "+route.Path]; ok {
+			t.Errorf("%s is public but has a policy", route.Method+" "+route.Path)
+		}
+	}
+
+	for key := range table {
+		if !registered[key] {
+			t.Errorf("policy %s does not match any private route", key)
+		}
+	}
+}
+
+func TestAdminOnlyPolicies(t *testing.T) {
+	table := policies(nil)
+
+	for _, key := range []string{
+		"POST /v1/payment/create",
+		"PUT /v1/payment/update/:id",
+		"DELETE /v1/payment/delete/:id",
+		"POST /v1/payment/:id/proof/approve",
+		"GET /v1/payments/charges/:status",
+	} {
+		policy := table[key]
+
+		if len(policy.Roles) != 1 || policy.Roles[0] != user.RoleAdmin {
+			t.Errorf("%s roles = %v, want admin only", key, policy.Roles)
+		}
+	}
+}
@@ -1,26 +1,23 @@
 package product
 
 import (
-	"context"
 	"fmt"
-	"log"
 	"net/http"
-	"os"
 	"strconv"
+	"strings"
+	"taman-pempek/storage"
 
-	"github.com/cloudinary/cloudinary-go/v2"
-	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
-	"github.com/joho/godotenv"
 )
 
 type controller struct {
 	productService ProductService
+	mediaStore     storage.MediaStore
 }
 
-func NewController(productService ProductService) *controller {
-	return &controller{productService}
+func NewController(productService ProductService, mediaStore storage.MediaStore) *controller {
+	return &controller{productService, mediaStore}
 }
 
 func (cn *controller) GetProducts(c *gin.Context) {
@@ -229,11 +226,6 @@ func (cn *controller) GetProductByCategory(c *gin.Context) {
 func (cn *controller) CreateProduct(c *gin.Context) {
 	var productRequest ProductCreateRequest
 
-	apiKey := goDotEnvVariable("APIKEY")
-	apiSecret := goDotEnvVariable("APISECRET")
-
-	urlCloudinary := "cloudinary://" + apiKey + ":" + apiSecret + "@dqudegiey"
-
 	err := c.ShouldBind(&productRequest)
 
 	if err != nil {
@@ -250,7 +242,7 @@ func (cn *controller) CreateProduct(c *gin.Context) {
 		return
 	}
 
-	file, err := productRequest.Image.Open()
+	media, err := storage.Upload(cn.mediaStore, imageFolder, &productRequest.Image, storage.ProductImage)
 
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -261,11 +253,10 @@ func (cn *controller) CreateProduct(c *gin.Context) {
 		return
 	}
 
-	ctx := context.Background()
-
-	cldService, err := cloudinary.NewFromURL(urlCloudinary)
+	product, err := cn.productService.CreateProduct(productRequest)
 
 	if err != nil {
+		cn.removeImage(media.Keys)
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": true,
 			"data":  nil,
@@ -274,23 +265,17 @@ func (cn *controller) CreateProduct(c *gin.Context) {
 		return
 	}
 
-	imageResponse, err := cldService.Upload.Upload(ctx, file, uploader.UploadParams{})
+	image := newImage(media)
+	image.IsPrimary = true
 
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": true,
-			"data":  nil,
-			"msg":   err.Error(),
-		})
-		return
-	}
-
-	productRequest.Image.Filename = imageResponse.SecureURL
+	_, err = cn.productService.AddImage(int(product.ID), image)
 
-	product, err := cn.productService.CreateProduct(productRequest)
+	if err == nil {
+		product, err = cn.productService.FindProductByID(int(product.ID))
+	}
 
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
+		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": true,
 			"data":  nil,
 			"msg":   err.Error(),
@@ -308,11 +293,6 @@ func (cn *controller) CreateProduct(c *gin.Context) {
 func (cn *controller) UpdateProduct(c *gin.Context) {
 	var productRequest ProductUpdateRequest
 
-	apiKey := goDotEnvVariable("APIKEY")
-	apiSecret := goDotEnvVariable("APISECRET")
-
-	urlCloudinary := "cloudinary://" + apiKey + ":" + apiSecret + "@dqudegiey"
-
 	err := c.ShouldBind(&productRequest)
 
 	if err != nil {
@@ -329,17 +309,6 @@ func (cn *controller) UpdateProduct(c *gin.Context) {
 		return
 	}
 
-	if productRequest.Image != nil {
-		file, _ := productRequest.Image.Open()
-
-		ctx := context.Background()
-
-		cldService, _ := cloudinary.NewFromURL(urlCloudinary)
-		imageResponse, _ := cldService.Upload.Upload(ctx, file, uploader.UploadParams{})
-
-		productRequest.Image.Filename = imageResponse.SecureURL
-	}
-
 	idString := c.Param("id")
 	id, err := strconv.Atoi(idString)
 
@@ -352,6 +321,40 @@ func (cn *controller) UpdateProduct(c *gin.Context) {
 		return
 	}
 
+	if productRequest.Image != nil {
+		media, err := storage.Upload(cn.mediaStore, imageFolder, productRequest.Image, storage.ProductImage)
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": true,
+				"data":  nil,
+				"msg":   err.Error(),
+			})
+			return
+		}
+
+		image := newImage(media)
+		image.IsPrimary = true
+
+		_, err = cn.productService.AddImage(id, image)
+
+		if err != nil {
+			cn.removeImage(media.Keys)
+			statusCode := http.StatusInternalServerError
+			if strings.HasSuffix(err.Error(), "not found") {
+				statusCode = http.StatusNotFound
+			} else if strings.HasPrefix(err.Error(), "Cannot") {
+				statusCode = http.StatusConflict
+			}
+			c.JSON(statusCode, gin.H{
+				"error": true,
+				"data":  nil,
+				"msg":   err.Error(),
+			})
+			return
+		}
+	}
+
 	product, err := cn.productService.UpdateProduct(id, productRequest)
 
 	if err != nil {
@@ -402,6 +405,10 @@ func (ch *controller) DeleteProduct(c *gin.Context) {
 		return
 	}
 
+	for _, image := range product.Images {
+		ch.removeImage(image.StorageKeyList())
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"error": false,
 		"msg":   "Success!",
@@ -410,22 +417,27 @@ func (ch *controller) DeleteProduct(c *gin.Context) {
 }
 
 func convertToProductResponse(product Product) ProductResponse {
-	return ProductResponse{
-		ID:          product.ID,
-		UserID:      product.UserID,
-		CategoryID:  product.CategoryID,
-		Name:        product.Name,
-		Image:       product.Image,
-		Description: product.Description,
-		Price:       product.Price,
-		Stock:       product.Stock,
+	primary := Image{}
+
+	for _, image := range product.Images {
+		if image.IsPrimary {
+			primary = image
+		}
 	}
-}
 
-func goDotEnvVariable(key string) string {
-	err := godotenv.Load(".env")
-	if err != nil {
-		log.Fatalf("Error loading .env file")
+	return ProductResponse{
+		ID:             product.ID,
+		UserID:         product.UserID,
+		CategoryID:     product.CategoryID,
+		Name:           product.Name,
+		Image:          product.Image,
+		ImageThumbnail: variant(primary.ThumbnailURL, product.Image),
+		ImageMedium:    variant(primary.MediumURL, product.Image),
+		ImageLarge:     product.Image,
+		Description:    product.Description,
+		Price:          product.Price,
+		Stock:          product.Stock,
+		Weight:         product.Weight,
+		Images:         convertToImagesResponse(product),
 	}
-	return os.Getenv(key)
 }
@@ -10,4 +10,5 @@ type ProductCreateRequest struct {
 	Description string               `form:"description" binding:"required"`
 	Price       int                  `form:"price" binding:"required,number"`
 	Stock       int                  `form:"stock" binding:"required,number"`
+	Weight      int                  `form:"weight" binding:"omitempty,min=1"`
 }
@@ -6,11 +6,13 @@ type Product struct {
 	ID          uint64    `gorm:"column:id;primaryKey;autoIncrement"`
 	UserID      int       `gorm:"column:user_id;type:varchar(255)"`
 	CategoryID  int       `gorm:"column:category_id;type:varchar(255)"`
-	Name        string    `gorm:"column:name;type:varchar(255)"`
+	Name        string    `gorm:"column:name;type:varchar(255);index:idx_products_search,class:FULLTEXT"`
 	Image       string    `gorm:"column:image;type:varchar(255)"`
-	Description string    `gorm:"column:description;type:text"`
+	Description string    `gorm:"column:description;type:text;index:idx_products_search,class:FULLTEXT"`
 	Price       int       `gorm:"column:price"`
 	Stock       int       `gorm:"column:stock"`
+	Weight      int       `gorm:"column:weight;default:1000"`
+	Images      []Image   `gorm:"foreignKey:ProductID"`
 	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt   time.Time `gorm:"column:updated_at;autoUpdateTime"`
 }
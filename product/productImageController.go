@@ -0,0 +1,338 @@
+package product
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"taman-pempek/storage"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+const imageFolder = "product/image"
+
+func (cn *controller) GetProductImages(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid product ID",
+		})
+		return
+	}
+
+	product, err := cn.productService.FindProductByID(id)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToImagesResponse(product),
+	})
+}
+
+func (cn *controller) CreateProductImage(c *gin.Context) {
+	var imageRequest ImageCreateRequest
+
+	err := c.ShouldBind(&imageRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+				errorMessages = append(errorMessages, errorMessage)
+			}
+		} else {
+			errorMessages = append(errorMessages, "Invalid request body")
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid product ID",
+		})
+		return
+	}
+
+	media, err := storage.Upload(cn.mediaStore, imageFolder, &imageRequest.Image, storage.ProductImage)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	image := newImage(media)
+	image.AltText = imageRequest.AltText
+	image.IsPrimary = imageRequest.Primary
+
+	image, err = cn.productService.AddImage(id, image)
+
+	if err != nil {
+		cn.removeImage(media.Keys)
+		statusCode := http.StatusInternalServerError
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		} else if strings.HasPrefix(err.Error(), "Cannot") {
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToImageResponse(image),
+	})
+}
+
+func (cn *controller) UpdateProductImage(c *gin.Context) {
+	var imageRequest ImageUpdateRequest
+
+	err := c.ShouldBindJSON(&imageRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+				errorMessages = append(errorMessages, errorMessage)
+			}
+		} else {
+			errorMessages = append(errorMessages, "Invalid request body")
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid image ID",
+		})
+		return
+	}
+
+	image, err := cn.productService.UpdateImage(id, imageRequest)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToImageResponse(image),
+	})
+}
+
+func (cn *controller) DeleteProductImage(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid image ID",
+		})
+		return
+	}
+
+	image, err := cn.productService.DeleteImage(id)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	cn.removeImage(image.StorageKeyList())
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToImageResponse(image),
+	})
+}
+
+func (cn *controller) ReorderProductImages(c *gin.Context) {
+	var orderRequest ImageOrderRequest
+
+	err := c.ShouldBindJSON(&orderRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+				errorMessages = append(errorMessages, errorMessage)
+			}
+		} else {
+			errorMessages = append(errorMessages, "Invalid request body")
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid product ID",
+		})
+		return
+	}
+
+	images, err := cn.productService.ReorderImages(id, orderRequest)
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	imagesResponse := []ImageResponse{}
+
+	for _, image := range images {
+		imagesResponse = append(imagesResponse, convertToImageResponse(image))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  imagesResponse,
+	})
+}
+
+func convertToImageResponse(image Image) ImageResponse {
+	return ImageResponse{
+		ID:           image.ID,
+		URL:          image.URL,
+		ThumbnailURL: variant(image.ThumbnailURL, image.URL),
+		MediumURL:    variant(image.MediumURL, image.URL),
+		LargeURL:     image.URL,
+		AltText:      image.AltText,
+		Position:     image.Position,
+		IsPrimary:    image.IsPrimary,
+	}
+}
+
+func convertToImagesResponse(product Product) []ImageResponse {
+	imagesResponse := []ImageResponse{}
+
+	if len(product.Images) == 0 && product.Image != "" {
+		return append(imagesResponse, ImageResponse{
+			URL:          product.Image,
+			ThumbnailURL: product.Image,
+			MediumURL:    product.Image,
+			LargeURL:     product.Image,
+			Position:     1,
+			IsPrimary:    true,
+		})
+	}
+
+	for _, image := range product.Images {
+		imagesResponse = append(imagesResponse, convertToImageResponse(image))
+	}
+
+	return imagesResponse
+}
+
+func newImage(media storage.Media) Image {
+	return Image{
+		URL:          media.URL,
+		ThumbnailURL: media.Variants[storage.Thumbnail.Name],
+		MediumURL:    media.Variants[storage.Medium.Name],
+		StorageKeys:  strings.Join(media.Keys, ","),
+	}
+}
+
+func variant(URL string, fallback string) string {
+	if URL == "" {
+		return fallback
+	}
+	return URL
+}
+
+func (cn *controller) removeImage(keys []string) {
+	if err := storage.Remove(cn.mediaStore, keys); err != nil {
+		log.Printf("Failed to remove image from storage: %v", err)
+	}
+}
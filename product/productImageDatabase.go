@@ -0,0 +1,33 @@
+package product
+
+import (
+	"strings"
+	"time"
+)
+
+const maxImages = 10
+
+type Image struct {
+	ID           uint64    `gorm:"column:id;primaryKey;autoIncrement"`
+	ProductID    uint64    `gorm:"column:product_id;index"`
+	URL          string    `gorm:"column:url;type:varchar(255)"`
+	ThumbnailURL string    `gorm:"column:thumbnail_url;type:varchar(255)"`
+	MediumURL    string    `gorm:"column:medium_url;type:varchar(255)"`
+	StorageKeys  string    `gorm:"column:storage_keys;type:text"`
+	AltText      string    `gorm:"column:alt_text;type:varchar(255)"`
+	Position     int       `gorm:"column:position"`
+	IsPrimary    bool      `gorm:"column:is_primary"`
+	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt    time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (Image) TableName() string {
+	return "product_images"
+}
+
+func (i Image) StorageKeyList() []string {
+	if i.StorageKeys == "" {
+		return []string{}
+	}
+	return strings.Split(i.StorageKeys, ",")
+}
@@ -0,0 +1,18 @@
+package product
+
+import "mime/multipart"
+
+type ImageCreateRequest struct {
+	Image   multipart.FileHeader `form:"image" binding:"required"`
+	AltText string               `form:"alt_text" binding:"max=255"`
+	Primary bool                 `form:"is_primary"`
+}
+
+type ImageUpdateRequest struct {
+	AltText *string `json:"alt_text" binding:"omitempty,max=255"`
+	Primary bool    `json:"is_primary"`
+}
+
+type ImageOrderRequest struct {
+	ImageIDs []uint64 `json:"image_ids" binding:"required,min=1"`
+}
@@ -0,0 +1,12 @@
+package product
+
+type ImageResponse struct {
+	ID           uint64 `json:"id"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	MediumURL    string `json:"medium_url"`
+	LargeURL     string `json:"large_url"`
+	AltText      string `json:"alt_text"`
+	Position     int    `json:"position"`
+	IsPrimary    bool   `json:"is_primary"`
+}
@@ -0,0 +1,170 @@
+package product
+
+import (
+	"errors"
+	"fmt"
+)
+
+func (s *service) FindImagesByProduct(productID int) ([]Image, error) {
+	if _, err := s.productRepository.FindProductByID(productID); err != nil {
+		return nil, err
+	}
+
+	return s.productRepository.FindImagesByProduct(productID)
+}
+
+func (s *service) FindImageByID(ID int) (Image, error) {
+	return s.productRepository.FindImageByID(ID)
+}
+
+func (s *service) AddImage(productID int, image Image) (Image, error) {
+	product, err := s.productRepository.FindProductByID(productID)
+
+	if err != nil {
+		return Image{}, err
+	}
+
+	images, err := s.productRepository.FindImagesByProduct(productID)
+
+	if err != nil {
+		return Image{}, err
+	}
+
+	if len(images) == 0 && product.Image != "" {
+		legacy, err := s.productRepository.CreateImage(Image{
+			ProductID: product.ID,
+			URL:       product.Image,
+			Position:  1,
+			IsPrimary: true,
+		})
+
+		if err != nil {
+			return Image{}, err
+		}
+
+		images = append(images, legacy)
+	}
+
+	if len(images) >= maxImages {
+		return Image{}, fmt.Errorf("Cannot add more than %d images to a product", maxImages)
+	}
+
+	image.ProductID = product.ID
+	image.Position = 1
+	if len(images) > 0 {
+		image.Position = images[len(images)-1].Position + 1
+	}
+
+	primary := image.IsPrimary || len(images) == 0
+	image.IsPrimary = false
+
+	image, err = s.productRepository.CreateImage(image)
+
+	if err != nil {
+		return Image{}, err
+	}
+
+	if primary {
+		if err := s.productRepository.SetPrimaryImage(image); err != nil {
+			return Image{}, err
+		}
+		image.IsPrimary = true
+	}
+
+	return image, nil
+}
+
+func (s *service) UpdateImage(ID int, request ImageUpdateRequest) (Image, error) {
+	image, err := s.productRepository.FindImageByID(ID)
+
+	if err != nil {
+		return Image{}, err
+	}
+
+	if request.AltText != nil {
+		image.AltText = *request.AltText
+
+		image, err = s.productRepository.UpdateImage(image)
+
+		if err != nil {
+			return Image{}, err
+		}
+	}
+
+	if request.Primary && !image.IsPrimary {
+		if err := s.productRepository.SetPrimaryImage(image); err != nil {
+			return Image{}, err
+		}
+		image.IsPrimary = true
+	}
+
+	return image, nil
+}
+
+func (s *service) DeleteImage(ID int) (Image, error) {
+	image, err := s.productRepository.FindImageByID(ID)
+
+	if err != nil {
+		return Image{}, err
+	}
+
+	image, err = s.productRepository.DeleteImage(image)
+
+	if err != nil {
+		return Image{}, err
+	}
+
+	if !image.IsPrimary {
+		return image, nil
+	}
+
+	remaining, err := s.productRepository.FindImagesByProduct(int(image.ProductID))
+
+	if err != nil {
+		return Image{}, err
+	}
+
+	if len(remaining) == 0 {
+		return image, s.productRepository.ClearPrimaryImage(image.ProductID)
+	}
+
+	return image, s.productRepository.SetPrimaryImage(remaining[0])
+}
+
+func (s *service) ReorderImages(productID int, request ImageOrderRequest) ([]Image, error) {
+	images, err := s.FindImagesByProduct(productID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	gallery := map[uint64]bool{}
+
+	for _, image := range images {
+		gallery[image.ID] = true
+	}
+
+	positions := map[uint64]int{}
+
+	for i, ID := range request.ImageIDs {
+		if !gallery[ID] {
+			return nil, fmt.Errorf("Image %d does not belong to this product", ID)
+		}
+
+		if _, ok := positions[ID]; ok {
+			return nil, fmt.Errorf("Image %d is listed more than once", ID)
+		}
+
+		positions[ID] = i + 1
+	}
+
+	if len(positions) != len(gallery) {
+		return nil, errors.New("Order must list every image of this product")
+	}
+
+	if err := s.productRepository.UpdateImagePositions(positions); err != nil {
+		return nil, err
+	}
+
+	return s.productRepository.FindImagesByProduct(productID)
+}
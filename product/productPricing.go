@@ -0,0 +1,133 @@
+package product
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+)
+
+type Pricing struct {
+	Product   Product
+	Variant   Variant
+	Options   []Option
+	UnitPrice int
+	Weight    int
+}
+
+func (p Pricing) Label() string {
+	parts := []string{}
+
+	if p.Variant.ID != 0 {
+		parts = append(parts, p.Variant.Name)
+	}
+	for _, option := range p.Options {
+		parts = append(parts, option.Name)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func PriceItem(repository ProductRepository, productID int, variantID uint64, optionIDs []uint64) (Pricing, error) {
+	product, err := repository.FindProductByID(productID)
+
+	if err != nil {
+		return Pricing{}, err
+	}
+
+	pricing := Pricing{Product: product, Options: []Option{}, UnitPrice: product.Price, Weight: product.Weight}
+
+	if variantID != 0 {
+		variant, err := repository.FindVariantByID(int(variantID))
+
+		if err != nil || variant.ProductID != product.ID {
+			return Pricing{}, errors.New("Variant not found")
+		}
+
+		pricing.Variant = variant
+		pricing.UnitPrice = variant.Price
+
+		if variant.Weight > 0 {
+			pricing.Weight = variant.Weight
+		}
+	} else {
+		count, err := repository.CountVariants(productID)
+
+		if err != nil {
+			return Pricing{}, err
+		}
+
+		if count > 0 {
+			return Pricing{}, fmt.Errorf("Please choose a variant of %s", product.Name)
+		}
+	}
+
+	groups, err := repository.FindOptionGroupsByProduct(productID)
+
+	if err != nil {
+		return Pricing{}, err
+	}
+
+	chosen := map[uint64]bool{}
+
+	for _, ID := range optionIDs {
+		if chosen[ID] {
+			return Pricing{}, fmt.Errorf("Option %d is chosen more than once", ID)
+		}
+		chosen[ID] = true
+	}
+
+	for _, group := range groups {
+		selected := 0
+
+		for _, option := range group.Options {
+			if !chosen[option.ID] {
+				continue
+			}
+
+			delete(chosen, option.ID)
+			selected++
+			pricing.Options = append(pricing.Options, option)
+			pricing.UnitPrice += option.Price
+		}
+
+		if group.Required && selected == 0 {
+			return Pricing{}, fmt.Errorf("Please choose %s", group.Name)
+		}
+
+		if group.MaxSelect > 0 && selected > group.MaxSelect {
+			return Pricing{}, fmt.Errorf("Choose at most %d %s", group.MaxSelect, group.Name)
+		}
+	}
+
+	if len(chosen) > 0 {
+		return Pricing{}, errors.New("Option not found")
+	}
+
+	return pricing, nil
+}
+
+func ReserveStock(repository ProductRepository, productID int, variantID uint64, quantity int) (bool, error) {
+	if variantID != 0 {
+		return repository.DecrementVariantStock(variantID, quantity)
+	}
+	return repository.DecrementStock(productID, quantity)
+}
+
+func RestoreStock(repository ProductRepository, productID int, variantID uint64, quantity int) error {
+	if variantID != 0 {
+		restored, err := repository.IncrementVariantStock(variantID, quantity)
+
+		if err != nil || restored {
+			return err
+		}
+	}
+
+	restored, err := repository.IncrementStock(productID, quantity)
+
+	if err == nil && !restored {
+		log.Printf("Product %d no longer exists, %d units were not restocked", productID, quantity)
+	}
+
+	return err
+}
@@ -2,10 +2,23 @@ package product
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
+	"taman-pempek/payment"
+	"time"
 
 	"gorm.io/gorm"
 )
 
+var soldStatuses = []string{
+	payment.StatusVerified,
+	payment.StatusProcessing,
+	payment.StatusShipped,
+	payment.StatusReadyForPickup,
+	payment.StatusDelivered,
+	payment.StatusCompleted,
+}
+
 type ProductRepository interface {
 	FindAll() ([]Product, error)
 	FindProductByID(ID int) (Product, error)
@@ -15,6 +28,31 @@ type ProductRepository interface {
 	CreateProduct(product Product) (Product, error)
 	UpdateProduct(product Product) (Product, error)
 	DeleteProduct(product Product) (Product, error)
+	DecrementStock(ID int, quantity int) (bool, error)
+	IncrementStock(ID int, quantity int) (bool, error)
+	FindVariantsByProduct(productID int) ([]Variant, error)
+	FindVariantByID(ID int) (Variant, error)
+	FindVariantBySKU(SKU string) (Variant, error)
+	CountVariants(productID int) (int, error)
+	CreateVariant(variant Variant) (Variant, error)
+	UpdateVariant(variant Variant) (Variant, error)
+	DeleteVariant(variant Variant) (Variant, error)
+	DecrementVariantStock(ID uint64, quantity int) (bool, error)
+	IncrementVariantStock(ID uint64, quantity int) (bool, error)
+	FindOptionGroupsByProduct(productID int) ([]OptionGroup, error)
+	FindOptionGroupByID(ID int) (OptionGroup, error)
+	SaveOptionGroup(group OptionGroup) (OptionGroup, error)
+	DeleteOptionGroup(group OptionGroup) (OptionGroup, error)
+	FindImagesByProduct(productID int) ([]Image, error)
+	FindImageByID(ID int) (Image, error)
+	CreateImage(image Image) (Image, error)
+	UpdateImage(image Image) (Image, error)
+	DeleteImage(image Image) (Image, error)
+	SetPrimaryImage(image Image) error
+	ClearPrimaryImage(productID uint64) error
+	UpdateImagePositions(positions map[uint64]int) error
+	SearchProducts(filter SearchFilter, after *Cursor, limit int) ([]SearchRow, error)
+	FindCategoryFacets(filter SearchFilter) ([]CategoryFacet, error)
 }
 
 type repository struct {
@@ -27,13 +65,13 @@ func NewRepository(db *gorm.DB) *repository {
 
 func (r *repository) FindAll() ([]Product, error) {
 	var products []Product
-	err := r.db.Find(&products).Error
+	err := r.db.Preload("Images", orderImages).Find(&products).Error
 	return products, err
 }
 
 func (r *repository) FindProductByID(ID int) (Product, error) {
 	var product Product
-	err := r.db.First(&product, ID).Error
+	err := r.db.Preload("Images", orderImages).First(&product, ID).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return Product{}, errors.New("Product not found")
 	}
@@ -42,7 +80,7 @@ func (r *repository) FindProductByID(ID int) (Product, error) {
 
 func (r *repository) GetProductByUserIDAndCategoryID(userID int, categoryID int) ([]Product, error) {
 	var products []Product
-	err := r.db.Where("user_id = ? AND category_id = ?", userID, categoryID).Find(&products).Error
+	err := r.db.Preload("Images", orderImages).Where("user_id = ? AND category_id = ?", userID, categoryID).Find(&products).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return []Product{}, errors.New("Product not found")
 	}
@@ -51,7 +89,7 @@ func (r *repository) GetProductByUserIDAndCategoryID(userID int, categoryID int)
 
 func (r *repository) GetProductByUser(userID int) ([]Product, error) {
 	var products []Product
-	err := r.db.Where("user_id = ?", userID).Find(&products).Error
+	err := r.db.Preload("Images", orderImages).Where("user_id = ?", userID).Find(&products).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return []Product{}, errors.New("Product not found")
 	}
@@ -60,7 +98,7 @@ func (r *repository) GetProductByUser(userID int) ([]Product, error) {
 
 func (r *repository) GetProductByCategory(categoryId int) ([]Product, error) {
 	var products []Product
-	err := r.db.Where("category_id = ?", categoryId).Find(&products).Error
+	err := r.db.Preload("Images", orderImages).Where("category_id = ?", categoryId).Find(&products).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return []Product{}, errors.New("Product not found")
 	}
@@ -68,16 +106,339 @@ func (r *repository) GetProductByCategory(categoryId int) ([]Product, error) {
 }
 
 func (r *repository) CreateProduct(product Product) (Product, error) {
-	err := r.db.Create(&product).Error
+	err := r.db.Omit("Images").Create(&product).Error
 	return product, err
 }
 
 func (r *repository) UpdateProduct(product Product) (Product, error) {
-	err := r.db.Save(&product).Error
+	err := r.db.Omit("Images").Save(&product).Error
 	return product, err
 }
 
 func (r *repository) DeleteProduct(product Product) (Product, error) {
-	err := r.db.Delete(&product).Error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("product_id = ?", product.ID).Delete(&Image{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("product_id = ?", product.ID).Delete(&Variant{}).Error; err != nil {
+			return err
+		}
+		var groupIDs []uint64
+		if err := tx.Model(&OptionGroup{}).Where("product_id = ?", product.ID).Pluck("id", &groupIDs).Error; err != nil {
+			return err
+		}
+		if len(groupIDs) > 0 {
+			if err := tx.Where("group_id IN ?", groupIDs).Delete(&Option{}).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("product_id = ?", product.ID).Delete(&OptionGroup{}).Error; err != nil {
+			return err
+		}
+		return tx.Omit("Images").Delete(&product).Error
+	})
 	return product, err
 }
+
+func (r *repository) DecrementStock(ID int, quantity int) (bool, error) {
+	result := r.db.Model(&Product{}).
+		Where("id = ? AND stock >= ?", ID, quantity).
+		Update("stock", gorm.Expr("stock - ?", quantity))
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *repository) IncrementStock(ID int, quantity int) (bool, error) {
+	result := r.db.Model(&Product{}).
+		Where("id = ?", ID).
+		Update("stock", gorm.Expr("stock + ?", quantity))
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *repository) FindVariantsByProduct(productID int) ([]Variant, error) {
+	var variants []Variant
+	err := r.db.Where("product_id = ?", productID).Order("price, id").Find(&variants).Error
+	return variants, err
+}
+
+func (r *repository) FindVariantByID(ID int) (Variant, error) {
+	var variant Variant
+	err := r.db.First(&variant, ID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Variant{}, errors.New("Variant not found")
+	}
+	return variant, err
+}
+
+func (r *repository) FindVariantBySKU(SKU string) (Variant, error) {
+	var variant Variant
+	err := r.db.Where("sku = ?", SKU).First(&variant).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Variant{}, errors.New("Variant not found")
+	}
+	return variant, err
+}
+
+func (r *repository) CountVariants(productID int) (int, error) {
+	var count int64
+	err := r.db.Model(&Variant{}).Where("product_id = ?", productID).Count(&count).Error
+	return int(count), err
+}
+
+func (r *repository) CreateVariant(variant Variant) (Variant, error) {
+	err := r.db.Create(&variant).Error
+	return variant, err
+}
+
+func (r *repository) UpdateVariant(variant Variant) (Variant, error) {
+	err := r.db.Save(&variant).Error
+	return variant, err
+}
+
+func (r *repository) DeleteVariant(variant Variant) (Variant, error) {
+	err := r.db.Delete(&variant).Error
+	return variant, err
+}
+
+func (r *repository) DecrementVariantStock(ID uint64, quantity int) (bool, error) {
+	result := r.db.Model(&Variant{}).
+		Where("id = ? AND stock >= ?", ID, quantity).
+		Update("stock", gorm.Expr("stock - ?", quantity))
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *repository) IncrementVariantStock(ID uint64, quantity int) (bool, error) {
+	result := r.db.Model(&Variant{}).
+		Where("id = ?", ID).
+		Update("stock", gorm.Expr("stock + ?", quantity))
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *repository) FindOptionGroupsByProduct(productID int) ([]OptionGroup, error) {
+	var groups []OptionGroup
+	err := r.db.Preload("Options", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Where("product_id = ?", productID).Order("id").Find(&groups).Error
+	return groups, err
+}
+
+func (r *repository) FindOptionGroupByID(ID int) (OptionGroup, error) {
+	var group OptionGroup
+	err := r.db.Preload("Options", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).First(&group, ID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return OptionGroup{}, errors.New("Option group not found")
+	}
+	return group, err
+}
+
+func (r *repository) SaveOptionGroup(group OptionGroup) (OptionGroup, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Options").Save(&group).Error; err != nil {
+			return err
+		}
+
+		kept := []uint64{}
+
+		for i := range group.Options {
+			group.Options[i].GroupID = group.ID
+
+			if err := tx.Save(&group.Options[i]).Error; err != nil {
+				return err
+			}
+
+			kept = append(kept, group.Options[i].ID)
+		}
+
+		return tx.Where("group_id = ? AND id NOT IN ?", group.ID, kept).Delete(&Option{}).Error
+	})
+	return group, err
+}
+
+func (r *repository) DeleteOptionGroup(group OptionGroup) (OptionGroup, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("group_id = ?", group.ID).Delete(&Option{}).Error; err != nil {
+			return err
+		}
+		return tx.Omit("Options").Delete(&group).Error
+	})
+	return group, err
+}
+
+func (r *repository) FindImagesByProduct(productID int) ([]Image, error) {
+	var images []Image
+	err := orderImages(r.db.Where("product_id = ?", productID)).Find(&images).Error
+	return images, err
+}
+
+func (r *repository) FindImageByID(ID int) (Image, error) {
+	var image Image
+	err := r.db.First(&image, ID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Image{}, errors.New("Image not found")
+	}
+	return image, err
+}
+
+func (r *repository) CreateImage(image Image) (Image, error) {
+	err := r.db.Create(&image).Error
+	return image, err
+}
+
+func (r *repository) UpdateImage(image Image) (Image, error) {
+	err := r.db.Save(&image).Error
+	return image, err
+}
+
+func (r *repository) DeleteImage(image Image) (Image, error) {
+	err := r.db.Delete(&image).Error
+	return image, err
+}
+
+func (r *repository) SetPrimaryImage(image Image) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Image{}).Where("product_id = ? AND id <> ?", image.ProductID, image.ID).Update("is_primary", false).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&Image{}).Where("id = ?", image.ID).Update("is_primary", true).Error; err != nil {
+			return err
+		}
+		return tx.Model(&Product{}).Where("id = ?", image.ProductID).Update("image", image.URL).Error
+	})
+}
+
+func (r *repository) ClearPrimaryImage(productID uint64) error {
+	return r.db.Model(&Product{}).Where("id = ?", productID).Update("image", "").Error
+}
+
+func (r *repository) UpdateImagePositions(positions map[uint64]int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for ID, position := range positions {
+			if err := tx.Model(&Image{}).Where("id = ?", ID).Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *repository) SearchProducts(filter SearchFilter, after *Cursor, limit int) ([]SearchRow, error) {
+	sort := searchSorts[filter.Sort]
+	query := fulltextQuery(filter.Keyword)
+
+	columns := "products.*, 0 AS score, 0 AS sold"
+	args := []any{}
+
+	if query != "" && filter.Sort == SortRelevance {
+		columns = "products.*, ROUND(MATCH(products.name, products.description) AGAINST (? IN BOOLEAN MODE), 6) AS score, 0 AS sold"
+		args = append(args, query)
+	}
+
+	if filter.Sort == SortBestSelling {
+		columns = "products.*, 0 AS score, COALESCE((SELECT SUM(CAST(carts.quantity AS UNSIGNED)) FROM carts JOIN payments ON payments.id = carts.payment_id WHERE carts.product_id = products.id AND payments.payment_status IN ?), 0) AS sold"
+		args = append(args, soldStatuses)
+	}
+
+	inner := r.db.Model(&Product{}).Select(columns, args...).Scopes(searchScope(filter, true))
+	outer := r.db.Table("(?) AS results", inner)
+
+	direction, comparison := "ASC", ">"
+	if sort.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	if after != nil {
+		value, err := cursorValue(*after)
+
+		if err != nil {
+			return nil, err
+		}
+
+		outer = outer.Where(fmt.Sprintf("(%s %s ? OR (%s = ? AND id %s ?))", sort.Column, comparison, sort.Column, comparison), value, value, after.ID)
+	}
+
+	var rows []SearchRow
+	err := outer.Order(fmt.Sprintf("%s %s, id %s", sort.Column, direction, direction)).Limit(limit).Find(&rows).Error
+
+	if err != nil || len(rows) == 0 {
+		return rows, err
+	}
+
+	IDs := []uint64{}
+	for _, row := range rows {
+		IDs = append(IDs, row.ID)
+	}
+
+	var images []Image
+	if err := orderImages(r.db.Where("product_id IN ?", IDs)).Find(&images).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		for _, image := range images {
+			if image.ProductID == rows[i].ID {
+				rows[i].Images = append(rows[i].Images, image)
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+func (r *repository) FindCategoryFacets(filter SearchFilter) ([]CategoryFacet, error) {
+	var facets []CategoryFacet
+	err := r.db.Model(&Product{}).
+		Select("products.category_id, categories.name, COUNT(*) AS count").
+		Joins("LEFT JOIN categories ON categories.id = products.category_id").
+		Scopes(searchScope(filter, false)).
+		Group("products.category_id, categories.name").
+		Order("count DESC, products.category_id").
+		Scan(&facets).Error
+	return facets, err
+}
+
+func searchScope(filter SearchFilter, withCategory bool) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if query := fulltextQuery(filter.Keyword); query != "" {
+			db = db.Where("MATCH(products.name, products.description) AGAINST (? IN BOOLEAN MODE)", query)
+		} else if filter.Keyword != "" {
+			db = db.Where("products.name LIKE ?", "%"+filter.Keyword+"%")
+		}
+		if withCategory && filter.CategoryID != 0 {
+			db = db.Where("products.category_id = ?", filter.CategoryID)
+		}
+		if filter.SellerID != 0 {
+			db = db.Where("products.user_id = ?", filter.SellerID)
+		}
+		if filter.MinPrice != 0 {
+			db = db.Where("products.price >= ?", filter.MinPrice)
+		}
+		if filter.MaxPrice != 0 {
+			db = db.Where("products.price <= ?", filter.MaxPrice)
+		}
+		if filter.InStock {
+			db = db.Where("(EXISTS (SELECT 1 FROM product_variants WHERE product_variants.product_id = products.id AND product_variants.stock > 0) OR (products.stock > 0 AND NOT EXISTS (SELECT 1 FROM product_variants WHERE product_variants.product_id = products.id)))")
+		}
+		return db
+	}
+}
+
+func cursorValue(cursor Cursor) (any, error) {
+	if cursor.Sort == SortNewest {
+		value, err := time.Parse(time.RFC3339Nano, cursor.Value)
+		if err != nil {
+			return nil, errInvalidCursor
+		}
+		return value, nil
+	}
+
+	value, err := strconv.ParseFloat(cursor.Value, 64)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	return value, nil
+}
+
+func orderImages(db *gorm.DB) *gorm.DB {
+	return db.Order("position, id")
+}
@@ -1,12 +1,17 @@
 package product
 
 type ProductResponse struct {
-	ID          uint64 `json:"id"`
-	UserID      int    `json:"user_id"`
-	CategoryID  int    `json:"category_id"`
-	Name        string `json:"name"`
-	Image       string `json:"image"`
-	Description string `json:"description"`
-	Price       int    `json:"price"`
-	Stock       int    `json:"stock"`
+	ID             uint64          `json:"id"`
+	UserID         int             `json:"user_id"`
+	CategoryID     int             `json:"category_id"`
+	Name           string          `json:"name"`
+	Image          string          `json:"image"`
+	ImageThumbnail string          `json:"image_thumbnail"`
+	ImageMedium    string          `json:"image_medium"`
+	ImageLarge     string          `json:"image_large"`
+	Description    string          `json:"description"`
+	Price          int             `json:"price"`
+	Stock          int             `json:"stock"`
+	Weight         int             `json:"weight"`
+	Images         []ImageResponse `json:"images"`
 }
@@ -0,0 +1,108 @@
+package product
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"unicode"
+)
+
+const (
+	SortRelevance   = "relevance"
+	SortPriceAsc    = "price_asc"
+	SortPriceDesc   = "price_desc"
+	SortNewest      = "newest"
+	SortBestSelling = "best_selling"
+)
+
+const (
+	defaultSearchLimit = 20
+	minSearchTermSize  = 3
+)
+
+var errInvalidCursor = errors.New("Invalid cursor")
+
+type searchSort struct {
+	Column     string
+	Descending bool
+}
+
+var searchSorts = map[string]searchSort{
+	SortRelevance:   {Column: "score", Descending: true},
+	SortPriceAsc:    {Column: "price", Descending: false},
+	SortPriceDesc:   {Column: "price", Descending: true},
+	SortNewest:      {Column: "created_at", Descending: true},
+	SortBestSelling: {Column: "sold", Descending: true},
+}
+
+type SearchFilter struct {
+	Keyword    string
+	CategoryID int
+	SellerID   int
+	MinPrice   int
+	MaxPrice   int
+	InStock    bool
+	Sort       string
+}
+
+type SearchRow struct {
+	Product
+	Score float64 `gorm:"column:score"`
+	Sold  int     `gorm:"column:sold"`
+}
+
+type CategoryFacet struct {
+	CategoryID int    `gorm:"column:category_id"`
+	Name       string `gorm:"column:name"`
+	Count      int    `gorm:"column:count"`
+}
+
+type SearchResult struct {
+	Rows       []SearchRow
+	NextCursor string
+	Categories []CategoryFacet
+}
+
+type Cursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	ID    uint64 `json:"id"`
+}
+
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(encoded string) (Cursor, error) {
+	var cursor Cursor
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+
+	if err != nil || json.Unmarshal(data, &cursor) != nil || cursor.ID == 0 {
+		return Cursor{}, errInvalidCursor
+	}
+
+	if _, ok := searchSorts[cursor.Sort]; !ok {
+		return Cursor{}, errInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+func fulltextQuery(keyword string) string {
+	terms := strings.FieldsFunc(keyword, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	parts := []string{}
+
+	for _, term := range terms {
+		if len([]rune(term)) >= minSearchTermSize {
+			parts = append(parts, "+"+term+"*")
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
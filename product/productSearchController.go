@@ -0,0 +1,85 @@
+package product
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+func (cn *controller) SearchProducts(c *gin.Context) {
+	var searchRequest ProductSearchRequest
+
+	err := c.ShouldBindQuery(&searchRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+				errorMessages = append(errorMessages, errorMessage)
+			}
+		} else {
+			errorMessages = append(errorMessages, "Invalid query parameters")
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	if searchRequest.MaxPrice != 0 && searchRequest.MaxPrice < searchRequest.MinPrice {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Maximum price must not be lower than minimum price",
+		})
+		return
+	}
+
+	result, err := cn.productService.SearchProducts(searchRequest)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err == errInvalidCursor {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToProductSearchResponse(result),
+	})
+}
+
+func convertToProductSearchResponse(result SearchResult) ProductSearchResponse {
+	searchResponse := ProductSearchResponse{
+		Products:   []ProductResponse{},
+		NextCursor: result.NextCursor,
+		Categories: []CategoryFacetResponse{},
+	}
+
+	for _, row := range result.Rows {
+		searchResponse.Products = append(searchResponse.Products, convertToProductResponse(row.Product))
+	}
+
+	for _, facet := range result.Categories {
+		searchResponse.Categories = append(searchResponse.Categories, CategoryFacetResponse{
+			CategoryID: facet.CategoryID,
+			Name:       facet.Name,
+			Count:      facet.Count,
+		})
+	}
+
+	return searchResponse
+}
@@ -0,0 +1,13 @@
+package product
+
+type ProductSearchRequest struct {
+	Keyword    string `form:"q" binding:"omitempty,max=100"`
+	CategoryID int    `form:"category_id" binding:"omitempty,min=1"`
+	SellerID   int    `form:"seller_id" binding:"omitempty,min=1"`
+	MinPrice   int    `form:"min_price" binding:"omitempty,min=0"`
+	MaxPrice   int    `form:"max_price" binding:"omitempty,min=0"`
+	InStock    bool   `form:"in_stock"`
+	Sort       string `form:"sort" binding:"omitempty,oneof=relevance price_asc price_desc newest best_selling"`
+	Cursor     string `form:"cursor"`
+	Limit      int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
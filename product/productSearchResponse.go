@@ -0,0 +1,13 @@
+package product
+
+type ProductSearchResponse struct {
+	Products   []ProductResponse       `json:"products"`
+	NextCursor string                  `json:"next_cursor"`
+	Categories []CategoryFacetResponse `json:"categories"`
+}
+
+type CategoryFacetResponse struct {
+	CategoryID int    `json:"category_id"`
+	Name       string `json:"name"`
+	Count      int    `json:"count"`
+}
@@ -0,0 +1,80 @@
+package product
+
+import (
+	"strconv"
+	"time"
+)
+
+func (s *service) SearchProducts(request ProductSearchRequest) (SearchResult, error) {
+	filter := SearchFilter{
+		Keyword:    request.Keyword,
+		CategoryID: request.CategoryID,
+		SellerID:   request.SellerID,
+		MinPrice:   request.MinPrice,
+		MaxPrice:   request.MaxPrice,
+		InStock:    request.InStock,
+		Sort:       request.Sort,
+	}
+
+	if filter.Sort == "" {
+		filter.Sort = SortRelevance
+	}
+	if filter.Sort == SortRelevance && fulltextQuery(filter.Keyword) == "" {
+		filter.Sort = SortNewest
+	}
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+
+	var after *Cursor
+
+	if request.Cursor != "" {
+		cursor, err := DecodeCursor(request.Cursor)
+
+		if err != nil {
+			return SearchResult{}, err
+		}
+
+		if cursor.Sort != filter.Sort {
+			return SearchResult{}, errInvalidCursor
+		}
+
+		after = &cursor
+	}
+
+	rows, err := s.productRepository.SearchProducts(filter, after, limit+1)
+
+	if err != nil {
+		return SearchResult{}, err
+	}
+
+	result := SearchResult{Rows: rows}
+
+	if len(rows) > limit {
+		result.Rows = rows[:limit]
+		result.NextCursor = nextCursor(filter.Sort, rows[limit-1]).Encode()
+	}
+
+	result.Categories, err = s.productRepository.FindCategoryFacets(filter)
+
+	return result, err
+}
+
+func nextCursor(sort string, row SearchRow) Cursor {
+	cursor := Cursor{Sort: sort, ID: row.ID}
+
+	switch sort {
+	case SortRelevance:
+		cursor.Value = strconv.FormatFloat(row.Score, 'f', 6, 64)
+	case SortNewest:
+		cursor.Value = row.CreatedAt.Format(time.RFC3339Nano)
+	case SortBestSelling:
+		cursor.Value = strconv.Itoa(row.Sold)
+	default:
+		cursor.Value = strconv.Itoa(row.Price)
+	}
+
+	return cursor
+}
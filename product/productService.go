@@ -15,6 +15,24 @@ type ProductService interface {
 	CreateProduct(product ProductCreateRequest) (Product, error)
 	UpdateProduct(ID int, product ProductUpdateRequest) (Product, error)
 	DeleteProduct(ID int) (Product, error)
+	PriceItem(productID int, variantID uint64, optionIDs []uint64) (Pricing, error)
+	FindVariantsByProduct(productID int) ([]Variant, error)
+	FindVariantByID(ID int) (Variant, error)
+	CreateVariant(productID int, request VariantRequest) (Variant, error)
+	UpdateVariant(ID int, request VariantRequest) (Variant, error)
+	DeleteVariant(ID int) (Variant, error)
+	FindOptionGroupsByProduct(productID int) ([]OptionGroup, error)
+	FindOptionGroupByID(ID int) (OptionGroup, error)
+	CreateOptionGroup(productID int, request OptionGroupRequest) (OptionGroup, error)
+	UpdateOptionGroup(ID int, request OptionGroupRequest) (OptionGroup, error)
+	DeleteOptionGroup(ID int) (OptionGroup, error)
+	FindImagesByProduct(productID int) ([]Image, error)
+	FindImageByID(ID int) (Image, error)
+	AddImage(productID int, image Image) (Image, error)
+	UpdateImage(ID int, request ImageUpdateRequest) (Image, error)
+	DeleteImage(ID int) (Image, error)
+	ReorderImages(productID int, request ImageOrderRequest) ([]Image, error)
+	SearchProducts(request ProductSearchRequest) (SearchResult, error)
 }
 
 type service struct {
@@ -50,10 +68,10 @@ func (s *service) CreateProduct(productRequest ProductCreateRequest) (Product, e
 		UserID:      productRequest.UserID,
 		CategoryID:  productRequest.CategoryID,
 		Name:        productRequest.Name,
-		Image:       productRequest.Image.Filename,
 		Description: productRequest.Description,
 		Price:       productRequest.Price,
 		Stock:       productRequest.Stock,
+		Weight:      productRequest.Weight,
 	}
 
 	product, err := s.productRepository.CreateProduct(productData)
@@ -78,9 +96,6 @@ func (s *service) UpdateProduct(ID int, productRequest ProductUpdateRequest) (Pr
 	if productRequest.Name != "" {
 		product.Name = productRequest.Name
 	}
-	if productRequest.Image != nil {
-		product.Image = productRequest.Image.Filename
-	}
 	if productRequest.Description != "" {
 		product.Description = productRequest.Description
 	}
@@ -90,6 +105,9 @@ func (s *service) UpdateProduct(ID int, productRequest ProductUpdateRequest) (Pr
 	if productRequest.Stock != 0 {
 		product.Stock = productRequest.Stock
 	}
+	if productRequest.Weight != 0 {
+		product.Weight = productRequest.Weight
+	}
 
 	return s.productRepository.UpdateProduct(product)
 }
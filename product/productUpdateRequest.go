@@ -9,4 +9,5 @@ type ProductUpdateRequest struct {
 	Description string                `form:"description,omitempty"`
 	Price       int                   `form:"price,omitempty"`
 	Stock       int                   `form:"stock,omitempty"`
+	Weight      int                   `form:"weight,omitempty" binding:"omitempty,min=1"`
 }
@@ -0,0 +1,424 @@
+package product
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+func (cn *controller) GetProductVariants(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid product ID",
+		})
+		return
+	}
+
+	variants, err := cn.productService.FindVariantsByProduct(id)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	variantsResponse := []VariantResponse{}
+
+	for _, variant := range variants {
+		variantsResponse = append(variantsResponse, convertToVariantResponse(variant))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  variantsResponse,
+	})
+}
+
+func (cn *controller) CreateVariant(c *gin.Context) {
+	var variantRequest VariantRequest
+
+	err := c.ShouldBindJSON(&variantRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+				errorMessages = append(errorMessages, errorMessage)
+			}
+		} else {
+			errorMessages = append(errorMessages, "Invalid request body")
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid product ID",
+		})
+		return
+	}
+
+	variant, err := cn.productService.CreateVariant(id, variantRequest)
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToVariantResponse(variant),
+	})
+}
+
+func (cn *controller) UpdateVariant(c *gin.Context) {
+	var variantRequest VariantRequest
+
+	err := c.ShouldBindJSON(&variantRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+				errorMessages = append(errorMessages, errorMessage)
+			}
+		} else {
+			errorMessages = append(errorMessages, "Invalid request body")
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid variant ID",
+		})
+		return
+	}
+
+	variant, err := cn.productService.UpdateVariant(id, variantRequest)
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToVariantResponse(variant),
+	})
+}
+
+func (cn *controller) DeleteVariant(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid variant ID",
+		})
+		return
+	}
+
+	variant, err := cn.productService.DeleteVariant(id)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToVariantResponse(variant),
+	})
+}
+
+func (cn *controller) GetProductOptions(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid product ID",
+		})
+		return
+	}
+
+	groups, err := cn.productService.FindOptionGroupsByProduct(id)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	groupsResponse := []OptionGroupResponse{}
+
+	for _, group := range groups {
+		groupsResponse = append(groupsResponse, convertToOptionGroupResponse(group))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  groupsResponse,
+	})
+}
+
+func (cn *controller) CreateOptionGroup(c *gin.Context) {
+	var groupRequest OptionGroupRequest
+
+	err := c.ShouldBindJSON(&groupRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+				errorMessages = append(errorMessages, errorMessage)
+			}
+		} else {
+			errorMessages = append(errorMessages, "Invalid request body")
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid product ID",
+		})
+		return
+	}
+
+	group, err := cn.productService.CreateOptionGroup(id, groupRequest)
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToOptionGroupResponse(group),
+	})
+}
+
+func (cn *controller) UpdateOptionGroup(c *gin.Context) {
+	var groupRequest OptionGroupRequest
+
+	err := c.ShouldBindJSON(&groupRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+				errorMessages = append(errorMessages, errorMessage)
+			}
+		} else {
+			errorMessages = append(errorMessages, "Invalid request body")
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid option group ID",
+		})
+		return
+	}
+
+	group, err := cn.productService.UpdateOptionGroup(id, groupRequest)
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToOptionGroupResponse(group),
+	})
+}
+
+func (cn *controller) DeleteOptionGroup(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid option group ID",
+		})
+		return
+	}
+
+	group, err := cn.productService.DeleteOptionGroup(id)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToOptionGroupResponse(group),
+	})
+}
+
+func convertToVariantResponse(variant Variant) VariantResponse {
+	return VariantResponse{
+		ID:        variant.ID,
+		ProductID: variant.ProductID,
+		SKU:       variant.SKU,
+		Name:      variant.Name,
+		Price:     variant.Price,
+		Stock:     variant.Stock,
+		Weight:    variant.Weight,
+	}
+}
+
+func convertToOptionGroupResponse(group OptionGroup) OptionGroupResponse {
+	optionsResponse := []OptionResponse{}
+
+	for _, option := range group.Options {
+		optionsResponse = append(optionsResponse, OptionResponse{
+			ID:    option.ID,
+			Name:  option.Name,
+			Price: option.Price,
+		})
+	}
+
+	return OptionGroupResponse{
+		ID:        group.ID,
+		ProductID: group.ProductID,
+		Name:      group.Name,
+		Required:  group.Required,
+		MaxSelect: group.MaxSelect,
+		Options:   optionsResponse,
+	}
+}
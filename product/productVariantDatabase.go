@@ -0,0 +1,47 @@
+package product
+
+import "time"
+
+type Variant struct {
+	ID        uint64    `gorm:"column:id;primaryKey;autoIncrement"`
+	ProductID uint64    `gorm:"column:product_id;index"`
+	SKU       string    `gorm:"column:sku;type:varchar(64);uniqueIndex"`
+	Name      string    `gorm:"column:name;type:varchar(255)"`
+	Price     int       `gorm:"column:price"`
+	Stock     int       `gorm:"column:stock"`
+	Weight    int       `gorm:"column:weight"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (Variant) TableName() string {
+	return "product_variants"
+}
+
+type OptionGroup struct {
+	ID        uint64    `gorm:"column:id;primaryKey;autoIncrement"`
+	ProductID uint64    `gorm:"column:product_id;index"`
+	Name      string    `gorm:"column:name;type:varchar(255)"`
+	Required  bool      `gorm:"column:required"`
+	MaxSelect int       `gorm:"column:max_select"`
+	Options   []Option  `gorm:"foreignKey:GroupID"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (OptionGroup) TableName() string {
+	return "product_option_groups"
+}
+
+type Option struct {
+	ID        uint64    `gorm:"column:id;primaryKey;autoIncrement"`
+	GroupID   uint64    `gorm:"column:group_id;index"`
+	Name      string    `gorm:"column:name;type:varchar(255)"`
+	Price     int       `gorm:"column:price"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (Option) TableName() string {
+	return "product_options"
+}
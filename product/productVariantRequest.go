@@ -0,0 +1,22 @@
+package product
+
+type VariantRequest struct {
+	SKU    string `json:"sku" binding:"required,max=64"`
+	Name   string `json:"name" binding:"required"`
+	Price  int    `json:"price" binding:"required,min=1"`
+	Stock  int    `json:"stock" binding:"min=0"`
+	Weight int    `json:"weight" binding:"omitempty,min=1"`
+}
+
+type OptionGroupRequest struct {
+	Name      string          `json:"name" binding:"required"`
+	Required  bool            `json:"required"`
+	MaxSelect int             `json:"max_select" binding:"min=0"`
+	Options   []OptionRequest `json:"options" binding:"required,min=1,dive"`
+}
+
+type OptionRequest struct {
+	ID    uint64 `json:"id"`
+	Name  string `json:"name" binding:"required"`
+	Price int    `json:"price" binding:"min=0"`
+}
@@ -0,0 +1,26 @@
+package product
+
+type VariantResponse struct {
+	ID        uint64 `json:"id"`
+	ProductID uint64 `json:"product_id"`
+	SKU       string `json:"sku"`
+	Name      string `json:"name"`
+	Price     int    `json:"price"`
+	Stock     int    `json:"stock"`
+	Weight    int    `json:"weight"`
+}
+
+type OptionGroupResponse struct {
+	ID        uint64           `json:"id"`
+	ProductID uint64           `json:"product_id"`
+	Name      string           `json:"name"`
+	Required  bool             `json:"required"`
+	MaxSelect int              `json:"max_select"`
+	Options   []OptionResponse `json:"options"`
+}
+
+type OptionResponse struct {
+	ID    uint64 `json:"id"`
+	Name  string `json:"name"`
+	Price int    `json:"price"`
+}
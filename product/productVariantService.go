@@ -0,0 +1,166 @@
+package product
+
+import (
+	"errors"
+	"fmt"
+)
+
+func (s *service) PriceItem(productID int, variantID uint64, optionIDs []uint64) (Pricing, error) {
+	return PriceItem(s.productRepository, productID, variantID, optionIDs)
+}
+
+func (s *service) FindVariantsByProduct(productID int) ([]Variant, error) {
+	if _, err := s.productRepository.FindProductByID(productID); err != nil {
+		return nil, err
+	}
+
+	return s.productRepository.FindVariantsByProduct(productID)
+}
+
+func (s *service) FindVariantByID(ID int) (Variant, error) {
+	return s.productRepository.FindVariantByID(ID)
+}
+
+func (s *service) CreateVariant(productID int, request VariantRequest) (Variant, error) {
+	product, err := s.productRepository.FindProductByID(productID)
+
+	if err != nil {
+		return Variant{}, err
+	}
+
+	if _, err := s.productRepository.FindVariantBySKU(request.SKU); err == nil {
+		return Variant{}, fmt.Errorf("SKU %s is already used", request.SKU)
+	}
+
+	variant := Variant{
+		ProductID: product.ID,
+		SKU:       request.SKU,
+		Name:      request.Name,
+		Price:     request.Price,
+		Stock:     request.Stock,
+		Weight:    request.Weight,
+	}
+
+	return s.productRepository.CreateVariant(variant)
+}
+
+func (s *service) UpdateVariant(ID int, request VariantRequest) (Variant, error) {
+	variant, err := s.productRepository.FindVariantByID(ID)
+
+	if err != nil {
+		return Variant{}, err
+	}
+
+	if other, err := s.productRepository.FindVariantBySKU(request.SKU); err == nil && other.ID != variant.ID {
+		return Variant{}, fmt.Errorf("SKU %s is already used", request.SKU)
+	}
+
+	variant.SKU = request.SKU
+	variant.Name = request.Name
+	variant.Price = request.Price
+	variant.Stock = request.Stock
+	variant.Weight = request.Weight
+
+	return s.productRepository.UpdateVariant(variant)
+}
+
+func (s *service) DeleteVariant(ID int) (Variant, error) {
+	variant, err := s.productRepository.FindVariantByID(ID)
+
+	if err != nil {
+		return Variant{}, err
+	}
+
+	return s.productRepository.DeleteVariant(variant)
+}
+
+func (s *service) FindOptionGroupsByProduct(productID int) ([]OptionGroup, error) {
+	if _, err := s.productRepository.FindProductByID(productID); err != nil {
+		return nil, err
+	}
+
+	return s.productRepository.FindOptionGroupsByProduct(productID)
+}
+
+func (s *service) FindOptionGroupByID(ID int) (OptionGroup, error) {
+	return s.productRepository.FindOptionGroupByID(ID)
+}
+
+func (s *service) CreateOptionGroup(productID int, request OptionGroupRequest) (OptionGroup, error) {
+	product, err := s.productRepository.FindProductByID(productID)
+
+	if err != nil {
+		return OptionGroup{}, err
+	}
+
+	group := OptionGroup{ProductID: product.ID}
+
+	if err := applyOptionGroup(&group, request); err != nil {
+		return OptionGroup{}, err
+	}
+
+	return s.productRepository.SaveOptionGroup(group)
+}
+
+func (s *service) UpdateOptionGroup(ID int, request OptionGroupRequest) (OptionGroup, error) {
+	group, err := s.productRepository.FindOptionGroupByID(ID)
+
+	if err != nil {
+		return OptionGroup{}, err
+	}
+
+	if err := applyOptionGroup(&group, request); err != nil {
+		return OptionGroup{}, err
+	}
+
+	return s.productRepository.SaveOptionGroup(group)
+}
+
+func (s *service) DeleteOptionGroup(ID int) (OptionGroup, error) {
+	group, err := s.productRepository.FindOptionGroupByID(ID)
+
+	if err != nil {
+		return OptionGroup{}, err
+	}
+
+	return s.productRepository.DeleteOptionGroup(group)
+}
+
+func applyOptionGroup(group *OptionGroup, request OptionGroupRequest) error {
+	if request.MaxSelect > 0 && request.MaxSelect > len(request.Options) {
+		return errors.New("Max select cannot exceed the number of options")
+	}
+
+	existing := map[uint64]Option{}
+
+	for _, option := range group.Options {
+		existing[option.ID] = option
+	}
+
+	options := []Option{}
+
+	for _, optionRequest := range request.Options {
+		option := Option{GroupID: group.ID}
+
+		if optionRequest.ID != 0 {
+			current, ok := existing[optionRequest.ID]
+
+			if !ok {
+				return errors.New("Option not found")
+			}
+
+			option = current
+		}
+
+		option.Name = optionRequest.Name
+		option.Price = optionRequest.Price
+		options = append(options, option)
+	}
+
+	group.Name = request.Name
+	group.Required = request.Required
+	group.MaxSelect = request.MaxSelect
+	group.Options = options
+
+	return nil
+}
@@ -0,0 +1,407 @@
+package refund
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"taman-pempek/payment"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type controller struct {
+	refundService RefundService
+}
+
+func NewController(refundService RefundService) *controller {
+	return &controller{refundService}
+}
+
+func (cn *controller) RequestRefund(c *gin.Context) {
+	var refundRequest RefundCreateRequest
+
+	err := c.ShouldBindJSON(&refundRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+				errorMessages = append(errorMessages, errorMessage)
+			}
+		} else {
+			errorMessages = append(errorMessages, "Invalid request body")
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid payment ID",
+		})
+		return
+	}
+
+	refund, err := cn.refundService.RequestRefund(id, refundRequest, actorFromContext(c))
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		if strings.HasPrefix(err.Error(), "Cannot refund") {
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToRefundResponse(refund),
+	})
+}
+
+func (cn *controller) GetRefund(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid refund ID",
+		})
+		return
+	}
+
+	refund, err := cn.refundService.FindRefundByID(id)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "Refund not found" {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToRefundResponse(refund),
+	})
+}
+
+func (cn *controller) GetPaymentRefunds(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid payment ID",
+		})
+		return
+	}
+
+	refunds, err := cn.refundService.FindRefundsByPayment(id)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToRefundsResponse(refunds),
+	})
+}
+
+func (cn *controller) GetRefundsByStatus(c *gin.Context) {
+	refunds, err := cn.refundService.FindRefundsByStatus(c.Param("status"))
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "Invalid refund status" {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToRefundsResponse(refunds),
+	})
+}
+
+func (cn *controller) ApproveRefund(c *gin.Context) {
+	cn.reviewRefund(c, true)
+}
+
+func (cn *controller) RejectRefund(c *gin.Context) {
+	cn.reviewRefund(c, false)
+}
+
+func (cn *controller) reviewRefund(c *gin.Context, approve bool) {
+	var reviewRequest RefundReviewRequest
+
+	c.ShouldBindJSON(&reviewRequest)
+
+	if !approve && strings.TrimSpace(reviewRequest.Note) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Rejection reason is required",
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid refund ID",
+		})
+		return
+	}
+
+	var refund Refund
+
+	if approve {
+		refund, err = cn.refundService.ApproveRefund(id, reviewRequest.Restock, reviewRequest.Note, actorFromContext(c))
+	} else {
+		refund, err = cn.refundService.RejectRefund(id, reviewRequest.Note, actorFromContext(c))
+	}
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "Refund not found" {
+			statusCode = http.StatusNotFound
+		}
+		if strings.HasPrefix(err.Error(), "Cannot change refund status") || strings.HasPrefix(err.Error(), "Cannot restock") || err.Error() == "Refund has been changed by another request" {
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToRefundResponse(refund),
+	})
+}
+
+func (cn *controller) ConfirmPayout(c *gin.Context) {
+	var payoutRequest RefundPayoutRequest
+
+	err := c.ShouldBindJSON(&payoutRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		for _, e := range err.(validator.ValidationErrors) {
+			errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+			errorMessages = append(errorMessages, errorMessage)
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid refund ID",
+		})
+		return
+	}
+
+	refund, err := cn.refundService.ConfirmPayout(id, payoutRequest.Reference, actorFromContext(c))
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		if strings.HasPrefix(err.Error(), "Cannot confirm payout") || err.Error() == "Refund has been changed by another request" {
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToRefundResponse(refund),
+	})
+}
+
+func (cn *controller) GetReport(c *gin.Context) {
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	to := from.AddDate(0, 1, 0)
+
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", value, now.Location())
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": true,
+				"data":  nil,
+				"msg":   "Invalid from date, expected YYYY-MM-DD",
+			})
+			return
+		}
+
+		from = parsed
+	}
+
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", value, now.Location())
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": true,
+				"data":  nil,
+				"msg":   "Invalid to date, expected YYYY-MM-DD",
+			})
+			return
+		}
+
+		to = parsed.AddDate(0, 0, 1)
+	}
+
+	report, err := cn.refundService.Report(from, to)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	statuses := map[string]RefundSummaryResponse{}
+
+	for status, summary := range report.Statuses {
+		statuses[status] = RefundSummaryResponse{Count: summary.Count, Amount: summary.Amount}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data": RefundReportResponse{
+			From:           report.From,
+			To:             report.To,
+			Statuses:       statuses,
+			RefundedAmount: report.RefundedAmount,
+			Refunds:        convertToRefundsResponse(report.Refunds),
+		},
+	})
+}
+
+func actorFromContext(c *gin.Context) payment.Actor {
+	return payment.Actor{
+		ID:   c.GetUint64("UserID"),
+		Role: c.GetString("UserRole"),
+	}
+}
+
+func convertToRefundsResponse(refunds []Refund) []RefundResponse {
+	refundsResponse := []RefundResponse{}
+
+	for _, refund := range refunds {
+		refundsResponse = append(refundsResponse, convertToRefundResponse(refund))
+	}
+
+	return refundsResponse
+}
+
+func convertToRefundResponse(refund Refund) RefundResponse {
+	items := []RefundItemResponse{}
+
+	for _, item := range refund.Items {
+		items = append(items, RefundItemResponse{
+			ID:        item.ID,
+			CartID:    item.CartID,
+			ProductID: item.ProductID,
+			VariantID: item.VariantID,
+			Quantity:  item.Quantity,
+			Amount:    item.Amount,
+		})
+	}
+
+	return RefundResponse{
+		ID:              refund.ID,
+		PaymentID:       refund.PaymentID,
+		UserID:          refund.UserID,
+		BankID:          refund.BankID,
+		Type:            refund.Type,
+		Reason:          refund.Reason,
+		Amount:          refund.Amount,
+		ShippingAmount:  refund.ShippingAmount,
+		Status:          refund.Status,
+		Restock:         refund.Restock,
+		ReviewNote:      refund.ReviewNote,
+		ReviewedAt:      refund.ReviewedAt,
+		PayoutReference: refund.PayoutReference,
+		PaidAt:          refund.PaidAt,
+		Items:           items,
+		CreatedAt:       refund.CreatedAt,
+	}
+}
@@ -0,0 +1,13 @@
+package refund
+
+type RefundItemRequest struct {
+	CartID   uint64 `json:"cart_id" binding:"required"`
+	Quantity int    `json:"quantity" binding:"required,min=1"`
+}
+
+type RefundCreateRequest struct {
+	BankID int                 `json:"bank_id" binding:"required"`
+	Reason string              `json:"reason" binding:"required"`
+	Amount int                 `json:"amount" binding:"omitempty,min=1"`
+	Items  []RefundItemRequest `json:"items" binding:"omitempty,dive"`
+}
@@ -0,0 +1,48 @@
+package refund
+
+import "time"
+
+const (
+	StatusRequested = "requested"
+	StatusApproved  = "approved"
+	StatusRejected  = "rejected"
+	StatusPaid      = "paid"
+)
+
+const (
+	TypeFull    = "full"
+	TypePartial = "partial"
+)
+
+type Refund struct {
+	ID              uint64       `gorm:"column:id;primaryKey;autoIncrement"`
+	PaymentID       uint64       `gorm:"column:payment_id;index"`
+	UserID          int          `gorm:"column:user_id;index"`
+	BankID          uint64       `gorm:"column:bank_id"`
+	Type            string       `gorm:"column:type;type:varchar(255)"`
+	Reason          string       `gorm:"column:reason;type:text"`
+	Amount          int          `gorm:"column:amount"`
+	ShippingAmount  int          `gorm:"column:shipping_amount"`
+	Status          string       `gorm:"column:status;type:varchar(255);index"`
+	Restock         bool         `gorm:"column:restock"`
+	RequesterID     uint64       `gorm:"column:requester_id"`
+	ReviewerID      *uint64      `gorm:"column:reviewer_id"`
+	ReviewNote      string       `gorm:"column:review_note;type:text"`
+	ReviewedAt      *time.Time   `gorm:"column:reviewed_at"`
+	PayoutReference string       `gorm:"column:payout_reference;type:varchar(255)"`
+	PaidAt          *time.Time   `gorm:"column:paid_at"`
+	Items           []RefundItem `gorm:"foreignKey:RefundID"`
+	CreatedAt       time.Time    `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt       time.Time    `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+type RefundItem struct {
+	ID        uint64    `gorm:"column:id;primaryKey;autoIncrement"`
+	RefundID  uint64    `gorm:"column:refund_id;index"`
+	CartID    uint64    `gorm:"column:cart_id;index"`
+	ProductID int       `gorm:"column:product_id"`
+	VariantID uint64    `gorm:"column:variant_id"`
+	Quantity  int       `gorm:"column:quantity"`
+	Amount    int       `gorm:"column:amount"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
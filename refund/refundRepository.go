@@ -0,0 +1,89 @@
+package refund
+
+import (
+	"errors"
+	"taman-pempek/cart"
+	"taman-pempek/payment"
+	"taman-pempek/product"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repositories struct {
+	Refund  RefundRepository
+	Cart    cart.CartRepository
+	Product product.ProductRepository
+	Payment payment.PaymentRepository
+}
+
+type RefundRepository interface {
+	Transaction(fn func(repositories Repositories) error) error
+	FindRefundByID(ID int) (Refund, error)
+	FindRefundsByPayment(paymentID int) ([]Refund, error)
+	FindRefundsByStatus(status string) ([]Refund, error)
+	FindRefundsBetween(from time.Time, to time.Time) ([]Refund, error)
+	CreateRefund(refund Refund) (Refund, error)
+	UpdateRefundStatus(refund Refund, from string) (bool, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) Transaction(fn func(repositories Repositories) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(Repositories{
+			Refund:  NewRepository(tx),
+			Cart:    cart.NewRepository(tx),
+			Product: product.NewRepository(tx),
+			Payment: payment.NewRepository(tx),
+		})
+	})
+}
+
+func (r *repository) FindRefundByID(ID int) (Refund, error) {
+	var refund Refund
+	err := r.db.Preload("Items").First(&refund, ID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Refund{}, errors.New("Refund not found")
+	}
+	return refund, err
+}
+
+func (r *repository) FindRefundsByPayment(paymentID int) ([]Refund, error) {
+	var refunds []Refund
+	err := r.db.Preload("Items").Where("payment_id = ?", paymentID).Order("id").Find(&refunds).Error
+	return refunds, err
+}
+
+func (r *repository) FindRefundsByStatus(status string) ([]Refund, error) {
+	var refunds []Refund
+	err := r.db.Preload("Items").Where("status = ?", status).Order("id").Find(&refunds).Error
+	return refunds, err
+}
+
+func (r *repository) FindRefundsBetween(from time.Time, to time.Time) ([]Refund, error) {
+	var refunds []Refund
+	err := r.db.Preload("Items").Where("created_at >= ? AND created_at < ?", from, to).Order("id").Find(&refunds).Error
+	return refunds, err
+}
+
+func (r *repository) CreateRefund(refund Refund) (Refund, error) {
+	err := r.db.Create(&refund).Error
+	return refund, err
+}
+
+func (r *repository) UpdateRefundStatus(refund Refund, from string) (bool, error) {
+	result := r.db.Model(&refund).
+		Where("status = ?", from).
+		Omit(clause.Associations).
+		Select("status", "restock", "reviewer_id", "review_note", "reviewed_at", "payout_reference", "paid_at").
+		Updates(&refund)
+	return result.RowsAffected > 0, result.Error
+}
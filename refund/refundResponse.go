@@ -0,0 +1,44 @@
+package refund
+
+import "time"
+
+type RefundItemResponse struct {
+	ID        uint64 `json:"id"`
+	CartID    uint64 `json:"cart_id"`
+	ProductID int    `json:"product_id"`
+	VariantID uint64 `json:"variant_id"`
+	Quantity  int    `json:"quantity"`
+	Amount    int    `json:"amount"`
+}
+
+type RefundResponse struct {
+	ID              uint64               `json:"id"`
+	PaymentID       uint64               `json:"payment_id"`
+	UserID          int                  `json:"user_id"`
+	BankID          uint64               `json:"bank_id"`
+	Type            string               `json:"type"`
+	Reason          string               `json:"reason"`
+	Amount          int                  `json:"amount"`
+	ShippingAmount  int                  `json:"shipping_amount"`
+	Status          string               `json:"status"`
+	Restock         bool                 `json:"restock"`
+	ReviewNote      string               `json:"review_note"`
+	ReviewedAt      *time.Time           `json:"reviewed_at"`
+	PayoutReference string               `json:"payout_reference"`
+	PaidAt          *time.Time           `json:"paid_at"`
+	Items           []RefundItemResponse `json:"items"`
+	CreatedAt       time.Time            `json:"created_at"`
+}
+
+type RefundSummaryResponse struct {
+	Count  int `json:"count"`
+	Amount int `json:"amount"`
+}
+
+type RefundReportResponse struct {
+	From           time.Time                        `json:"from"`
+	To             time.Time                        `json:"to"`
+	Statuses       map[string]RefundSummaryResponse `json:"statuses"`
+	RefundedAmount int                              `json:"refunded_amount"`
+	Refunds        []RefundResponse                 `json:"refunds"`
+}
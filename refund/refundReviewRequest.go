@@ -0,0 +1,10 @@
+package refund
+
+type RefundReviewRequest struct {
+	Note    string `json:"note"`
+	Restock bool   `json:"restock"`
+}
+
+type RefundPayoutRequest struct {
+	Reference string `json:"reference" binding:"required"`
+}
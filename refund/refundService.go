@@ -0,0 +1,453 @@
+package refund
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"taman-pempek/bank"
+	"taman-pempek/payment"
+	"taman-pempek/product"
+	"time"
+)
+
+type RefundService interface {
+	RequestRefund(paymentID int, request RefundCreateRequest, actor payment.Actor) (Refund, error)
+	FindRefundByID(ID int) (Refund, error)
+	FindRefundsByPayment(paymentID int) ([]Refund, error)
+	FindRefundsByStatus(status string) ([]Refund, error)
+	ApproveRefund(ID int, restock bool, note string, actor payment.Actor) (Refund, error)
+	RejectRefund(ID int, note string, actor payment.Actor) (Refund, error)
+	ConfirmPayout(ID int, reference string, actor payment.Actor) (Refund, error)
+	Report(from time.Time, to time.Time) (Report, error)
+}
+
+type Summary struct {
+	Count  int
+	Amount int
+}
+
+type Report struct {
+	From           time.Time
+	To             time.Time
+	Statuses       map[string]Summary
+	RefundedAmount int
+	Refunds        []Refund
+}
+
+var refundableStatuses = []string{
+	payment.StatusVerified,
+	payment.StatusProcessing,
+	payment.StatusShipped,
+	payment.StatusDelivered,
+	payment.StatusCompleted,
+}
+
+type service struct {
+	refundRepository RefundRepository
+	bankService      bank.BankService
+}
+
+func NewService(refundRepository RefundRepository, bankService bank.BankService) *service {
+	return &service{refundRepository, bankService}
+}
+
+func (s *service) RequestRefund(paymentID int, request RefundCreateRequest, actor payment.Actor) (Refund, error) {
+	var refund Refund
+
+	err := s.refundRepository.Transaction(func(repositories Repositories) error {
+		p, err := repositories.Payment.LockPaymentByID(paymentID)
+
+		if err != nil {
+			return err
+		}
+
+		history := []payment.PaymentStatusHistory{}
+
+		if p.PaymentStatus == payment.StatusCancelled {
+			if history, err = repositories.Payment.FindStatusHistory(paymentID); err != nil {
+				return err
+			}
+		}
+
+		if !isRefundable(p, history) {
+			return fmt.Errorf("Cannot refund payment with status %s", p.PaymentStatus)
+		}
+
+		destination, err := s.bankService.FindBankByID(request.BankID)
+
+		if err != nil {
+			return err
+		}
+
+		if destination.UserID != p.UserID {
+			return errors.New("Bank does not belong to the buyer")
+		}
+
+		refunds, err := repositories.Refund.FindRefundsByPayment(paymentID)
+
+		if err != nil {
+			return err
+		}
+
+		reservedAmount := 0
+		reservedShipping := 0
+		reservedQuantity := map[uint64]int{}
+
+		for _, r := range refunds {
+			if r.Status == StatusRejected {
+				continue
+			}
+
+			reservedAmount += r.Amount
+			reservedShipping += r.ShippingAmount
+
+			for _, item := range r.Items {
+				reservedQuantity[item.CartID] += item.Quantity
+			}
+		}
+
+		carts, err := repositories.Cart.FindCartsByPaymentID(paymentID)
+
+		if err != nil {
+			return err
+		}
+
+		lines := map[uint64]refundLine{}
+
+		for _, c := range carts {
+			productID, _ := strconv.Atoi(c.ProductID.String())
+			quantity, _ := strconv.Atoi(c.Quantity.String())
+			totalPrice, _ := strconv.Atoi(c.TotalPrice.String())
+
+			if quantity <= 0 {
+				continue
+			}
+
+			lines[c.ID] = refundLine{
+				productID: productID,
+				variantID: c.VariantID,
+				remaining: quantity - reservedQuantity[c.ID],
+				unitPrice: totalPrice / quantity,
+			}
+		}
+
+		items := []RefundItem{}
+		refundType := TypePartial
+		shippingAmount := 0
+
+		if len(request.Items) == 0 {
+			refundType = TypeFull
+			shippingAmount = p.ShippingFee - reservedShipping
+
+			for _, c := range carts {
+				if line, ok := lines[c.ID]; ok && line.remaining > 0 {
+					items = append(items, line.item(c.ID, line.remaining))
+				}
+			}
+		}
+
+		for _, requested := range request.Items {
+			line, ok := lines[requested.CartID]
+
+			if !ok {
+				return fmt.Errorf("Cart %d is not part of this payment", requested.CartID)
+			}
+
+			if requested.Quantity > line.remaining {
+				return fmt.Errorf("Refund quantity exceeds remaining quantity on cart %d", requested.CartID)
+			}
+
+			line.remaining -= requested.Quantity
+			lines[requested.CartID] = line
+			items = append(items, line.item(requested.CartID, requested.Quantity))
+		}
+
+		refundable := p.TotalPrice - reservedAmount
+		amount := refundable
+
+		if len(carts) > 0 {
+			amount = shippingAmount
+			for _, item := range items {
+				amount += item.Amount
+			}
+		}
+
+		if amount > refundable {
+			amount = refundable
+		}
+
+		if shippingAmount > amount {
+			shippingAmount = amount
+		}
+
+		if request.Amount > 0 {
+			if request.Amount > amount {
+				return errors.New("Refund amount exceeds refundable amount")
+			}
+			if amount > 0 {
+				shippingAmount = shippingAmount * request.Amount / amount
+			}
+			amount = request.Amount
+		}
+
+		if amount <= 0 {
+			return errors.New("Nothing left to refund on this payment")
+		}
+
+		refund, err = repositories.Refund.CreateRefund(Refund{
+			PaymentID:      p.ID,
+			UserID:         p.UserID,
+			BankID:         destination.ID,
+			Type:           refundType,
+			Reason:         request.Reason,
+			Amount:         amount,
+			ShippingAmount: shippingAmount,
+			Status:         StatusRequested,
+			RequesterID:    actor.ID,
+			Items:          items,
+		})
+
+		return err
+	})
+
+	return refund, err
+}
+
+func (s *service) FindRefundByID(ID int) (Refund, error) {
+	return s.refundRepository.FindRefundByID(ID)
+}
+
+func (s *service) FindRefundsByPayment(paymentID int) ([]Refund, error) {
+	return s.refundRepository.FindRefundsByPayment(paymentID)
+}
+
+func (s *service) FindRefundsByStatus(status string) ([]Refund, error) {
+	if status != StatusRequested && status != StatusApproved && status != StatusRejected && status != StatusPaid {
+		return nil, errors.New("Invalid refund status")
+	}
+
+	return s.refundRepository.FindRefundsByStatus(status)
+}
+
+func (s *service) ApproveRefund(ID int, restock bool, note string, actor payment.Actor) (Refund, error) {
+	var refund Refund
+
+	err := s.refundRepository.Transaction(func(repositories Repositories) error {
+		var err error
+
+		if restock {
+			if err := checkRestock(repositories, ID); err != nil {
+				return err
+			}
+		}
+
+		refund, err = s.review(repositories, ID, StatusApproved, note, restock, actor)
+
+		if err != nil || !restock {
+			return err
+		}
+
+		for _, item := range refund.Items {
+			if item.ProductID == 0 {
+				continue
+			}
+
+			if err := product.RestoreStock(repositories.Product, item.ProductID, item.VariantID, item.Quantity); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return refund, err
+}
+
+func (s *service) RejectRefund(ID int, note string, actor payment.Actor) (Refund, error) {
+	var refund Refund
+
+	err := s.refundRepository.Transaction(func(repositories Repositories) error {
+		var err error
+		refund, err = s.review(repositories, ID, StatusRejected, note, false, actor)
+		return err
+	})
+
+	return refund, err
+}
+
+func (s *service) ConfirmPayout(ID int, reference string, actor payment.Actor) (Refund, error) {
+	var refund Refund
+
+	err := s.refundRepository.Transaction(func(repositories Repositories) error {
+		var err error
+
+		refund, err = repositories.Refund.FindRefundByID(ID)
+
+		if err != nil {
+			return err
+		}
+
+		if refund.Status != StatusApproved {
+			return fmt.Errorf("Cannot confirm payout for refund with status %s", refund.Status)
+		}
+
+		now := time.Now()
+		refund.Status = StatusPaid
+		refund.PayoutReference = reference
+		refund.PaidAt = &now
+
+		updated, err := repositories.Refund.UpdateRefundStatus(refund, StatusApproved)
+
+		if err != nil {
+			return err
+		}
+
+		if !updated {
+			return errors.New("Refund has been changed by another request")
+		}
+
+		p, err := repositories.Payment.LockPaymentByID(int(refund.PaymentID))
+
+		if err != nil {
+			return err
+		}
+
+		if err := repositories.Payment.AddRefundedAmount(p.ID, refund.Amount); err != nil {
+			return err
+		}
+
+		if p.RefundedAmount+refund.Amount < p.TotalPrice || !payment.CanTransition(p.PaymentMethod, p.PaymentStatus, payment.StatusRefunded) {
+			return nil
+		}
+
+		_, err = repositories.Payment.TransitionStatus(p.ID, p.PaymentStatus, payment.PaymentStatusHistory{
+			PaymentID:  p.ID,
+			FromStatus: p.PaymentStatus,
+			ToStatus:   payment.StatusRefunded,
+			ActorID:    actor.ID,
+			ActorRole:  actor.Role,
+			Note:       fmt.Sprintf("Refund %d paid out (%s)", refund.ID, reference),
+		})
+
+		return err
+	})
+
+	return refund, err
+}
+
+func (s *service) Report(from time.Time, to time.Time) (Report, error) {
+	refunds, err := s.refundRepository.FindRefundsBetween(from, to)
+
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := Report{From: from, To: to, Statuses: map[string]Summary{}, Refunds: refunds}
+
+	for _, status := range []string{StatusRequested, StatusApproved, StatusRejected, StatusPaid} {
+		report.Statuses[status] = Summary{}
+	}
+
+	for _, r := range refunds {
+		summary := report.Statuses[r.Status]
+		summary.Count++
+		summary.Amount += r.Amount
+		report.Statuses[r.Status] = summary
+
+		if r.Status == StatusPaid {
+			report.RefundedAmount += r.Amount
+		}
+	}
+
+	return report, nil
+}
+
+func (s *service) review(repositories Repositories, ID int, to string, note string, restock bool, actor payment.Actor) (Refund, error) {
+	refund, err := repositories.Refund.FindRefundByID(ID)
+
+	if err != nil {
+		return Refund{}, err
+	}
+
+	if refund.Status != StatusRequested {
+		return Refund{}, fmt.Errorf("Cannot change refund status from %s to %s", refund.Status, to)
+	}
+
+	now := time.Now()
+	refund.Status = to
+	refund.Restock = restock
+	refund.ReviewerID = &actor.ID
+	refund.ReviewNote = note
+	refund.ReviewedAt = &now
+
+	updated, err := repositories.Refund.UpdateRefundStatus(refund, StatusRequested)
+
+	if err != nil {
+		return Refund{}, err
+	}
+
+	if !updated {
+		return Refund{}, errors.New("Refund has been changed by another request")
+	}
+
+	return refund, nil
+}
+
+type refundLine struct {
+	productID int
+	variantID uint64
+	remaining int
+	unitPrice int
+}
+
+func (l refundLine) item(cartID uint64, quantity int) RefundItem {
+	return RefundItem{
+		CartID:    cartID,
+		ProductID: l.productID,
+		VariantID: l.variantID,
+		Quantity:  quantity,
+		Amount:    l.unitPrice * quantity,
+	}
+}
+
+func checkRestock(repositories Repositories, ID int) error {
+	refund, err := repositories.Refund.FindRefundByID(ID)
+
+	if err != nil {
+		return err
+	}
+
+	p, err := repositories.Payment.FindPaymentByID(int(refund.PaymentID))
+
+	if err != nil {
+		return err
+	}
+
+	if p.PaymentStatus == payment.StatusCancelled {
+		return errors.New("Cannot restock a cancelled order, its stock has already been released")
+	}
+
+	return nil
+}
+
+func isRefundable(p payment.Payment, history []payment.PaymentStatusHistory) bool {
+	if p.PaymentStatus == payment.StatusCancelled {
+		for _, h := range history {
+			if h.ToStatus == payment.StatusVerified {
+				return true
+			}
+		}
+		return false
+	}
+
+	if payment.IsCashMethod(p.PaymentMethod) {
+		return payment.CanTransition(p.PaymentMethod, p.PaymentStatus, payment.StatusRefunded)
+	}
+
+	for _, s := range refundableStatuses {
+		if s == p.PaymentStatus {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package scheduler
+
+import (
+	"log"
+	"taman-pempek/checkout"
+	"taman-pempek/setting"
+	"time"
+)
+
+const defaultPaymentDeadline = 24 * time.Hour
+
+type expiryScheduler struct {
+	checkoutService checkout.CheckoutService
+	settingService  setting.SettingService
+	interval        time.Duration
+}
+
+func NewExpiryScheduler(checkoutService checkout.CheckoutService, settingService setting.SettingService, interval time.Duration) *expiryScheduler {
+	return &expiryScheduler{checkoutService, settingService, interval}
+}
+
+func (s *expiryScheduler) Start() {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			s.Run()
+		}
+	}()
+}
+
+func (s *expiryScheduler) Run() {
+	deadline := defaultPaymentDeadline
+
+	if storeSetting, err := s.settingService.FindSettingByID(setting.StoreSettingID); err == nil && storeSetting.PaymentDeadline > 0 {
+		deadline = time.Duration(storeSetting.PaymentDeadline) * time.Minute
+	}
+
+	expired, err := s.checkoutService.ExpireOverdueOrders(deadline)
+
+	if err != nil {
+		log.Printf("Failed to expire overdue payments: %v", err)
+	}
+
+	if expired > 0 {
+		log.Printf("Expired %d overdue payments", expired)
+	}
+}
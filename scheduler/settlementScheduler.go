@@ -0,0 +1,39 @@
+package scheduler
+
+import (
+	"log"
+	"taman-pempek/ledger"
+	"time"
+)
+
+type settlementScheduler struct {
+	ledgerService ledger.LedgerService
+	interval      time.Duration
+}
+
+func NewSettlementScheduler(ledgerService ledger.LedgerService, interval time.Duration) *settlementScheduler {
+	return &settlementScheduler{ledgerService, interval}
+}
+
+func (s *settlementScheduler) Start() {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			s.Run()
+		}
+	}()
+}
+
+func (s *settlementScheduler) Run() {
+	settled, err := s.ledgerService.SettleCompletedPayments()
+
+	if err != nil {
+		log.Printf("Failed to settle completed payments: %v", err)
+	}
+
+	if settled > 0 {
+		log.Printf("Settled %d completed payments", settled)
+	}
+}
@@ -0,0 +1,39 @@
+package scheduler
+
+import (
+	"log"
+	"taman-pempek/tracking"
+	"time"
+)
+
+type trackingScheduler struct {
+	trackingService tracking.TrackingService
+	interval        time.Duration
+}
+
+func NewTrackingScheduler(trackingService tracking.TrackingService, interval time.Duration) *trackingScheduler {
+	return &trackingScheduler{trackingService, interval}
+}
+
+func (s *trackingScheduler) Start() {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			s.Run()
+		}
+	}()
+}
+
+func (s *trackingScheduler) Run() {
+	delivered, err := s.trackingService.PollInTransit()
+
+	if err != nil {
+		log.Printf("Failed to poll shipment tracking: %v", err)
+	}
+
+	if delivered > 0 {
+		log.Printf("Marked %d shipments as delivered from courier tracking", delivered)
+	}
+}
@@ -0,0 +1,5 @@
+package session
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
@@ -0,0 +1,57 @@
+package session
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type controller struct {
+	sessionService SessionService
+}
+
+func NewController(sessionService SessionService) *controller {
+	return &controller{sessionService}
+}
+
+func (cn *controller) Refresh(c *gin.Context) {
+	var request RefreshRequest
+
+	c.ShouldBindJSON(&request)
+
+	if request.RefreshToken == "" {
+		request.RefreshToken, _ = c.Cookie(RefreshTokenCookie)
+	}
+
+	if request.RefreshToken == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"token": nil,
+			"msg":   "Refresh token is required",
+		})
+		return
+	}
+
+	tokens, err := cn.sessionService.Refresh(request.RefreshToken, c.Request.UserAgent(), c.ClientIP())
+
+	if err != nil {
+		ClearAuthCookies(c)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": true,
+			"data":  nil,
+			"token": nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	SetAuthCookies(c, tokens)
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"token": tokens.AccessToken,
+		"data":  ConvertToTokenResponse(tokens),
+	})
+}
@@ -0,0 +1,32 @@
+package session
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	AccessTokenCookie  = "Authorization"
+	RefreshTokenCookie = "RefreshToken"
+)
+
+func SetAuthCookies(c *gin.Context, tokens Tokens) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(AccessTokenCookie, tokens.AccessToken, int(AccessTokenTTL.Seconds()), "", "", false, true)
+	c.SetCookie(RefreshTokenCookie, tokens.RefreshToken, int(RefreshTokenTTL.Seconds()), "/v1/token", "", false, true)
+}
+
+func ClearAuthCookies(c *gin.Context) {
+	c.SetCookie(AccessTokenCookie, "", -1, "", "", false, true)
+	c.SetCookie(RefreshTokenCookie, "", -1, "/v1/token", "", false, true)
+}
+
+func ConvertToTokenResponse(tokens Tokens) TokenResponse {
+	return TokenResponse{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(AccessTokenTTL.Seconds()),
+	}
+}
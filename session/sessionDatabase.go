@@ -0,0 +1,16 @@
+package session
+
+import "time"
+
+type Session struct {
+	ID               uint64     `gorm:"column:id;primaryKey;autoIncrement"`
+	UserID           uint64     `gorm:"column:user_id;index"`
+	RefreshTokenHash string     `gorm:"column:refresh_token_hash;type:varchar(64);uniqueIndex"`
+	UserAgent        string     `gorm:"column:user_agent;type:varchar(255)"`
+	IP               string     `gorm:"column:ip;type:varchar(255)"`
+	ExpiresAt        time.Time  `gorm:"column:expires_at"`
+	RevokedAt        *time.Time `gorm:"column:revoked_at"`
+	ReplacedByID     *uint64    `gorm:"column:replaced_by_id"`
+	CreatedAt        time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt        time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
@@ -0,0 +1,66 @@
+package session
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SessionRepository interface {
+	FindSessionByID(ID uint64) (Session, error)
+	FindSessionByTokenHash(hash string) (Session, error)
+	CreateSession(session Session) (Session, error)
+	UpdateSession(session Session) (Session, error)
+	RevokeSession(ID uint64, revokedAt time.Time) (bool, error)
+	RevokeSessionsByUser(userID uint64, revokedAt time.Time) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) FindSessionByID(ID uint64) (Session, error) {
+	var session Session
+	err := r.db.First(&session, ID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Session{}, errors.New("Session not found")
+	}
+	return session, err
+}
+
+func (r *repository) FindSessionByTokenHash(hash string) (Session, error) {
+	var session Session
+	err := r.db.Where("refresh_token_hash = ?", hash).First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Session{}, errors.New("Session not found")
+	}
+	return session, err
+}
+
+func (r *repository) CreateSession(session Session) (Session, error) {
+	err := r.db.Create(&session).Error
+	return session, err
+}
+
+func (r *repository) UpdateSession(session Session) (Session, error) {
+	err := r.db.Save(&session).Error
+	return session, err
+}
+
+func (r *repository) RevokeSession(ID uint64, revokedAt time.Time) (bool, error) {
+	result := r.db.Model(&Session{}).
+		Where("id = ? AND revoked_at IS NULL", ID).
+		Update("revoked_at", revokedAt)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *repository) RevokeSessionsByUser(userID uint64, revokedAt time.Time) error {
+	return r.db.Model(&Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", revokedAt).Error
+}
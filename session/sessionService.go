@@ -0,0 +1,193 @@
+package session
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AccessTokenTTL  = 15 * time.Minute
+	RefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+type Tokens struct {
+	AccessToken  string
+	RefreshToken string
+	Session      Session
+}
+
+type SessionService interface {
+	CreateSession(userID uint64, userAgent string, ip string) (Tokens, error)
+	Refresh(refreshToken string, userAgent string, ip string) (Tokens, error)
+	ValidateAccessToken(accessToken string) (Session, error)
+	RevokeSession(ID uint64) error
+	RevokeSessionsByUser(userID uint64) error
+}
+
+type service struct {
+	sessionRepository SessionRepository
+}
+
+func NewService(sessionRepository SessionRepository) *service {
+	return &service{sessionRepository}
+}
+
+func (s *service) CreateSession(userID uint64, userAgent string, ip string) (Tokens, error) {
+	refreshToken, err := generateRefreshToken()
+
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	sessionData := Session{
+		UserID:           userID,
+		RefreshTokenHash: hashToken(refreshToken),
+		UserAgent:        userAgent,
+		IP:               ip,
+		ExpiresAt:        time.Now().Add(RefreshTokenTTL),
+	}
+
+	session, err := s.sessionRepository.CreateSession(sessionData)
+
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	accessToken, err := signAccessToken(session)
+
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	return Tokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		Session:      session,
+	}, nil
+}
+
+func (s *service) Refresh(refreshToken string, userAgent string, ip string) (Tokens, error) {
+	session, err := s.sessionRepository.FindSessionByTokenHash(hashToken(refreshToken))
+
+	if err != nil {
+		return Tokens{}, errors.New("Invalid refresh token")
+	}
+
+	now := time.Now()
+
+	if session.RevokedAt != nil {
+		// A rotated token being presented again means it leaked, so end every session of the user.
+		s.sessionRepository.RevokeSessionsByUser(session.UserID, now)
+		return Tokens{}, errors.New("Invalid refresh token")
+	}
+
+	if now.After(session.ExpiresAt) {
+		return Tokens{}, errors.New("Refresh token expired")
+	}
+
+	revoked, err := s.sessionRepository.RevokeSession(session.ID, now)
+
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	if !revoked {
+		s.sessionRepository.RevokeSessionsByUser(session.UserID, now)
+		return Tokens{}, errors.New("Invalid refresh token")
+	}
+
+	tokens, err := s.CreateSession(session.UserID, userAgent, ip)
+
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	session.RevokedAt = &now
+	session.ReplacedByID = &tokens.Session.ID
+
+	if _, err := s.sessionRepository.UpdateSession(session); err != nil {
+		return Tokens{}, err
+	}
+
+	return tokens, nil
+}
+
+func (s *service) ValidateAccessToken(accessToken string) (Session, error) {
+	token, err := jwt.Parse(accessToken, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+		}
+
+		return []byte(os.Getenv("SECRET")), nil
+	})
+
+	if err != nil || !token.Valid {
+		return Session{}, errors.New("Invalid access token")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+
+	if !ok {
+		return Session{}, errors.New("Invalid access token")
+	}
+
+	sessionID, ok := claims["sid"].(float64)
+
+	if !ok {
+		return Session{}, errors.New("Invalid access token")
+	}
+
+	session, err := s.sessionRepository.FindSessionByID(uint64(sessionID))
+
+	if err != nil {
+		return Session{}, errors.New("Invalid access token")
+	}
+
+	if session.RevokedAt != nil || time.Now().After(session.ExpiresAt) {
+		return Session{}, errors.New("Session has been revoked")
+	}
+
+	return session, nil
+}
+
+func (s *service) RevokeSession(ID uint64) error {
+	_, err := s.sessionRepository.RevokeSession(ID, time.Now())
+	return err
+}
+
+func (s *service) RevokeSessionsByUser(userID uint64) error {
+	return s.sessionRepository.RevokeSessionsByUser(userID, time.Now())
+}
+
+func signAccessToken(session Session) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": session.UserID,
+		"sid": session.ID,
+		"exp": time.Now().Add(AccessTokenTTL).Unix(),
+	})
+
+	return token.SignedString([]byte(os.Getenv("SECRET")))
+}
+
+func generateRefreshToken() (string, error) {
+	buf := make([]byte, 32)
+
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,150 @@
+package session
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type memoryRepository struct {
+	sessions []Session
+}
+
+func (r *memoryRepository) FindSessionByID(ID uint64) (Session, error) {
+	if ID == 0 || ID > uint64(len(r.sessions)) {
+		return Session{}, errors.New("Session not found")
+	}
+	return r.sessions[ID-1], nil
+}
+
+func (r *memoryRepository) FindSessionByTokenHash(hash string) (Session, error) {
+	for _, session := range r.sessions {
+		if session.RefreshTokenHash == hash {
+			return session, nil
+		}
+	}
+	return Session{}, errors.New("Session not found")
+}
+
+func (r *memoryRepository) CreateSession(session Session) (Session, error) {
+	session.ID = uint64(len(r.sessions) + 1)
+	r.sessions = append(r.sessions, session)
+	return session, nil
+}
+
+func (r *memoryRepository) UpdateSession(session Session) (Session, error) {
+	r.sessions[session.ID-1] = session
+	return session, nil
+}
+
+func (r *memoryRepository) RevokeSession(ID uint64, revokedAt time.Time) (bool, error) {
+	session := &r.sessions[ID-1]
+	if session.RevokedAt != nil {
+		return false, nil
+	}
+	session.RevokedAt = &revokedAt
+	return true, nil
+}
+
+func (r *memoryRepository) RevokeSessionsByUser(userID uint64, revokedAt time.Time) error {
+	for i := range r.sessions {
+		if r.sessions[i].UserID == userID && r.sessions[i].RevokedAt == nil {
+			r.sessions[i].RevokedAt = &revokedAt
+		}
+	}
+	return nil
+}
+
+func newTestService(t *testing.T) (*service, *memoryRepository) {
+	t.Setenv("SECRET", "session-test-secret")
+	repository := &memoryRepository{}
+	return NewService(repository), repository
+}
+
+func TestRefreshRotatesToken(t *testing.T) {
+	s, repository := newTestService(t)
+
+	tokens, err := s.CreateSession(1, "browser", "127.0.0.1")
+	if err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+
+	if repository.sessions[0].RefreshTokenHash == tokens.RefreshToken {
+		t.Fatal("refresh token is stored in plain text")
+	}
+
+	rotated, err := s.Refresh(tokens.RefreshToken, "browser", "127.0.0.1")
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+
+	if rotated.RefreshToken == tokens.RefreshToken || rotated.Session.ID == tokens.Session.ID {
+		t.Fatal("refresh token was not rotated")
+	}
+
+	old := repository.sessions[0]
+	if old.RevokedAt == nil || old.ReplacedByID == nil || *old.ReplacedByID != rotated.Session.ID {
+		t.Errorf("old session = %+v", old)
+	}
+
+	if _, err := s.ValidateAccessToken(tokens.AccessToken); err == nil {
+		t.Error("access token of the rotated session is still valid")
+	}
+
+	session, err := s.ValidateAccessToken(rotated.AccessToken)
+	if err != nil || session.ID != rotated.Session.ID {
+		t.Errorf("ValidateAccessToken = %+v, %v", session, err)
+	}
+}
+
+func TestRefreshReuseRevokesEverySession(t *testing.T) {
+	s, repository := newTestService(t)
+
+	phone, _ := s.CreateSession(1, "phone", "10.0.0.1")
+	laptop, _ := s.CreateSession(1, "laptop", "10.0.0.2")
+	other, _ := s.CreateSession(2, "browser", "10.0.0.3")
+
+	rotated, err := s.Refresh(phone.RefreshToken, "phone", "10.0.0.1")
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+
+	if _, err := s.Refresh(phone.RefreshToken, "attacker", "10.0.0.9"); err == nil {
+		t.Fatal("reused refresh token was accepted")
+	}
+
+	for _, tokens := range []Tokens{rotated, laptop} {
+		if _, err := s.ValidateAccessToken(tokens.AccessToken); err == nil {
+			t.Errorf("session %d survived refresh token reuse", tokens.Session.ID)
+		}
+	}
+
+	if _, err := s.Refresh(rotated.RefreshToken, "phone", "10.0.0.1"); err == nil {
+		t.Error("rotated refresh token still works after reuse")
+	}
+
+	if _, err := s.ValidateAccessToken(other.AccessToken); err != nil {
+		t.Errorf("another user's session was revoked: %v", err)
+	}
+
+	for _, session := range repository.sessions {
+		if session.UserID == 1 && session.RevokedAt == nil {
+			t.Errorf("session %d is still active", session.ID)
+		}
+	}
+}
+
+func TestRefreshRejectsExpiredAndUnknownTokens(t *testing.T) {
+	s, repository := newTestService(t)
+
+	tokens, _ := s.CreateSession(1, "browser", "127.0.0.1")
+	repository.sessions[0].ExpiresAt = time.Now().Add(-time.Minute)
+
+	if _, err := s.Refresh(tokens.RefreshToken, "browser", "127.0.0.1"); err == nil || err.Error() != "Refresh token expired" {
+		t.Errorf("expired refresh = %v", err)
+	}
+
+	if _, err := s.Refresh("not-a-token", "browser", "127.0.0.1"); err == nil {
+		t.Error("unknown refresh token was accepted")
+	}
+}
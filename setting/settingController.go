@@ -1,26 +1,24 @@
 package setting
 
 import (
-	"context"
 	"fmt"
-	"log"
 	"net/http"
-	"os"
 	"strconv"
+	"taman-pempek/storage"
 
-	"github.com/cloudinary/cloudinary-go/v2"
-	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
-	"github.com/joho/godotenv"
 )
 
+const imageFolder = "setting/image"
+
 type controller struct {
 	settingService SettingService
+	mediaStore     storage.MediaStore
 }
 
-func NewController(settingService SettingService) *controller {
-	return &controller{settingService}
+func NewController(settingService SettingService, mediaStore storage.MediaStore) *controller {
+	return &controller{settingService, mediaStore}
 }
 
 func (cn *controller) GetSetting(c *gin.Context) {
@@ -61,11 +59,6 @@ func (cn *controller) GetSetting(c *gin.Context) {
 func (cn *controller) UpdateSetting(c *gin.Context) {
 	var settingRequest SettingUpdateRequest
 
-	apiKey := goDotEnvVariable("APIKEY")
-	apiSecret := goDotEnvVariable("APISECRET")
-
-	urlCloudinary := "cloudinary://" + apiKey + ":" + apiSecret + "@dqudegiey"
-
 	err := c.ShouldBind(&settingRequest)
 
 	if err != nil {
@@ -83,14 +76,20 @@ func (cn *controller) UpdateSetting(c *gin.Context) {
 	}
 
 	if settingRequest.Image != nil {
-		file, _ := settingRequest.Image.Open()
-
-		ctx := context.Background()
-
-		cldService, _ := cloudinary.NewFromURL(urlCloudinary)
-		imageResponse, _ := cldService.Upload.Upload(ctx, file, uploader.UploadParams{})
+		media, err := storage.Upload(cn.mediaStore, imageFolder, settingRequest.Image, storage.SettingImage)
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": true,
+				"data":  nil,
+				"msg":   err.Error(),
+			})
+			return
+		}
 
-		settingRequest.Image.Filename = imageResponse.SecureURL
+		settingRequest.Image.Filename = media.URL
+		settingRequest.ImageThumbnail = media.Variants[storage.Thumbnail.Name]
+		settingRequest.ImageMedium = media.Variants[storage.Medium.Name]
 	}
 
 	idString := c.Param("id")
@@ -129,19 +128,23 @@ func (cn *controller) UpdateSetting(c *gin.Context) {
 
 func convertToSettingResponse(setting Setting) SettingResponse {
 	return SettingResponse{
-		ID:          setting.ID,
-		Image:       setting.Image,
-		Description: setting.Description,
-		Email:       setting.Email,
-		Instagram:   setting.Instagram,
-		Website:     setting.Website,
+		ID:                 setting.ID,
+		Image:              setting.Image,
+		ImageThumbnail:     variant(setting.ImageThumbnail, setting.Image),
+		ImageMedium:        variant(setting.ImageMedium, setting.Image),
+		ImageLarge:         setting.Image,
+		Description:        setting.Description,
+		Email:              setting.Email,
+		Instagram:          setting.Instagram,
+		Website:            setting.Website,
+		PaymentDeadline:    setting.PaymentDeadline,
+		PlatformCommission: setting.PlatformCommission,
 	}
 }
 
-func goDotEnvVariable(key string) string {
-	err := godotenv.Load(".env")
-	if err != nil {
-		log.Fatalf("Error loading .env file")
+func variant(URL string, fallback string) string {
+	if URL == "" {
+		return fallback
 	}
-	return os.Getenv(key)
+	return URL
 }
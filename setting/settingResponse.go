@@ -1,10 +1,15 @@
 package setting
 
 type SettingResponse struct {
-	ID          uint64 `json:"id"`
-	Image       string `json:"image"`
-	Description string `json:"description"`
-	Email       string `json:"email"`
-	Instagram   string `json:"instagram"`
-	Website     string `json:"website"`
+	ID                 uint64 `json:"id"`
+	Image              string `json:"image"`
+	ImageThumbnail     string `json:"image_thumbnail"`
+	ImageMedium        string `json:"image_medium"`
+	ImageLarge         string `json:"image_large"`
+	Description        string `json:"description"`
+	Email              string `json:"email"`
+	Instagram          string `json:"instagram"`
+	Website            string `json:"website"`
+	PaymentDeadline    int    `json:"payment_deadline"`
+	PlatformCommission int    `json:"platform_commission"`
 }
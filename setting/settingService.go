@@ -34,6 +34,8 @@ func (s *service) UpdateSetting(ID int, settingRequest SettingUpdateRequest) (Se
 	}
 	if settingRequest.Image != nil {
 		setting.Image = settingRequest.Image.Filename
+		setting.ImageThumbnail = settingRequest.ImageThumbnail
+		setting.ImageMedium = settingRequest.ImageMedium
 	}
 	if settingRequest.Description != "" {
 		setting.Description = settingRequest.Description
@@ -47,6 +49,12 @@ func (s *service) UpdateSetting(ID int, settingRequest SettingUpdateRequest) (Se
 	if settingRequest.Website != "" {
 		setting.Website = settingRequest.Website
 	}
+	if settingRequest.PaymentDeadline != 0 {
+		setting.PaymentDeadline = settingRequest.PaymentDeadline
+	}
+	if settingRequest.PlatformCommission != nil {
+		setting.PlatformCommission = *settingRequest.PlatformCommission
+	}
 
 	return s.settingRepository.UpdateSetting(setting)
 }
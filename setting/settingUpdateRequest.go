@@ -3,9 +3,13 @@ package setting
 import "mime/multipart"
 
 type SettingUpdateRequest struct {
-	Image       *multipart.FileHeader `form:"image,omitempty"`
-	Description string                `form:"description,omitempty"`
-	Email       string                `form:"email,omitempty"`
-	Instagram   string                `form:"instagram,omitempty"`
-	Website     string                `form:"website,omitempty"`
+	Image              *multipart.FileHeader `form:"image,omitempty"`
+	ImageThumbnail     string                `form:"-"`
+	ImageMedium        string                `form:"-"`
+	Description        string                `form:"description,omitempty"`
+	Email              string                `form:"email,omitempty"`
+	Instagram          string                `form:"instagram,omitempty"`
+	Website            string                `form:"website,omitempty"`
+	PaymentDeadline    int                   `form:"payment_deadline,omitempty" binding:"omitempty,min=1"`
+	PlatformCommission *int                  `form:"platform_commission,omitempty" binding:"omitempty,min=0,max=100"`
 }
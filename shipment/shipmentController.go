@@ -0,0 +1,276 @@
+package shipment
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"taman-pempek/payment"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type controller struct {
+	shipmentService ShipmentService
+}
+
+func NewController(shipmentService ShipmentService) *controller {
+	return &controller{shipmentService}
+}
+
+func (cn *controller) GetShipment(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid shipment ID",
+		})
+		return
+	}
+
+	fulfilment, err := cn.shipmentService.FindShipmentByID(id)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "Shipment not found" {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToShipmentResponse(fulfilment),
+	})
+}
+
+func (cn *controller) GetPaymentShipments(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid payment ID",
+		})
+		return
+	}
+
+	fulfilments, err := cn.shipmentService.FindShipmentsByPayment(id)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToShipmentsResponse(fulfilments),
+	})
+}
+
+func (cn *controller) GetSellerShipments(c *gin.Context) {
+	userIDString := c.Param("userId")
+	userID, err := strconv.Atoi(userIDString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid user ID",
+		})
+		return
+	}
+
+	fulfilments, err := cn.shipmentService.FindShipmentsBySeller(userID, c.Query("status"))
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToShipmentsResponse(fulfilments),
+	})
+}
+
+func (cn *controller) UpdateShipmentDelivery(c *gin.Context) {
+	var deliveryRequest ShipmentDeliveryRequest
+
+	err := c.ShouldBindJSON(&deliveryRequest)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid request body",
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid shipment ID",
+		})
+		return
+	}
+
+	shipment, err := cn.shipmentService.UpdateDelivery(id, deliveryRequest)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "Shipment not found" {
+			statusCode = http.StatusNotFound
+		}
+		if strings.HasPrefix(err.Error(), "Cannot update delivery") {
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	fulfilment, _ := cn.shipmentService.FindShipmentByID(int(shipment.ID))
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToShipmentResponse(fulfilment),
+	})
+}
+
+func (cn *controller) UpdateShipmentStatus(c *gin.Context) {
+	var statusRequest ShipmentStatusRequest
+
+	err := c.ShouldBindJSON(&statusRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		for _, e := range err.(validator.ValidationErrors) {
+			errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+			errorMessages = append(errorMessages, errorMessage)
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid shipment ID",
+		})
+		return
+	}
+
+	actor := payment.Actor{
+		ID:   c.GetUint64("UserID"),
+		Role: c.GetString("UserRole"),
+	}
+
+	shipment, err := cn.shipmentService.UpdateStatus(id, statusRequest.Status, actor)
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if err.Error() == "Shipment not found" || err.Error() == "Payment not found" {
+			statusCode = http.StatusNotFound
+		}
+		if strings.HasPrefix(err.Error(), "Cannot change") || err.Error() == "Shipment status has been changed by another request" {
+			statusCode = http.StatusConflict
+		}
+		if err.Error() == "Only admins can cancel a shipment" {
+			statusCode = http.StatusForbidden
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	fulfilment, _ := cn.shipmentService.FindShipmentByID(int(shipment.ID))
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToShipmentResponse(fulfilment),
+	})
+}
+
+func convertToShipmentsResponse(fulfilments []Fulfilment) []ShipmentResponse {
+	shipmentsResponse := []ShipmentResponse{}
+
+	for _, fulfilment := range fulfilments {
+		shipmentsResponse = append(shipmentsResponse, convertToShipmentResponse(fulfilment))
+	}
+
+	return shipmentsResponse
+}
+
+func convertToShipmentResponse(fulfilment Fulfilment) ShipmentResponse {
+	items := []ShipmentItemResponse{}
+
+	for _, item := range fulfilment.Items {
+		items = append(items, ShipmentItemResponse{
+			CartID:     item.ID,
+			ProductID:  item.ProductID,
+			Quantity:   item.Quantity,
+			TotalPrice: item.TotalPrice,
+		})
+	}
+
+	shipment := fulfilment.Shipment
+
+	return ShipmentResponse{
+		ID:           shipment.ID,
+		PaymentID:    shipment.PaymentID,
+		SellerID:     shipment.SellerID,
+		BuyerID:      shipment.BuyerID,
+		Status:       shipment.Status,
+		DeliveryID:   shipment.DeliveryID,
+		DeliveryName: shipment.DeliveryName,
+		Resi:         shipment.Resi,
+		Subtotal:     shipment.Subtotal,
+		ShippedAt:    shipment.ShippedAt,
+		DeliveredAt:  shipment.DeliveredAt,
+		Items:        items,
+	}
+}
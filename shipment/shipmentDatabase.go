@@ -0,0 +1,28 @@
+package shipment
+
+import "time"
+
+const (
+	StatusPending        = "pending"
+	StatusProcessing     = "processing"
+	StatusShipped        = "shipped"
+	StatusReadyForPickup = "ready_for_pickup"
+	StatusDelivered      = "delivered"
+	StatusCancelled      = "cancelled"
+)
+
+type Shipment struct {
+	ID           uint64     `gorm:"column:id;primaryKey;autoIncrement"`
+	PaymentID    uint64     `gorm:"column:payment_id;index"`
+	SellerID     int        `gorm:"column:seller_id;index"`
+	BuyerID      int        `gorm:"column:buyer_id;index"`
+	Status       string     `gorm:"column:status;type:varchar(255)"`
+	DeliveryID   int        `gorm:"column:delivery_id"`
+	DeliveryName string     `gorm:"column:delivery_name;type:varchar(255)"`
+	Resi         string     `gorm:"column:resi;type:varchar(255)"`
+	Subtotal     int        `gorm:"column:subtotal"`
+	ShippedAt    *time.Time `gorm:"column:shipped_at"`
+	DeliveredAt  *time.Time `gorm:"column:delivered_at"`
+	CreatedAt    time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt    time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
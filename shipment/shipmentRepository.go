@@ -0,0 +1,89 @@
+package shipment
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ShipmentRepository interface {
+	FindShipmentByID(ID int) (Shipment, error)
+	FindShipmentsByPayment(paymentID int) ([]Shipment, error)
+	FindShipmentsBySeller(sellerID int, status string) ([]Shipment, error)
+	FindShipmentsByStatus(status string) ([]Shipment, error)
+	CreateShipment(shipment Shipment) (Shipment, error)
+	UpdateShipment(shipment Shipment) (Shipment, error)
+	TransitionShipment(shipment Shipment, from string) (bool, error)
+	CancelShipmentsByPayment(paymentID uint64) error
+	DeliverShipmentsByPayment(paymentID uint64) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) FindShipmentByID(ID int) (Shipment, error) {
+	var shipment Shipment
+	err := r.db.First(&shipment, ID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Shipment{}, errors.New("Shipment not found")
+	}
+	return shipment, err
+}
+
+func (r *repository) FindShipmentsByPayment(paymentID int) ([]Shipment, error) {
+	var shipments []Shipment
+	err := r.db.Where("payment_id = ?", paymentID).Order("id").Find(&shipments).Error
+	return shipments, err
+}
+
+func (r *repository) FindShipmentsBySeller(sellerID int, status string) ([]Shipment, error) {
+	var shipments []Shipment
+	query := r.db.Where("seller_id = ?", sellerID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("id DESC").Find(&shipments).Error
+	return shipments, err
+}
+
+func (r *repository) FindShipmentsByStatus(status string) ([]Shipment, error) {
+	var shipments []Shipment
+	err := r.db.Where("status = ?", status).Order("id").Find(&shipments).Error
+	return shipments, err
+}
+
+func (r *repository) CreateShipment(shipment Shipment) (Shipment, error) {
+	err := r.db.Create(&shipment).Error
+	return shipment, err
+}
+
+func (r *repository) UpdateShipment(shipment Shipment) (Shipment, error) {
+	err := r.db.Omit("status").Save(&shipment).Error
+	return shipment, err
+}
+
+func (r *repository) TransitionShipment(shipment Shipment, from string) (bool, error) {
+	result := r.db.Model(&shipment).
+		Where("status = ?", from).
+		Select("status", "shipped_at", "delivered_at").
+		Updates(&shipment)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *repository) CancelShipmentsByPayment(paymentID uint64) error {
+	return r.db.Model(&Shipment{}).
+		Where("payment_id = ? AND status IN ?", paymentID, []string{StatusPending, StatusProcessing}).
+		Update("status", StatusCancelled).Error
+}
+
+func (r *repository) DeliverShipmentsByPayment(paymentID uint64) error {
+	return r.db.Model(&Shipment{}).
+		Where("payment_id = ? AND status IN ?", paymentID, []string{StatusProcessing, StatusShipped, StatusReadyForPickup}).
+		Updates(map[string]interface{}{"status": StatusDelivered, "delivered_at": time.Now()}).Error
+}
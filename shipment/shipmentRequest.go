@@ -0,0 +1,11 @@
+package shipment
+
+type ShipmentDeliveryRequest struct {
+	DeliveryID   int    `json:"delivery_id,omitempty"`
+	DeliveryName string `json:"delivery_name,omitempty"`
+	Resi         string `json:"resi,omitempty"`
+}
+
+type ShipmentStatusRequest struct {
+	Status string `json:"status" binding:"required"`
+}
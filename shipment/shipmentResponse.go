@@ -0,0 +1,28 @@
+package shipment
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type ShipmentItemResponse struct {
+	CartID     uint64      `json:"cart_id"`
+	ProductID  json.Number `json:"product_id"`
+	Quantity   json.Number `json:"quantity"`
+	TotalPrice json.Number `json:"total_price"`
+}
+
+type ShipmentResponse struct {
+	ID           uint64                 `json:"id"`
+	PaymentID    uint64                 `json:"payment_id"`
+	SellerID     int                    `json:"seller_id"`
+	BuyerID      int                    `json:"buyer_id"`
+	Status       string                 `json:"status"`
+	DeliveryID   int                    `json:"delivery_id"`
+	DeliveryName string                 `json:"delivery_name"`
+	Resi         string                 `json:"resi"`
+	Subtotal     int                    `json:"subtotal"`
+	ShippedAt    *time.Time             `json:"shipped_at"`
+	DeliveredAt  *time.Time             `json:"delivered_at"`
+	Items        []ShipmentItemResponse `json:"items"`
+}
@@ -0,0 +1,297 @@
+package shipment
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"taman-pempek/cart"
+	"taman-pempek/payment"
+	"taman-pempek/user"
+	"time"
+)
+
+type Fulfilment struct {
+	Shipment Shipment
+	Items    []cart.Cart
+}
+
+type ShipmentService interface {
+	FindShipmentByID(ID int) (Fulfilment, error)
+	FindShipmentsByPayment(paymentID int) ([]Fulfilment, error)
+	FindShipmentsBySeller(sellerID int, status string) ([]Fulfilment, error)
+	UpdateDelivery(ID int, request ShipmentDeliveryRequest) (Shipment, error)
+	UpdateStatus(ID int, status string, actor payment.Actor) (Shipment, error)
+}
+
+var transitions = map[string][]string{
+	StatusPending:        {StatusProcessing, StatusCancelled},
+	StatusProcessing:     {StatusShipped, StatusReadyForPickup, StatusCancelled},
+	StatusShipped:        {StatusDelivered},
+	StatusReadyForPickup: {StatusDelivered, StatusCancelled},
+}
+
+var stages = []string{StatusPending, StatusProcessing, StatusShipped, StatusDelivered}
+
+var paidStatuses = []string{
+	payment.StatusVerified,
+	payment.StatusProcessing,
+	payment.StatusShipped,
+	payment.StatusDelivered,
+}
+
+var cashStatuses = []string{
+	payment.StatusAwaitingCash,
+	payment.StatusProcessing,
+	payment.StatusShipped,
+	payment.StatusReadyForPickup,
+}
+
+type service struct {
+	shipmentRepository ShipmentRepository
+	cartRepository     cart.CartRepository
+	paymentService     payment.PaymentService
+}
+
+func NewService(shipmentRepository ShipmentRepository, cartRepository cart.CartRepository, paymentService payment.PaymentService) *service {
+	return &service{shipmentRepository, cartRepository, paymentService}
+}
+
+func (s *service) FindShipmentByID(ID int) (Fulfilment, error) {
+	shipment, err := s.shipmentRepository.FindShipmentByID(ID)
+
+	if err != nil {
+		return Fulfilment{}, err
+	}
+
+	return s.fulfilment(shipment)
+}
+
+func (s *service) FindShipmentsByPayment(paymentID int) ([]Fulfilment, error) {
+	shipments, err := s.shipmentRepository.FindShipmentsByPayment(paymentID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.fulfilments(shipments)
+}
+
+func (s *service) FindShipmentsBySeller(sellerID int, status string) ([]Fulfilment, error) {
+	shipments, err := s.shipmentRepository.FindShipmentsBySeller(sellerID, status)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.fulfilments(shipments)
+}
+
+func (s *service) UpdateDelivery(ID int, request ShipmentDeliveryRequest) (Shipment, error) {
+	shipment, err := s.shipmentRepository.FindShipmentByID(ID)
+
+	if err != nil {
+		return Shipment{}, err
+	}
+
+	if shipment.Status == StatusDelivered || shipment.Status == StatusCancelled {
+		return Shipment{}, fmt.Errorf("Cannot update delivery for shipment with status %s", shipment.Status)
+	}
+
+	if request.DeliveryID != 0 {
+		shipment.DeliveryID = request.DeliveryID
+	}
+	if request.DeliveryName != "" {
+		shipment.DeliveryName = request.DeliveryName
+	}
+	if request.Resi != "" {
+		shipment.Resi = request.Resi
+	}
+
+	return s.shipmentRepository.UpdateShipment(shipment)
+}
+
+func (s *service) UpdateStatus(ID int, status string, actor payment.Actor) (Shipment, error) {
+	shipment, err := s.shipmentRepository.FindShipmentByID(ID)
+
+	if err != nil {
+		return Shipment{}, err
+	}
+
+	if !canTransition(shipment.Status, status) {
+		return Shipment{}, fmt.Errorf("Cannot change shipment status from %s to %s", shipment.Status, status)
+	}
+
+	if status == StatusCancelled && actor.Role != payment.RoleSystem && !strings.EqualFold(actor.Role, user.RoleAdmin) {
+		return Shipment{}, errors.New("Only admins can cancel a shipment")
+	}
+
+	p, err := s.paymentService.FindPaymentByID(int(shipment.PaymentID))
+
+	if err != nil {
+		return Shipment{}, err
+	}
+
+	if status != StatusCancelled && !isFulfillable(p) {
+		return Shipment{}, errors.New("Payment for this shipment has not been verified")
+	}
+
+	if status == StatusShipped && p.PaymentMethod == payment.MethodPickup {
+		return Shipment{}, errors.New("Pickup orders are collected at the store and cannot be shipped")
+	}
+
+	if status == StatusReadyForPickup && p.PaymentMethod != payment.MethodPickup {
+		return Shipment{}, errors.New("Only pickup orders can be marked ready for pickup")
+	}
+
+	if status == StatusDelivered && payment.IsCashMethod(p.PaymentMethod) {
+		return Shipment{}, errors.New("Cash orders are delivered once the cash is collected")
+	}
+
+	if status == StatusShipped && shipment.Resi == "" {
+		return Shipment{}, errors.New("Tracking number is required before shipping")
+	}
+
+	from := shipment.Status
+	now := time.Now()
+	shipment.Status = status
+
+	if status == StatusShipped {
+		shipment.ShippedAt = &now
+	}
+	if status == StatusDelivered {
+		shipment.DeliveredAt = &now
+	}
+
+	transitioned, err := s.shipmentRepository.TransitionShipment(shipment, from)
+
+	if err != nil {
+		return Shipment{}, err
+	}
+
+	if !transitioned {
+		return Shipment{}, errors.New("Shipment status has been changed by another request")
+	}
+
+	return shipment, s.syncPayment(p)
+}
+
+func (s *service) syncPayment(p payment.Payment) error {
+	shipments, err := s.shipmentRepository.FindShipmentsByPayment(int(p.ID))
+
+	if err != nil {
+		return err
+	}
+
+	lowest, highest := len(stages), -1
+
+	for _, shipment := range shipments {
+		if shipment.Status == StatusCancelled {
+			continue
+		}
+
+		stage := stageOf(shipment.Status)
+
+		if stage < lowest {
+			lowest = stage
+		}
+		if stage > highest {
+			highest = stage
+		}
+	}
+
+	if highest < 0 {
+		return nil
+	}
+
+	targets := []string{}
+
+	if highest >= stageOf(StatusProcessing) {
+		targets = append(targets, payment.StatusProcessing)
+	}
+	if lowest >= stageOf(StatusShipped) && p.PaymentMethod == payment.MethodPickup {
+		targets = append(targets, payment.StatusReadyForPickup)
+	} else if lowest >= stageOf(StatusShipped) {
+		targets = append(targets, payment.StatusShipped)
+	}
+	if lowest >= stageOf(StatusDelivered) {
+		targets = append(targets, payment.StatusDelivered)
+	}
+
+	for _, target := range targets {
+		if !payment.CanActorTransition(payment.SystemActor, p.PaymentMethod, p.PaymentStatus, target) {
+			continue
+		}
+
+		next, err := s.paymentService.TransitionStatus(int(p.ID), target, payment.SystemActor, "Updated from seller shipments")
+
+		if err != nil {
+			if err.Error() == "Payment status has been changed by another request" {
+				return nil
+			}
+			return err
+		}
+
+		p = next
+	}
+
+	return nil
+}
+
+func (s *service) fulfilments(shipments []Shipment) ([]Fulfilment, error) {
+	fulfilments := []Fulfilment{}
+
+	for _, shipment := range shipments {
+		fulfilment, err := s.fulfilment(shipment)
+
+		if err != nil {
+			return nil, err
+		}
+
+		fulfilments = append(fulfilments, fulfilment)
+	}
+
+	return fulfilments, nil
+}
+
+func (s *service) fulfilment(shipment Shipment) (Fulfilment, error) {
+	items, err := s.cartRepository.FindCartsByShipmentID(shipment.ID)
+
+	if err != nil {
+		return Fulfilment{}, err
+	}
+
+	return Fulfilment{Shipment: shipment, Items: items}, nil
+}
+
+func canTransition(from string, to string) bool {
+	return contains(transitions[from], to)
+}
+
+func stageOf(status string) int {
+	if status == StatusReadyForPickup {
+		status = StatusShipped
+	}
+
+	for i, stage := range stages {
+		if stage == status {
+			return i
+		}
+	}
+	return -1
+}
+
+func isFulfillable(p payment.Payment) bool {
+	if payment.IsCashMethod(p.PaymentMethod) {
+		return contains(cashStatuses, p.PaymentStatus)
+	}
+	return contains(paidStatuses, p.PaymentStatus)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package shipping
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type mockService struct {
+	Service     string
+	Description string
+	PerKg       int
+	ETD         string
+}
+
+var mockCouriers = map[string][]mockService{
+	"jne": {
+		{Service: "OKE", Description: "Ongkos Kirim Ekonomis", PerKg: 8000, ETD: "3-4"},
+		{Service: "REG", Description: "Layanan Reguler", PerKg: 10000, ETD: "2-3"},
+		{Service: "YES", Description: "Yakin Esok Sampai", PerKg: 18000, ETD: "1-1"},
+	},
+	"pos": {
+		{Service: "Pos Reguler", Description: "Pos Reguler", PerKg: 9000, ETD: "3 HARI"},
+	},
+	"tiki": {
+		{Service: "ECO", Description: "Economy Service", PerKg: 7500, ETD: "4"},
+		{Service: "REG", Description: "Regular Service", PerKg: 9500, ETD: "2"},
+	},
+}
+
+type MockRajaOngkirServer struct {
+	apiKey string
+}
+
+func NewMockRajaOngkirServer(apiKey string) *MockRajaOngkirServer {
+	return &MockRajaOngkirServer{apiKey}
+}
+
+func (s *MockRajaOngkirServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != "/cost" {
+		writeMockStatus(w, http.StatusNotFound, "Invalid path")
+		return
+	}
+
+	if r.Header.Get("key") != s.apiKey {
+		writeMockStatus(w, http.StatusBadRequest, "Invalid key. API key tidak ditemukan di database RajaOngkir.")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		writeMockStatus(w, http.StatusBadRequest, "Invalid body")
+		return
+	}
+
+	origin := r.PostForm.Get("origin")
+	destination := r.PostForm.Get("destination")
+	weight, err := strconv.Atoi(r.PostForm.Get("weight"))
+
+	if origin == "" || destination == "" || err != nil || weight <= 0 {
+		writeMockStatus(w, http.StatusBadRequest, "Bad request. Origin, destination and weight are required.")
+		return
+	}
+
+	kilograms := (weight + 999) / 1000
+	results := []map[string]any{}
+
+	for _, code := range strings.Split(r.PostForm.Get("courier"), ":") {
+		services, ok := mockCouriers[strings.ToLower(code)]
+
+		if !ok {
+			writeMockStatus(w, http.StatusBadRequest, "Bad request. Kurir "+code+" tidak tersedia.")
+			return
+		}
+
+		costs := []map[string]any{}
+
+		for _, service := range services {
+			costs = append(costs, map[string]any{
+				"service":     service.Service,
+				"description": service.Description,
+				"cost": []map[string]any{
+					{"value": service.PerKg * kilograms, "etd": service.ETD, "note": ""},
+				},
+			})
+		}
+
+		results = append(results, map[string]any{
+			"code":  strings.ToLower(code),
+			"name":  strings.ToUpper(code),
+			"costs": costs,
+		})
+	}
+
+	writeMockJSON(w, http.StatusOK, map[string]any{
+		"rajaongkir": map[string]any{
+			"query":               map[string]any{"origin": origin, "destination": destination, "weight": weight},
+			"status":              map[string]any{"code": http.StatusOK, "description": "OK"},
+			"origin_details":      map[string]any{"city_id": origin},
+			"destination_details": map[string]any{"city_id": destination},
+			"results":             results,
+		},
+	})
+}
+
+func writeMockStatus(w http.ResponseWriter, status int, description string) {
+	writeMockJSON(w, status, map[string]any{
+		"rajaongkir": map[string]any{
+			"status": map[string]any{"code": status, "description": description},
+		},
+	})
+}
+
+func writeMockJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,109 @@
+package shipping
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type rajaOngkirProvider struct {
+	baseURL  string
+	apiKey   string
+	origin   string
+	couriers []string
+	client   *http.Client
+}
+
+func NewRajaOngkirProvider(baseURL string, apiKey string, origin string, couriers []string) *rajaOngkirProvider {
+	return &rajaOngkirProvider{strings.TrimRight(baseURL, "/"), apiKey, origin, couriers, &http.Client{Timeout: 15 * time.Second}}
+}
+
+type rajaOngkirResponse struct {
+	RajaOngkir struct {
+		Status struct {
+			Code        int    `json:"code"`
+			Description string `json:"description"`
+		} `json:"status"`
+		Results []struct {
+			Code  string `json:"code"`
+			Name  string `json:"name"`
+			Costs []struct {
+				Service     string `json:"service"`
+				Description string `json:"description"`
+				Cost        []struct {
+					Value int    `json:"value"`
+					ETD   string `json:"etd"`
+					Note  string `json:"note"`
+				} `json:"cost"`
+			} `json:"costs"`
+		} `json:"results"`
+	} `json:"rajaongkir"`
+}
+
+func (p *rajaOngkirProvider) Name() string {
+	return "rajaongkir"
+}
+
+func (p *rajaOngkirProvider) Rates(request RateRequest) ([]Rate, error) {
+	if request.Destination == "" {
+		return nil, errors.New("Destination is required")
+	}
+
+	form := url.Values{}
+	form.Set("origin", p.origin)
+	form.Set("destination", request.Destination)
+	form.Set("weight", strconv.Itoa(request.Weight))
+	form.Set("courier", strings.Join(p.couriers, ":"))
+
+	httpRequest, err := http.NewRequest(http.MethodPost, p.baseURL+"/cost", strings.NewReader(form.Encode()))
+
+	if err != nil {
+		return nil, err
+	}
+
+	httpRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpRequest.Header.Set("key", p.apiKey)
+
+	response, err := p.client.Do(httpRequest)
+
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var result rajaOngkirResponse
+
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("Invalid response from rajaongkir: %s", err.Error())
+	}
+
+	if result.RajaOngkir.Status.Code != http.StatusOK {
+		return nil, fmt.Errorf("Rajaongkir error: %s", result.RajaOngkir.Status.Description)
+	}
+
+	rates := []Rate{}
+
+	for _, courier := range result.RajaOngkir.Results {
+		for _, cost := range courier.Costs {
+			if len(cost.Cost) == 0 {
+				continue
+			}
+
+			rates = append(rates, Rate{
+				Provider:    p.Name(),
+				Courier:     courier.Code,
+				Service:     cost.Service,
+				Description: cost.Description,
+				Fee:         cost.Cost[0].Value,
+				ETD:         cost.Cost[0].ETD,
+			})
+		}
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,93 @@
+package shipping
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const testAPIKey = "rajaongkir-test-key"
+
+func newTestProvider(t *testing.T, apiKey string, couriers []string) *rajaOngkirProvider {
+	server := httptest.NewServer(NewMockRajaOngkirServer(testAPIKey))
+	t.Cleanup(server.Close)
+
+	return NewRajaOngkirProvider(server.URL+"/", apiKey, "151", couriers)
+}
+
+func TestRajaOngkirRates(t *testing.T) {
+	provider := newTestProvider(t, testAPIKey, []string{"jne", "tiki"})
+
+	rates, err := provider.Rates(RateRequest{Destination: "327", Weight: 1500})
+	if err != nil {
+		t.Fatalf("Rates: %v", err)
+	}
+
+	if len(rates) != 5 {
+		t.Fatalf("got %d rates, want 5", len(rates))
+	}
+
+	fees := map[string]int{}
+	for _, rate := range rates {
+		if rate.Provider != "rajaongkir" {
+			t.Errorf("provider = %s", rate.Provider)
+		}
+		fees[rate.Courier+":"+rate.Service] = rate.Fee
+	}
+
+	if fees["jne:REG"] != 20000 || fees["tiki:ECO"] != 15000 {
+		t.Errorf("fees = %v", fees)
+	}
+}
+
+func TestRajaOngkirRejectsInvalidKey(t *testing.T) {
+	provider := newTestProvider(t, "wrong-key", []string{"jne"})
+
+	if _, err := provider.Rates(RateRequest{Destination: "327", Weight: 1000}); err == nil {
+		t.Fatal("request with an invalid key succeeded")
+	}
+}
+
+func TestRajaOngkirRejectsUnknownCourier(t *testing.T) {
+	provider := newTestProvider(t, testAPIKey, []string{"jne", "sicepat"})
+
+	if _, err := provider.Rates(RateRequest{Destination: "327", Weight: 1000}); err == nil {
+		t.Fatal("request with an unknown courier succeeded")
+	}
+}
+
+func TestRajaOngkirRequiresDestination(t *testing.T) {
+	provider := newTestProvider(t, testAPIKey, []string{"jne"})
+
+	if _, err := provider.Rates(RateRequest{Weight: 1000}); err == nil {
+		t.Fatal("request without a destination succeeded")
+	}
+}
+
+func TestQuoteRoundTrip(t *testing.T) {
+	quote := Quote{UserID: 7, Destination: "327", Weight: 1500, Provider: "rajaongkir", Courier: "jne", Service: "REG", Fee: 20000, ExpiresAt: time.Now().Add(quoteLifetime).Unix()}
+
+	verified, err := VerifyQuote(SignQuote(quote))
+	if err != nil {
+		t.Fatalf("VerifyQuote: %v", err)
+	}
+
+	if verified != quote {
+		t.Errorf("verified = %+v, want %+v", verified, quote)
+	}
+}
+
+func TestVerifyQuoteRejectsTamperedAndExpired(t *testing.T) {
+	token := SignQuote(Quote{UserID: 7, Destination: "327", Fee: 20000, ExpiresAt: time.Now().Add(quoteLifetime).Unix()})
+	tampered := SignQuote(Quote{UserID: 7, Destination: "327", Fee: 1, ExpiresAt: time.Now().Add(quoteLifetime).Unix()})
+
+	if _, err := VerifyQuote(tampered[:len(tampered)-64] + token[len(token)-64:]); err == nil {
+		t.Error("quote with a swapped signature was accepted")
+	}
+
+	expired := SignQuote(Quote{UserID: 7, Destination: "327", Fee: 20000, ExpiresAt: time.Now().Add(-time.Minute).Unix()})
+
+	if _, err := VerifyQuote(expired); err == nil {
+		t.Error("expired quote was accepted")
+	}
+}
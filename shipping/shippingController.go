@@ -0,0 +1,455 @@
+package shipping
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type controller struct {
+	shippingService ShippingService
+}
+
+func NewController(shippingService ShippingService) *controller {
+	return &controller{shippingService}
+}
+
+func (cn *controller) GetZones(c *gin.Context) {
+	zones, err := cn.shippingService.FindZones()
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	zonesResponse := []ZoneResponse{}
+
+	for _, zone := range zones {
+		zonesResponse = append(zonesResponse, convertToZoneResponse(zone))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  zonesResponse,
+	})
+}
+
+func (cn *controller) GetZone(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid zone ID",
+		})
+		return
+	}
+
+	zone, err := cn.shippingService.FindZoneByID(id)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToZoneResponse(zone),
+	})
+}
+
+func (cn *controller) CreateZone(c *gin.Context) {
+	var zoneRequest ZoneCreateRequest
+
+	err := c.ShouldBindJSON(&zoneRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+				errorMessages = append(errorMessages, errorMessage)
+			}
+		} else {
+			errorMessages = append(errorMessages, "Invalid request body")
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	zone, err := cn.shippingService.CreateZone(zoneRequest)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToZoneResponse(zone),
+	})
+}
+
+func (cn *controller) UpdateZone(c *gin.Context) {
+	var zoneRequest ZoneUpdateRequest
+
+	err := c.ShouldBindJSON(&zoneRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+				errorMessages = append(errorMessages, errorMessage)
+			}
+		} else {
+			errorMessages = append(errorMessages, "Invalid request body")
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid zone ID",
+		})
+		return
+	}
+
+	zone, err := cn.shippingService.UpdateZone(id, zoneRequest)
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToZoneResponse(zone),
+	})
+}
+
+func (cn *controller) DeleteZone(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid zone ID",
+		})
+		return
+	}
+
+	zone, err := cn.shippingService.DeleteZone(id)
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToZoneResponse(zone),
+	})
+}
+
+func (cn *controller) CreateTier(c *gin.Context) {
+	var tierRequest TierRequest
+
+	err := c.ShouldBindJSON(&tierRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+				errorMessages = append(errorMessages, errorMessage)
+			}
+		} else {
+			errorMessages = append(errorMessages, "Invalid request body")
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid zone ID",
+		})
+		return
+	}
+
+	tier, err := cn.shippingService.CreateTier(id, tierRequest)
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToTierResponse(tier),
+	})
+}
+
+func (cn *controller) UpdateTier(c *gin.Context) {
+	var tierRequest TierRequest
+
+	err := c.ShouldBindJSON(&tierRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+				errorMessages = append(errorMessages, errorMessage)
+			}
+		} else {
+			errorMessages = append(errorMessages, "Invalid request body")
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid tier ID",
+		})
+		return
+	}
+
+	tier, err := cn.shippingService.UpdateTier(id, tierRequest)
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToTierResponse(tier),
+	})
+}
+
+func (cn *controller) DeleteTier(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid tier ID",
+		})
+		return
+	}
+
+	tier, err := cn.shippingService.DeleteTier(id)
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToTierResponse(tier),
+	})
+}
+
+func (cn *controller) Quote(c *gin.Context) {
+	var quoteRequest QuoteRequest
+
+	err := c.ShouldBindJSON(&quoteRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+				errorMessages = append(errorMessages, errorMessage)
+			}
+		} else {
+			errorMessages = append(errorMessages, "Invalid request body")
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	quotation, err := cn.shippingService.Quote(int(c.GetUint64("UserID")), quoteRequest)
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		if strings.HasPrefix(err.Error(), "No shipping rates") {
+			statusCode = http.StatusBadGateway
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	optionsResponse := []OptionResponse{}
+
+	for _, option := range quotation.Options {
+		optionsResponse = append(optionsResponse, OptionResponse{
+			Provider:    option.Rate.Provider,
+			Courier:     option.Rate.Courier,
+			Service:     option.Rate.Service,
+			Description: option.Rate.Description,
+			Fee:         option.Rate.Fee,
+			ETD:         option.Rate.ETD,
+			Token:       option.Token,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data": QuoteResponse{
+			Destination: quotation.Destination,
+			Weight:      quotation.Weight,
+			Options:     optionsResponse,
+			Errors:      quotation.Errors,
+		},
+	})
+}
+
+func convertToTierResponse(tier Tier) TierResponse {
+	return TierResponse{
+		ID:        tier.ID,
+		ZoneID:    tier.ZoneID,
+		MinWeight: tier.MinWeight,
+		MaxWeight: tier.MaxWeight,
+		Fee:       tier.Fee,
+	}
+}
+
+func convertToZoneResponse(zone Zone) ZoneResponse {
+	tiersResponse := []TierResponse{}
+
+	for _, tier := range zone.Tiers {
+		tiersResponse = append(tiersResponse, convertToTierResponse(tier))
+	}
+
+	return ZoneResponse{
+		ID:           zone.ID,
+		Name:         zone.Name,
+		Service:      zone.Service,
+		Destinations: zone.Destinations,
+		ETD:          zone.ETD,
+		Tiers:        tiersResponse,
+	}
+}
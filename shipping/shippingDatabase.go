@@ -0,0 +1,32 @@
+package shipping
+
+import "time"
+
+type Zone struct {
+	ID           uint64    `gorm:"column:id;primaryKey;autoIncrement"`
+	Name         string    `gorm:"column:name;type:varchar(255)"`
+	Service      string    `gorm:"column:service;type:varchar(255)"`
+	Destinations string    `gorm:"column:destinations;type:text"`
+	ETD          string    `gorm:"column:etd;type:varchar(255)"`
+	Tiers        []Tier    `gorm:"foreignKey:ZoneID"`
+	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt    time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (Zone) TableName() string {
+	return "shipping_zones"
+}
+
+type Tier struct {
+	ID        uint64    `gorm:"column:id;primaryKey;autoIncrement"`
+	ZoneID    uint64    `gorm:"column:zone_id;index"`
+	MinWeight int       `gorm:"column:min_weight"`
+	MaxWeight int       `gorm:"column:max_weight"`
+	Fee       int       `gorm:"column:fee"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (Tier) TableName() string {
+	return "shipping_tiers"
+}
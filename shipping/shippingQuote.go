@@ -0,0 +1,64 @@
+package shipping
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"time"
+)
+
+const quoteLifetime = 30 * time.Minute
+
+type Quote struct {
+	UserID      int    `json:"user_id"`
+	Destination string `json:"destination"`
+	Weight      int    `json:"weight"`
+	Provider    string `json:"provider"`
+	Courier     string `json:"courier"`
+	Service     string `json:"service"`
+	Fee         int    `json:"fee"`
+	ExpiresAt   int64  `json:"expires_at"`
+}
+
+func SignQuote(quote Quote) string {
+	payload, _ := json.Marshal(quote)
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + quoteSignature(encoded)
+}
+
+func VerifyQuote(token string) (Quote, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+
+	if !ok || !hmac.Equal([]byte(signature), []byte(quoteSignature(encoded))) {
+		return Quote{}, errors.New("Invalid shipping quote")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+
+	if err != nil {
+		return Quote{}, errors.New("Invalid shipping quote")
+	}
+
+	var quote Quote
+
+	if err := json.Unmarshal(payload, &quote); err != nil {
+		return Quote{}, errors.New("Invalid shipping quote")
+	}
+
+	if time.Now().Unix() > quote.ExpiresAt {
+		return Quote{}, errors.New("Shipping quote has expired, please request a new quote")
+	}
+
+	return quote, nil
+}
+
+func quoteSignature(encoded string) string {
+	mac := hmac.New(sha256.New, []byte(os.Getenv("SECRET")))
+	mac.Write([]byte("shipping:" + encoded))
+	return hex.EncodeToString(mac.Sum(nil))
+}
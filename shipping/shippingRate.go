@@ -0,0 +1,20 @@
+package shipping
+
+type RateRequest struct {
+	Destination string
+	Weight      int
+}
+
+type Rate struct {
+	Provider    string
+	Courier     string
+	Service     string
+	Description string
+	Fee         int
+	ETD         string
+}
+
+type RateProvider interface {
+	Name() string
+	Rates(request RateRequest) ([]Rate, error)
+}
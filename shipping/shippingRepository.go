@@ -0,0 +1,90 @@
+package shipping
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type ShippingRepository interface {
+	FindZones() ([]Zone, error)
+	FindZoneByID(ID int) (Zone, error)
+	CreateZone(zone Zone) (Zone, error)
+	UpdateZone(zone Zone) (Zone, error)
+	DeleteZone(zone Zone) (Zone, error)
+	FindTierByID(ID int) (Tier, error)
+	CreateTier(tier Tier) (Tier, error)
+	UpdateTier(tier Tier) (Tier, error)
+	DeleteTier(tier Tier) (Tier, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) FindZones() ([]Zone, error) {
+	var zones []Zone
+	err := r.db.Preload("Tiers", func(db *gorm.DB) *gorm.DB {
+		return db.Order("min_weight")
+	}).Order("id").Find(&zones).Error
+	return zones, err
+}
+
+func (r *repository) FindZoneByID(ID int) (Zone, error) {
+	var zone Zone
+	err := r.db.Preload("Tiers", func(db *gorm.DB) *gorm.DB {
+		return db.Order("min_weight")
+	}).First(&zone, ID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Zone{}, errors.New("Shipping zone not found")
+	}
+	return zone, err
+}
+
+func (r *repository) CreateZone(zone Zone) (Zone, error) {
+	err := r.db.Create(&zone).Error
+	return zone, err
+}
+
+func (r *repository) UpdateZone(zone Zone) (Zone, error) {
+	err := r.db.Omit("Tiers").Save(&zone).Error
+	return zone, err
+}
+
+func (r *repository) DeleteZone(zone Zone) (Zone, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("zone_id = ?", zone.ID).Delete(&Tier{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&zone).Error
+	})
+	return zone, err
+}
+
+func (r *repository) FindTierByID(ID int) (Tier, error) {
+	var tier Tier
+	err := r.db.First(&tier, ID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Tier{}, errors.New("Shipping tier not found")
+	}
+	return tier, err
+}
+
+func (r *repository) CreateTier(tier Tier) (Tier, error) {
+	err := r.db.Create(&tier).Error
+	return tier, err
+}
+
+func (r *repository) UpdateTier(tier Tier) (Tier, error) {
+	err := r.db.Save(&tier).Error
+	return tier, err
+}
+
+func (r *repository) DeleteTier(tier Tier) (Tier, error) {
+	err := r.db.Delete(&tier).Error
+	return tier, err
+}
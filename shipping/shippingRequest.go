@@ -0,0 +1,26 @@
+package shipping
+
+type ZoneCreateRequest struct {
+	Name         string `json:"name" binding:"required"`
+	Service      string `json:"service" binding:"required"`
+	Destinations string `json:"destinations" binding:"required"`
+	ETD          string `json:"etd"`
+}
+
+type ZoneUpdateRequest struct {
+	Name         string `json:"name,omitempty"`
+	Service      string `json:"service,omitempty"`
+	Destinations string `json:"destinations,omitempty"`
+	ETD          string `json:"etd,omitempty"`
+}
+
+type TierRequest struct {
+	MinWeight int `json:"min_weight" binding:"min=0"`
+	MaxWeight int `json:"max_weight" binding:"min=0"`
+	Fee       int `json:"fee" binding:"required,min=1"`
+}
+
+type QuoteRequest struct {
+	AddressID   int    `json:"address_id"`
+	Destination string `json:"destination" binding:"required_without=AddressID"`
+}
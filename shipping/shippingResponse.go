@@ -0,0 +1,35 @@
+package shipping
+
+type TierResponse struct {
+	ID        uint64 `json:"id"`
+	ZoneID    uint64 `json:"zone_id"`
+	MinWeight int    `json:"min_weight"`
+	MaxWeight int    `json:"max_weight"`
+	Fee       int    `json:"fee"`
+}
+
+type ZoneResponse struct {
+	ID           uint64         `json:"id"`
+	Name         string         `json:"name"`
+	Service      string         `json:"service"`
+	Destinations string         `json:"destinations"`
+	ETD          string         `json:"etd"`
+	Tiers        []TierResponse `json:"tiers"`
+}
+
+type OptionResponse struct {
+	Provider    string `json:"provider"`
+	Courier     string `json:"courier"`
+	Service     string `json:"service"`
+	Description string `json:"description"`
+	Fee         int    `json:"fee"`
+	ETD         string `json:"etd"`
+	Token       string `json:"token"`
+}
+
+type QuoteResponse struct {
+	Destination string           `json:"destination"`
+	Weight      int              `json:"weight"`
+	Options     []OptionResponse `json:"options"`
+	Errors      []string         `json:"errors"`
+}
@@ -0,0 +1,256 @@
+package shipping
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"taman-pempek/address"
+	"taman-pempek/cart"
+	"taman-pempek/product"
+	"time"
+)
+
+type Option struct {
+	Rate  Rate
+	Token string
+}
+
+type Quotation struct {
+	Destination string
+	Weight      int
+	Options     []Option
+	Errors      []string
+}
+
+type ShippingService interface {
+	FindZones() ([]Zone, error)
+	FindZoneByID(ID int) (Zone, error)
+	CreateZone(request ZoneCreateRequest) (Zone, error)
+	UpdateZone(ID int, request ZoneUpdateRequest) (Zone, error)
+	DeleteZone(ID int) (Zone, error)
+	CreateTier(zoneID int, request TierRequest) (Tier, error)
+	UpdateTier(ID int, request TierRequest) (Tier, error)
+	DeleteTier(ID int) (Tier, error)
+	Quote(userID int, request QuoteRequest) (Quotation, error)
+}
+
+type service struct {
+	shippingRepository ShippingRepository
+	addressService     address.AddressService
+	cartService        cart.CartService
+	productService     product.ProductService
+	providers          []RateProvider
+}
+
+func NewService(shippingRepository ShippingRepository, addressService address.AddressService, cartService cart.CartService, productService product.ProductService, providers ...RateProvider) *service {
+	return &service{shippingRepository, addressService, cartService, productService, providers}
+}
+
+func (s *service) FindZones() ([]Zone, error) {
+	return s.shippingRepository.FindZones()
+}
+
+func (s *service) FindZoneByID(ID int) (Zone, error) {
+	return s.shippingRepository.FindZoneByID(ID)
+}
+
+func (s *service) CreateZone(request ZoneCreateRequest) (Zone, error) {
+	zone := Zone{
+		Name:         request.Name,
+		Service:      request.Service,
+		Destinations: request.Destinations,
+		ETD:          request.ETD,
+	}
+
+	return s.shippingRepository.CreateZone(zone)
+}
+
+func (s *service) UpdateZone(ID int, request ZoneUpdateRequest) (Zone, error) {
+	zone, err := s.shippingRepository.FindZoneByID(ID)
+
+	if err != nil {
+		return Zone{}, err
+	}
+
+	if request.Name != "" {
+		zone.Name = request.Name
+	}
+	if request.Service != "" {
+		zone.Service = request.Service
+	}
+	if request.Destinations != "" {
+		zone.Destinations = request.Destinations
+	}
+	if request.ETD != "" {
+		zone.ETD = request.ETD
+	}
+
+	return s.shippingRepository.UpdateZone(zone)
+}
+
+func (s *service) DeleteZone(ID int) (Zone, error) {
+	zone, err := s.shippingRepository.FindZoneByID(ID)
+
+	if err != nil {
+		return Zone{}, err
+	}
+
+	return s.shippingRepository.DeleteZone(zone)
+}
+
+func (s *service) CreateTier(zoneID int, request TierRequest) (Tier, error) {
+	zone, err := s.shippingRepository.FindZoneByID(zoneID)
+
+	if err != nil {
+		return Tier{}, err
+	}
+
+	tier := Tier{
+		ZoneID:    zone.ID,
+		MinWeight: request.MinWeight,
+		MaxWeight: request.MaxWeight,
+		Fee:       request.Fee,
+	}
+
+	if err := checkTier(zone, tier); err != nil {
+		return Tier{}, err
+	}
+
+	return s.shippingRepository.CreateTier(tier)
+}
+
+func (s *service) UpdateTier(ID int, request TierRequest) (Tier, error) {
+	tier, err := s.shippingRepository.FindTierByID(ID)
+
+	if err != nil {
+		return Tier{}, err
+	}
+
+	zone, err := s.shippingRepository.FindZoneByID(int(tier.ZoneID))
+
+	if err != nil {
+		return Tier{}, err
+	}
+
+	tier.MinWeight = request.MinWeight
+	tier.MaxWeight = request.MaxWeight
+	tier.Fee = request.Fee
+
+	if err := checkTier(zone, tier); err != nil {
+		return Tier{}, err
+	}
+
+	return s.shippingRepository.UpdateTier(tier)
+}
+
+func (s *service) DeleteTier(ID int) (Tier, error) {
+	tier, err := s.shippingRepository.FindTierByID(ID)
+
+	if err != nil {
+		return Tier{}, err
+	}
+
+	return s.shippingRepository.DeleteTier(tier)
+}
+
+func (s *service) Quote(userID int, request QuoteRequest) (Quotation, error) {
+	destination := request.Destination
+
+	if request.AddressID != 0 {
+		addr, err := s.addressService.FindAddressByID(request.AddressID)
+
+		if err != nil {
+			return Quotation{}, err
+		}
+
+		if addr.UserID != userID {
+			return Quotation{}, errors.New("Address not found")
+		}
+
+		destination = addr.Destination()
+	}
+
+	carts, err := s.cartService.FindStatusCardByUser(userID, cart.Active)
+
+	if err != nil {
+		return Quotation{}, err
+	}
+
+	if len(carts) == 0 {
+		return Quotation{}, errors.New("Cart is empty")
+	}
+
+	weight := 0
+
+	for _, c := range carts {
+		productID, _ := strconv.Atoi(c.ProductID.String())
+		quantity, _ := strconv.Atoi(c.Quantity.String())
+
+		pricing, err := s.productService.PriceItem(productID, c.VariantID, c.OptionIDList())
+
+		if err != nil {
+			return Quotation{}, err
+		}
+
+		weight += pricing.Weight * quantity
+	}
+
+	quotation := Quotation{Destination: destination, Weight: weight, Options: []Option{}, Errors: []string{}}
+	rateRequest := RateRequest{Destination: destination, Weight: weight}
+	expiresAt := time.Now().Add(quoteLifetime).Unix()
+
+	for _, provider := range s.providers {
+		rates, err := provider.Rates(rateRequest)
+
+		if err != nil {
+			quotation.Errors = append(quotation.Errors, fmt.Sprintf("%s: %s", provider.Name(), err.Error()))
+			continue
+		}
+
+		for _, rate := range rates {
+			quotation.Options = append(quotation.Options, Option{
+				Rate: rate,
+				Token: SignQuote(Quote{
+					UserID:      userID,
+					Destination: destination,
+					Weight:      weight,
+					Provider:    rate.Provider,
+					Courier:     rate.Courier,
+					Service:     rate.Service,
+					Fee:         rate.Fee,
+					ExpiresAt:   expiresAt,
+				}),
+			})
+		}
+	}
+
+	if len(quotation.Options) == 0 && len(quotation.Errors) > 0 {
+		return quotation, errors.New("No shipping rates are available right now")
+	}
+
+	return quotation, nil
+}
+
+func checkTier(zone Zone, tier Tier) error {
+	if tier.MaxWeight > 0 && tier.MaxWeight < tier.MinWeight {
+		return errors.New("Max weight must be greater than min weight")
+	}
+
+	for _, other := range zone.Tiers {
+		if other.ID == tier.ID {
+			continue
+		}
+
+		if overlaps(other, tier) {
+			return fmt.Errorf("Weight tier overlaps tier %d", other.ID)
+		}
+	}
+
+	return nil
+}
+
+func overlaps(a Tier, b Tier) bool {
+	aEnds := a.MaxWeight == 0 || b.MinWeight <= a.MaxWeight
+	bEnds := b.MaxWeight == 0 || a.MinWeight <= b.MaxWeight
+	return aEnds && bEnds
+}
@@ -0,0 +1,60 @@
+package shipping
+
+import "strings"
+
+type tableProvider struct {
+	shippingRepository ShippingRepository
+}
+
+func NewTableProvider(shippingRepository ShippingRepository) *tableProvider {
+	return &tableProvider{shippingRepository}
+}
+
+func (p *tableProvider) Name() string {
+	return "local"
+}
+
+func (p *tableProvider) Rates(request RateRequest) ([]Rate, error) {
+	zones, err := p.shippingRepository.FindZones()
+
+	if err != nil {
+		return nil, err
+	}
+
+	rates := []Rate{}
+
+	for _, zone := range zones {
+		if !covers(zone, request.Destination) {
+			continue
+		}
+
+		for _, tier := range zone.Tiers {
+			if request.Weight < tier.MinWeight || (tier.MaxWeight > 0 && request.Weight > tier.MaxWeight) {
+				continue
+			}
+
+			rates = append(rates, Rate{
+				Provider:    p.Name(),
+				Courier:     "local",
+				Service:     zone.Service,
+				Description: zone.Name,
+				Fee:         tier.Fee,
+				ETD:         zone.ETD,
+			})
+			break
+		}
+	}
+
+	return rates, nil
+}
+
+func covers(zone Zone, destination string) bool {
+	destination = strings.TrimSpace(destination)
+
+	for _, value := range strings.Split(zone.Destinations, ",") {
+		if strings.EqualFold(strings.TrimSpace(value), destination) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,328 @@
+package slot
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type controller struct {
+	slotService SlotService
+}
+
+func NewController(slotService SlotService) *controller {
+	return &controller{slotService}
+}
+
+func (cn *controller) GetSlots(c *gin.Context) {
+	slots, err := cn.slotService.FindSlots()
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	slotsResponse := []SlotResponse{}
+
+	for _, slot := range slots {
+		slotsResponse = append(slotsResponse, convertToSlotResponse(slot))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  slotsResponse,
+	})
+}
+
+func (cn *controller) GetSlot(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid slot ID",
+		})
+		return
+	}
+
+	slot, err := cn.slotService.FindSlotByID(id)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToSlotResponse(slot),
+	})
+}
+
+func (cn *controller) CreateSlot(c *gin.Context) {
+	var slotRequest SlotRequest
+
+	err := c.ShouldBindJSON(&slotRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+				errorMessages = append(errorMessages, errorMessage)
+			}
+		} else {
+			errorMessages = append(errorMessages, "Invalid request body")
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	slot, err := cn.slotService.CreateSlot(slotRequest)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToSlotResponse(slot),
+	})
+}
+
+func (cn *controller) UpdateSlot(c *gin.Context) {
+	var slotRequest SlotRequest
+
+	err := c.ShouldBindJSON(&slotRequest)
+
+	if err != nil {
+		errorMessages := []string{}
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+				errorMessages = append(errorMessages, errorMessage)
+			}
+		} else {
+			errorMessages = append(errorMessages, "Invalid request body")
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid slot ID",
+		})
+		return
+	}
+
+	slot, err := cn.slotService.UpdateSlot(id, slotRequest)
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToSlotResponse(slot),
+	})
+}
+
+func (cn *controller) DeleteSlot(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid slot ID",
+		})
+		return
+	}
+
+	slot, err := cn.slotService.DeleteSlot(id)
+
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if strings.HasSuffix(err.Error(), "not found") {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToSlotResponse(slot),
+	})
+}
+
+func (cn *controller) GetAvailableSlots(c *gin.Context) {
+	availability, err := cn.slotService.FindAvailability(c.DefaultQuery("date", time.Now().Format(dateLayout)), c.Query("kind"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	availabilityResponse := []AvailabilityResponse{}
+
+	for _, a := range availability {
+		availabilityResponse = append(availabilityResponse, AvailabilityResponse{
+			SlotID:    a.Slot.ID,
+			Name:      a.Slot.Name,
+			Kind:      a.Slot.Kind,
+			Date:      a.Date,
+			StartTime: a.Slot.StartTime,
+			EndTime:   a.Slot.EndTime,
+			Capacity:  a.Slot.Capacity,
+			Booked:    a.Booked,
+			Remaining: a.Remaining,
+			Available: a.Available,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  availabilityResponse,
+	})
+}
+
+func (cn *controller) GetSchedule(c *gin.Context) {
+	schedules, err := cn.slotService.FindSchedule(c.DefaultQuery("date", time.Now().Format(dateLayout)))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	schedulesResponse := []ScheduleResponse{}
+
+	for _, schedule := range schedules {
+		schedulesResponse = append(schedulesResponse, convertToScheduleResponse(schedule))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  schedulesResponse,
+	})
+}
+
+func convertToSlotResponse(slot Slot) SlotResponse {
+	days := []string{}
+
+	if slot.Days != "" {
+		days = strings.Split(slot.Days, ",")
+	}
+
+	return SlotResponse{
+		ID:        slot.ID,
+		Name:      slot.Name,
+		Kind:      slot.Kind,
+		StartTime: slot.StartTime,
+		EndTime:   slot.EndTime,
+		Days:      days,
+		Capacity:  slot.Capacity,
+		Active:    slot.Active,
+	}
+}
+
+func convertToScheduleResponse(schedule Schedule) ScheduleResponse {
+	ordersResponse := []ScheduleOrderResponse{}
+
+	for _, order := range schedule.Orders {
+		ordersResponse = append(ordersResponse, ScheduleOrderResponse{
+			PaymentID:     order.ID,
+			UserID:        order.UserID,
+			PaymentStatus: order.PaymentStatus,
+			PaymentMethod: order.PaymentMethod,
+			Recipient:     order.Recipient,
+			Address:       order.Address,
+			Whatsapp:      order.Whatsapp,
+			DeliveryName:  order.DeliveryName,
+			TotalPrice:    order.TotalPrice,
+		})
+	}
+
+	itemsResponse := []ScheduleItemResponse{}
+
+	for _, item := range schedule.Items {
+		itemsResponse = append(itemsResponse, ScheduleItemResponse{
+			ProductID: item.ProductID,
+			Name:      item.Name,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	return ScheduleResponse{
+		Slot:   convertToSlotResponse(schedule.Slot),
+		Date:   schedule.Date,
+		Orders: ordersResponse,
+		Items:  itemsResponse,
+	}
+}
@@ -0,0 +1,52 @@
+package slot
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	KindDelivery = "delivery"
+	KindPickup   = "pickup"
+)
+
+var weekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+type Slot struct {
+	ID        uint64    `gorm:"column:id;primaryKey;autoIncrement"`
+	Name      string    `gorm:"column:name;type:varchar(255)"`
+	Kind      string    `gorm:"column:kind;type:varchar(255);index"`
+	StartTime string    `gorm:"column:start_time;type:varchar(5)"`
+	EndTime   string    `gorm:"column:end_time;type:varchar(5)"`
+	Days      string    `gorm:"column:days;type:varchar(255)"`
+	Capacity  int       `gorm:"column:capacity"`
+	Active    bool      `gorm:"column:active"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (Slot) TableName() string {
+	return "delivery_slots"
+}
+
+func (s Slot) Window() string {
+	return s.StartTime + "-" + s.EndTime
+}
+
+func (s Slot) OpensOn(weekday time.Weekday) bool {
+	if s.Days == "" {
+		return true
+	}
+
+	for _, day := range strings.Split(s.Days, ",") {
+		if day == weekdays[weekday] {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Slot) StartsAt(day time.Time) time.Time {
+	start, _ := time.Parse("15:04", s.StartTime)
+	return time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, day.Location())
+}
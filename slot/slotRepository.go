@@ -0,0 +1,94 @@
+package slot
+
+import (
+	"errors"
+	"taman-pempek/payment"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var releasedStatuses = []string{payment.StatusCancelled, payment.StatusExpired, payment.StatusRefunded}
+
+type SlotRepository interface {
+	FindSlots() ([]Slot, error)
+	FindActiveSlots(kind string) ([]Slot, error)
+	FindSlotByID(ID int) (Slot, error)
+	LockSlotByID(ID int) (Slot, error)
+	CreateSlot(slot Slot) (Slot, error)
+	UpdateSlot(slot Slot) (Slot, error)
+	DeleteSlot(slot Slot) (Slot, error)
+	CountBookings(slotID uint64, date string) (int, error)
+	FindBookingsByDate(date string) ([]payment.Payment, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) FindSlots() ([]Slot, error) {
+	var slots []Slot
+	err := r.db.Order("kind, start_time, id").Find(&slots).Error
+	return slots, err
+}
+
+func (r *repository) FindActiveSlots(kind string) ([]Slot, error) {
+	var slots []Slot
+	query := r.db.Where("active = ?", true)
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+	err := query.Order("kind, start_time, id").Find(&slots).Error
+	return slots, err
+}
+
+func (r *repository) FindSlotByID(ID int) (Slot, error) {
+	var slot Slot
+	err := r.db.First(&slot, ID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Slot{}, errors.New("Slot not found")
+	}
+	return slot, err
+}
+
+func (r *repository) LockSlotByID(ID int) (Slot, error) {
+	var slot Slot
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&slot, ID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Slot{}, errors.New("Slot not found")
+	}
+	return slot, err
+}
+
+func (r *repository) CreateSlot(slot Slot) (Slot, error) {
+	err := r.db.Create(&slot).Error
+	return slot, err
+}
+
+func (r *repository) UpdateSlot(slot Slot) (Slot, error) {
+	err := r.db.Save(&slot).Error
+	return slot, err
+}
+
+func (r *repository) DeleteSlot(slot Slot) (Slot, error) {
+	err := r.db.Delete(&slot).Error
+	return slot, err
+}
+
+func (r *repository) CountBookings(slotID uint64, date string) (int, error) {
+	var count int64
+	err := r.db.Model(&payment.Payment{}).
+		Where("slot_id = ? AND scheduled_date = ? AND payment_status NOT IN ?", slotID, date, releasedStatuses).
+		Count(&count).Error
+	return int(count), err
+}
+
+func (r *repository) FindBookingsByDate(date string) ([]payment.Payment, error) {
+	var payments []payment.Payment
+	err := r.db.Where("scheduled_date = ? AND payment_status NOT IN ?", date, releasedStatuses).Order("slot_id, id").Find(&payments).Error
+	return payments, err
+}
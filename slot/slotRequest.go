@@ -0,0 +1,11 @@
+package slot
+
+type SlotRequest struct {
+	Name      string   `json:"name" binding:"required"`
+	Kind      string   `json:"kind" binding:"required,oneof=delivery pickup"`
+	StartTime string   `json:"start_time" binding:"required,datetime=15:04"`
+	EndTime   string   `json:"end_time" binding:"required,datetime=15:04"`
+	Days      []string `json:"days" binding:"dive,oneof=sun mon tue wed thu fri sat"`
+	Capacity  int      `json:"capacity" binding:"required,min=1"`
+	Active    *bool    `json:"active"`
+}
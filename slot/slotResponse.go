@@ -0,0 +1,52 @@
+package slot
+
+import "encoding/json"
+
+type SlotResponse struct {
+	ID        uint64   `json:"id"`
+	Name      string   `json:"name"`
+	Kind      string   `json:"kind"`
+	StartTime string   `json:"start_time"`
+	EndTime   string   `json:"end_time"`
+	Days      []string `json:"days"`
+	Capacity  int      `json:"capacity"`
+	Active    bool     `json:"active"`
+}
+
+type AvailabilityResponse struct {
+	SlotID    uint64 `json:"slot_id"`
+	Name      string `json:"name"`
+	Kind      string `json:"kind"`
+	Date      string `json:"date"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+	Capacity  int    `json:"capacity"`
+	Booked    int    `json:"booked"`
+	Remaining int    `json:"remaining"`
+	Available bool   `json:"available"`
+}
+
+type ScheduleOrderResponse struct {
+	PaymentID     uint64 `json:"payment_id"`
+	UserID        int    `json:"user_id"`
+	PaymentStatus string `json:"payment_status"`
+	PaymentMethod string `json:"payment_method"`
+	Recipient     string `json:"recipient"`
+	Address       string `json:"address"`
+	Whatsapp      string `json:"whatsapp"`
+	DeliveryName  string `json:"delivery_name"`
+	TotalPrice    int    `json:"total_price"`
+}
+
+type ScheduleItemResponse struct {
+	ProductID json.Number `json:"product_id"`
+	Name      string      `json:"name"`
+	Quantity  int         `json:"quantity"`
+}
+
+type ScheduleResponse struct {
+	Slot   SlotResponse            `json:"slot"`
+	Date   string                  `json:"date"`
+	Orders []ScheduleOrderResponse `json:"orders"`
+	Items  []ScheduleItemResponse  `json:"items"`
+}
@@ -0,0 +1,291 @@
+package slot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"taman-pempek/cart"
+	"taman-pempek/payment"
+	"taman-pempek/product"
+	"time"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	timeLayout = "15:04"
+)
+
+type Availability struct {
+	Slot      Slot
+	Date      string
+	Booked    int
+	Remaining int
+	Available bool
+}
+
+type ScheduleItem struct {
+	ProductID json.Number
+	Name      string
+	Quantity  int
+}
+
+type Schedule struct {
+	Slot   Slot
+	Date   string
+	Orders []payment.Payment
+	Items  []ScheduleItem
+}
+
+type SlotService interface {
+	FindSlots() ([]Slot, error)
+	FindSlotByID(ID int) (Slot, error)
+	CreateSlot(request SlotRequest) (Slot, error)
+	UpdateSlot(ID int, request SlotRequest) (Slot, error)
+	DeleteSlot(ID int) (Slot, error)
+	FindAvailability(date string, kind string) ([]Availability, error)
+	FindSchedule(date string) ([]Schedule, error)
+}
+
+type service struct {
+	slotRepository SlotRepository
+	cartRepository cart.CartRepository
+	productService product.ProductService
+}
+
+func NewService(slotRepository SlotRepository, cartRepository cart.CartRepository, productService product.ProductService) *service {
+	return &service{slotRepository, cartRepository, productService}
+}
+
+func (s *service) FindSlots() ([]Slot, error) {
+	return s.slotRepository.FindSlots()
+}
+
+func (s *service) FindSlotByID(ID int) (Slot, error) {
+	return s.slotRepository.FindSlotByID(ID)
+}
+
+func (s *service) CreateSlot(request SlotRequest) (Slot, error) {
+	startTime, endTime, err := parseTimes(request.StartTime, request.EndTime)
+
+	if err != nil {
+		return Slot{}, err
+	}
+
+	slot := Slot{
+		Name:      request.Name,
+		Kind:      request.Kind,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Days:      strings.Join(request.Days, ","),
+		Capacity:  request.Capacity,
+		Active:    request.Active == nil || *request.Active,
+	}
+
+	return s.slotRepository.CreateSlot(slot)
+}
+
+func (s *service) UpdateSlot(ID int, request SlotRequest) (Slot, error) {
+	slot, err := s.slotRepository.FindSlotByID(ID)
+
+	if err != nil {
+		return Slot{}, err
+	}
+
+	startTime, endTime, err := parseTimes(request.StartTime, request.EndTime)
+
+	if err != nil {
+		return Slot{}, err
+	}
+
+	slot.Name = request.Name
+	slot.Kind = request.Kind
+	slot.StartTime = startTime
+	slot.EndTime = endTime
+	slot.Days = strings.Join(request.Days, ",")
+	slot.Capacity = request.Capacity
+
+	if request.Active != nil {
+		slot.Active = *request.Active
+	}
+
+	return s.slotRepository.UpdateSlot(slot)
+}
+
+func parseTimes(startTime string, endTime string) (string, string, error) {
+	start, err := time.Parse(timeLayout, startTime)
+
+	if err != nil {
+		return "", "", errors.New("Invalid start time, expected HH:MM")
+	}
+
+	end, err := time.Parse(timeLayout, endTime)
+
+	if err != nil {
+		return "", "", errors.New("Invalid end time, expected HH:MM")
+	}
+
+	if !end.After(start) {
+		return "", "", errors.New("End time must be after start time")
+	}
+
+	return start.Format(timeLayout), end.Format(timeLayout), nil
+}
+
+func (s *service) DeleteSlot(ID int) (Slot, error) {
+	slot, err := s.slotRepository.FindSlotByID(ID)
+
+	if err != nil {
+		return Slot{}, err
+	}
+
+	return s.slotRepository.DeleteSlot(slot)
+}
+
+func (s *service) FindAvailability(date string, kind string) ([]Availability, error) {
+	day, err := time.ParseInLocation(dateLayout, date, time.Local)
+
+	if err != nil {
+		return nil, errors.New("Invalid date, expected YYYY-MM-DD")
+	}
+
+	slots, err := s.slotRepository.FindActiveSlots(kind)
+
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	availability := []Availability{}
+
+	for _, slot := range slots {
+		if !slot.OpensOn(day.Weekday()) {
+			continue
+		}
+
+		booked, err := s.slotRepository.CountBookings(slot.ID, date)
+
+		if err != nil {
+			return nil, err
+		}
+
+		remaining := slot.Capacity - booked
+
+		if remaining < 0 {
+			remaining = 0
+		}
+
+		availability = append(availability, Availability{
+			Slot:      slot,
+			Date:      date,
+			Booked:    booked,
+			Remaining: remaining,
+			Available: remaining > 0 && slot.StartsAt(day).After(now),
+		})
+	}
+
+	return availability, nil
+}
+
+func (s *service) FindSchedule(date string) ([]Schedule, error) {
+	if _, err := time.Parse(dateLayout, date); err != nil {
+		return nil, errors.New("Invalid date, expected YYYY-MM-DD")
+	}
+
+	bookings, err := s.slotRepository.FindBookingsByDate(date)
+
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := []Schedule{}
+	scheduleIndex := map[uint64]int{}
+	itemIndex := map[uint64]map[string]int{}
+	names := map[string]string{}
+
+	for _, booking := range bookings {
+		i, ok := scheduleIndex[booking.SlotID]
+
+		if !ok {
+			slot, err := s.slotRepository.FindSlotByID(int(booking.SlotID))
+
+			if err != nil {
+				start, end, _ := strings.Cut(booking.ScheduledWindow, "-")
+				slot = Slot{ID: booking.SlotID, StartTime: start, EndTime: end}
+			}
+
+			i = len(schedules)
+			scheduleIndex[booking.SlotID] = i
+			itemIndex[booking.SlotID] = map[string]int{}
+			schedules = append(schedules, Schedule{Slot: slot, Date: date, Orders: []payment.Payment{}, Items: []ScheduleItem{}})
+		}
+
+		schedules[i].Orders = append(schedules[i].Orders, booking)
+
+		carts, err := s.cartRepository.FindCartsByPaymentID(int(booking.ID))
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range carts {
+			quantity, _ := c.Quantity.Int64()
+			productID := c.ProductID.String()
+
+			if _, ok := names[productID]; !ok {
+				names[productID] = productID
+
+				if ID, err := c.ProductID.Int64(); err == nil {
+					if p, err := s.productService.FindProductByID(int(ID)); err == nil {
+						names[productID] = p.Name
+					}
+				}
+			}
+
+			j, ok := itemIndex[booking.SlotID][productID]
+
+			if !ok {
+				j = len(schedules[i].Items)
+				itemIndex[booking.SlotID][productID] = j
+				schedules[i].Items = append(schedules[i].Items, ScheduleItem{ProductID: c.ProductID, Name: names[productID]})
+			}
+
+			schedules[i].Items[j].Quantity += int(quantity)
+		}
+	}
+
+	return schedules, nil
+}
+
+func KindFor(method string) string {
+	if method == payment.MethodPickup {
+		return KindPickup
+	}
+	return KindDelivery
+}
+
+func Check(slot Slot, date string, kind string, now time.Time) error {
+	day, err := time.ParseInLocation(dateLayout, date, time.Local)
+
+	if err != nil {
+		return errors.New("Invalid scheduled date, expected YYYY-MM-DD")
+	}
+
+	if !slot.Active {
+		return errors.New("Slot is not available")
+	}
+
+	if slot.Kind != kind {
+		return fmt.Errorf("Slot %s is not a %s slot", slot.Name, kind)
+	}
+
+	if !slot.OpensOn(day.Weekday()) {
+		return fmt.Errorf("Slot %s is not available on %s", slot.Name, day.Weekday())
+	}
+
+	if !slot.StartsAt(day).After(now) {
+		return fmt.Errorf("Slot %s on %s has already started", slot.Name, date)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package slot
+
+import "testing"
+
+func TestParseTimesComparesClockTimes(t *testing.T) {
+	cases := []struct {
+		start string
+		end   string
+		want  string
+		ok    bool
+	}{
+		{"9:00", "10:00", "09:00-10:00", true},
+		{"09:00", "13:30", "09:00-13:30", true},
+		{"9:00", "13:00", "09:00-13:00", true},
+		{"10:00", "9:00", "", false},
+		{"14:00", "14:00", "", false},
+		{"25:00", "26:00", "", false},
+	}
+
+	for _, c := range cases {
+		start, end, err := parseTimes(c.start, c.end)
+
+		if (err == nil) != c.ok {
+			t.Errorf("parseTimes(%q, %q) error = %v", c.start, c.end, err)
+			continue
+		}
+
+		if c.ok && start+"-"+end != c.want {
+			t.Errorf("parseTimes(%q, %q) = %s-%s, want %s", c.start, c.end, start, end, c.want)
+		}
+	}
+}
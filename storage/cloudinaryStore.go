@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/cloudinary/cloudinary-go/v2"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+)
+
+type cloudinaryStore struct {
+	cld *cloudinary.Cloudinary
+}
+
+func NewCloudinaryStore(cloud string, apiKey string, apiSecret string) (*cloudinaryStore, error) {
+	cld, err := cloudinary.NewFromParams(cloud, apiKey, apiSecret)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cld.Config.URL.Secure = true
+
+	return &cloudinaryStore{cld}, nil
+}
+
+func (s *cloudinaryStore) Put(key string, content io.Reader, contentType string) (Object, error) {
+	publicID := strings.TrimSuffix(key, path.Ext(key))
+
+	response, err := s.cld.Upload.Upload(context.Background(), content, uploader.UploadParams{PublicID: publicID})
+
+	if err != nil {
+		return Object{}, err
+	}
+
+	if response.Error.Message != "" {
+		return Object{}, fmt.Errorf("Cloudinary upload failed: %s", response.Error.Message)
+	}
+
+	return Object{Key: response.PublicID, URL: response.SecureURL}, nil
+}
+
+func (s *cloudinaryStore) Delete(key string) error {
+	response, err := s.cld.Upload.Destroy(context.Background(), uploader.DestroyParams{PublicID: key})
+
+	if err != nil {
+		return err
+	}
+
+	if response.Error.Message != "" {
+		return fmt.Errorf("Cloudinary delete failed: %s", response.Error.Message)
+	}
+
+	return nil
+}
+
+func (s *cloudinaryStore) URL(key string) string {
+	image, err := s.cld.Image(key)
+
+	if err != nil {
+		return ""
+	}
+
+	url, err := image.String()
+
+	if err != nil {
+		return ""
+	}
+
+	return url
+}
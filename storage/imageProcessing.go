@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/nfnt/resize"
+)
+
+const jpegQuality = 85
+
+func decodeImage(body []byte, contentType string) (image.Image, error) {
+	var img image.Image
+	var err error
+
+	switch contentType {
+	case TypeJPEG:
+		img, err = jpeg.Decode(bytes.NewReader(body))
+	case TypePNG:
+		img, err = png.Decode(bytes.NewReader(body))
+	case TypeGIF:
+		img, err = gif.Decode(bytes.NewReader(body))
+	default:
+		return nil, errUnsupportedImage
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if contentType == TypeJPEG {
+		img = orient(img, jpegOrientation(body))
+	}
+
+	return img, nil
+}
+
+func encodeImage(img image.Image, contentType string, variant Variant) ([]byte, string, error) {
+	resized := resize.Thumbnail(variant.Width, variant.Height, img, resize.Lanczos3)
+
+	var buffer bytes.Buffer
+
+	if contentType == TypeJPEG {
+		if err := jpeg.Encode(&buffer, resized, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, "", err
+		}
+		return buffer.Bytes(), ".jpg", nil
+	}
+
+	if err := png.Encode(&buffer, resized); err != nil {
+		return nil, "", err
+	}
+	return buffer.Bytes(), ".png", nil
+}
+
+func jpegOrientation(body []byte) int {
+	for i := 2; i+4 <= len(body) && body[i] == 0xFF; {
+		marker := body[i+1]
+		length := int(binary.BigEndian.Uint16(body[i+2 : i+4]))
+
+		if marker == 0xDA || length < 2 || i+2+length > len(body) {
+			return 1
+		}
+
+		segment := body[i+4 : i+2+length]
+
+		if marker == 0xE1 && len(segment) > 14 && string(segment[:6]) == "Exif\x00\x00" {
+			return exifOrientation(segment[6:])
+		}
+
+		i += 2 + length
+	}
+	return 1
+}
+
+func exifOrientation(tiff []byte) int {
+	var order binary.ByteOrder
+
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:8]))
+
+	if offset+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[offset : offset+2]))
+
+	for i := 0; i < entries; i++ {
+		entry := offset + 2 + i*12
+
+		if entry+12 > len(tiff) {
+			return 1
+		}
+
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			orientation := int(order.Uint16(tiff[entry+8 : entry+10]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+	}
+	return 1
+}
+
+func orient(img image.Image, orientation int) image.Image {
+	if orientation <= 1 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if orientation >= 5 {
+		width, height = height, width
+	}
+
+	oriented := image.NewRGBA(image.Rect(0, 0, width, height))
+	src := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			dx, dy := x, y
+
+			switch orientation {
+			case 2:
+				dx = width - 1 - x
+			case 3:
+				dx, dy = width-1-x, height-1-y
+			case 4:
+				dy = height - 1 - y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = width-1-y, x
+			case 7:
+				dx, dy = width-1-y, height-1-x
+			case 8:
+				dx, dy = y, height-1-x
+			}
+
+			oriented.SetRGBA(dx, dy, src.RGBAAt(x, y))
+		}
+	}
+
+	return oriented
+}
@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+)
+
+func exifTIFF(order binary.ByteOrder, orientation uint16) []byte {
+	tiff := make([]byte, 8+2+12+4)
+
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:4], 42)
+	order.PutUint32(tiff[4:8], 8)
+	order.PutUint16(tiff[8:10], 1)
+	order.PutUint16(tiff[10:12], 0x0112)
+	order.PutUint16(tiff[12:14], 3)
+	order.PutUint32(tiff[14:18], 1)
+	order.PutUint16(tiff[18:20], orientation)
+
+	return tiff
+}
+
+func jpegWithAPP1(t *testing.T, width int, height int, payload []byte) []byte {
+	var buffer bytes.Buffer
+	if err := jpeg.Encode(&buffer, image.NewRGBA(image.Rect(0, 0, width, height)), nil); err != nil {
+		t.Fatal(err)
+	}
+	encoded := buffer.Bytes()
+
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:4], uint16(2+len(payload)))
+	segment = append(segment, payload...)
+
+	body := append([]byte{}, encoded[:2]...)
+	body = append(body, segment...)
+	return append(body, encoded[2:]...)
+}
+
+func exifPayload(tiff []byte) []byte {
+	return append([]byte("Exif\x00\x00"), tiff...)
+}
+
+func TestJpegOrientationByteOrder(t *testing.T) {
+	for name, order := range map[string]binary.ByteOrder{"II": binary.LittleEndian, "MM": binary.BigEndian} {
+		body := jpegWithAPP1(t, 4, 2, exifPayload(exifTIFF(order, 6)))
+
+		if got := jpegOrientation(body); got != 6 {
+			t.Errorf("%s: orientation = %d, want 6", name, got)
+		}
+	}
+}
+
+func TestJpegOrientationMalformedExif(t *testing.T) {
+	outOfRange := exifTIFF(binary.BigEndian, 6)
+	binary.BigEndian.PutUint32(outOfRange[4:8], 0xFFFFFF00)
+
+	tooManyEntries := exifTIFF(binary.LittleEndian, 6)
+	binary.LittleEndian.PutUint16(tooManyEntries[8:10], 50)
+	binary.LittleEndian.PutUint16(tooManyEntries[10:12], 0x0100)
+
+	truncated := jpegWithAPP1(t, 4, 2, exifPayload(exifTIFF(binary.BigEndian, 6)))
+	binary.BigEndian.PutUint16(truncated[4:6], 0xFFF0)
+
+	cases := map[string][]byte{
+		"truncated APP1":   truncated,
+		"IFD out of range": jpegWithAPP1(t, 4, 2, exifPayload(outOfRange)),
+		"entries past end": jpegWithAPP1(t, 4, 2, exifPayload(tooManyEntries)),
+		"unknown order":    jpegWithAPP1(t, 4, 2, exifPayload(append([]byte("XX"), exifTIFF(binary.BigEndian, 6)[2:]...))),
+		"cut inside APP1":  jpegWithAPP1(t, 4, 2, exifPayload(exifTIFF(binary.BigEndian, 6)))[:20],
+	}
+
+	for name, body := range cases {
+		if got := jpegOrientation(body); got != 1 {
+			t.Errorf("%s: orientation = %d, want 1", name, got)
+		}
+	}
+}
+
+func TestDecodeImageRotatesOrientedJPEG(t *testing.T) {
+	for _, orientation := range []uint16{6, 8} {
+		body := jpegWithAPP1(t, 40, 20, exifPayload(exifTIFF(binary.BigEndian, orientation)))
+
+		img, err := decodeImage(body, TypeJPEG)
+		if err != nil {
+			t.Fatalf("decodeImage: %v", err)
+		}
+
+		if bounds := img.Bounds(); bounds.Dx() != 20 || bounds.Dy() != 40 {
+			t.Errorf("orientation %d: size = %dx%d, want 20x40", orientation, bounds.Dx(), bounds.Dy())
+		}
+	}
+}
+
+func TestOrientRotatesPixels(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	src := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	src.SetRGBA(0, 0, red)
+
+	cases := map[int]image.Point{
+		1: {0, 0},
+		6: {1, 0},
+		8: {0, 2},
+	}
+
+	for orientation, want := range cases {
+		img := orient(src, orientation)
+		bounds := img.Bounds()
+
+		if orientation >= 5 && (bounds.Dx() != 2 || bounds.Dy() != 3) {
+			t.Errorf("orientation %d: size = %dx%d, want 2x3", orientation, bounds.Dx(), bounds.Dy())
+		}
+
+		if got := color.RGBAModel.Convert(img.At(want.X, want.Y)); got != red {
+			t.Errorf("orientation %d: pixel at %v = %v, want red", orientation, want, got)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type localStore struct {
+	dir     string
+	baseURL string
+}
+
+func NewLocalStore(dir string, baseURL string) *localStore {
+	return &localStore{dir, strings.TrimRight(baseURL, "/")}
+}
+
+func (s *localStore) Put(key string, content io.Reader, contentType string) (Object, error) {
+	if !validKey(key) {
+		return Object{}, errors.New("Invalid storage key")
+	}
+
+	name := filepath.Join(s.dir, filepath.FromSlash(key))
+
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return Object{}, err
+	}
+
+	file, err := os.Create(name)
+
+	if err != nil {
+		return Object{}, err
+	}
+
+	if _, err := io.Copy(file, content); err != nil {
+		file.Close()
+		os.Remove(name)
+		return Object{}, err
+	}
+
+	if err := file.Close(); err != nil {
+		return Object{}, err
+	}
+
+	return Object{Key: key, URL: s.URL(key)}, nil
+}
+
+func (s *localStore) Delete(key string) error {
+	if !validKey(key) {
+		return errors.New("Invalid storage key")
+	}
+
+	err := os.Remove(filepath.Join(s.dir, filepath.FromSlash(key)))
+
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+func (s *localStore) URL(key string) string {
+	return s.baseURL + "/" + key
+}
@@ -0,0 +1,54 @@
+package storage
+
+const (
+	TypeJPEG = "image/jpeg"
+	TypePNG  = "image/png"
+	TypeGIF  = "image/gif"
+	TypePDF  = "application/pdf"
+)
+
+type Variant struct {
+	Name   string
+	Width  uint
+	Height uint
+}
+
+var (
+	Thumbnail = Variant{Name: "thumbnail", Width: 200, Height: 200}
+	Medium    = Variant{Name: "medium", Width: 640, Height: 640}
+	Large     = Variant{Name: "large", Width: 1280, Height: 1280}
+)
+
+type Kind struct {
+	Name     string
+	MaxSize  int64
+	Types    []string
+	Variants []Variant
+}
+
+var (
+	ProductImage = Kind{
+		Name:     "Product image",
+		MaxSize:  5 << 20,
+		Types:    []string{TypeJPEG, TypePNG, TypeGIF},
+		Variants: []Variant{Thumbnail, Medium, Large},
+	}
+	SettingImage = Kind{
+		Name:     "Store image",
+		MaxSize:  2 << 20,
+		Types:    []string{TypeJPEG, TypePNG, TypeGIF},
+		Variants: []Variant{Thumbnail, Medium, Large},
+	}
+	PaymentProof = Kind{
+		Name:     "Payment proof",
+		MaxSize:  5 << 20,
+		Types:    []string{TypeJPEG, TypePNG, TypePDF},
+		Variants: []Variant{Large},
+	}
+	DeliveryProof = Kind{
+		Name:     "Delivery proof",
+		MaxSize:  5 << 20,
+		Types:    []string{TypeJPEG, TypePNG},
+		Variants: []Variant{Large},
+	}
+)
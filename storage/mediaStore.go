@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"mime/multipart"
+	"path"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/google/uuid"
+)
+
+const maxPixels = 40000000
+
+var errUnsupportedImage = errors.New("Unsupported image type")
+
+type Object struct {
+	Key string
+	URL string
+}
+
+type Media struct {
+	URL      string
+	Keys     []string
+	Variants map[string]string
+}
+
+type MediaStore interface {
+	Put(key string, content io.Reader, contentType string) (Object, error)
+	Delete(key string) error
+	URL(key string) string
+}
+
+func Upload(store MediaStore, folder string, file *multipart.FileHeader, kind Kind) (Media, error) {
+	tooLarge := fmt.Errorf("%s must not be larger than %d MB", kind.Name, kind.MaxSize>>20)
+
+	if file.Size > kind.MaxSize {
+		return Media{}, tooLarge
+	}
+
+	content, err := file.Open()
+
+	if err != nil {
+		return Media{}, err
+	}
+	defer content.Close()
+
+	body, err := io.ReadAll(io.LimitReader(content, kind.MaxSize+1))
+
+	if err != nil {
+		return Media{}, err
+	}
+
+	if int64(len(body)) > kind.MaxSize {
+		return Media{}, tooLarge
+	}
+
+	contentType := detectType(body, kind)
+
+	if contentType == "" {
+		return Media{}, fmt.Errorf("%s must be one of %s", kind.Name, strings.Join(kind.Types, ", "))
+	}
+
+	base := path.Join(folder, uuid.NewString())
+
+	if contentType == TypePDF {
+		object, err := store.Put(base+".pdf", bytes.NewReader(body), contentType)
+
+		if err != nil {
+			return Media{}, err
+		}
+
+		return Media{URL: object.URL, Keys: []string{object.Key}, Variants: map[string]string{}}, nil
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(body))
+
+	if err == nil && config.Width*config.Height > maxPixels {
+		return Media{}, fmt.Errorf("%s dimensions are too large", kind.Name)
+	}
+
+	img, err := decodeImage(body, contentType)
+
+	if err != nil {
+		return Media{}, fmt.Errorf("%s could not be read as an image", kind.Name)
+	}
+
+	media := Media{Variants: map[string]string{}}
+
+	for _, variant := range kind.Variants {
+		data, extension, err := encodeImage(img, contentType, variant)
+
+		if err == nil {
+			var object Object
+			object, err = store.Put(base+"-"+variant.Name+extension, bytes.NewReader(data), mimetype.Detect(data).String())
+
+			if err == nil {
+				media.Keys = append(media.Keys, object.Key)
+				media.Variants[variant.Name] = object.URL
+				media.URL = object.URL
+				continue
+			}
+		}
+
+		Remove(store, media.Keys)
+		return Media{}, err
+	}
+
+	return media, nil
+}
+
+func Remove(store MediaStore, keys []string) error {
+	var errs []error
+
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+
+		if err := store.Delete(key); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func detectType(body []byte, kind Kind) string {
+	detected := mimetype.Detect(body)
+
+	for _, allowed := range kind.Types {
+		if detected.Is(allowed) {
+			return allowed
+		}
+	}
+	return ""
+}
+
+func validKey(key string) bool {
+	return key != "" && path.Clean(key) == key && !path.IsAbs(key) && key != ".." && !strings.HasPrefix(key, "../")
+}
@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+type mockObject struct {
+	Body        []byte
+	ContentType string
+}
+
+type MockS3Server struct {
+	region    string
+	accessKey string
+	secretKey string
+
+	mu      sync.Mutex
+	objects map[string]mockObject
+}
+
+func NewMockS3Server(region string, accessKey string, secretKey string) *MockS3Server {
+	return &MockS3Server{
+		region:    region,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		objects:   map[string]mockObject{},
+	}
+}
+
+func (s *MockS3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/")
+
+	if !strings.Contains(path, "/") {
+		writeMockError(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		s.get(w, r, path)
+	case http.MethodPut:
+		s.put(w, r, path)
+	case http.MethodDelete:
+		s.delete(w, r, path)
+	default:
+		writeMockError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed against this resource.")
+	}
+}
+
+func (s *MockS3Server) get(w http.ResponseWriter, r *http.Request, path string) {
+	s.mu.Lock()
+	object, ok := s.objects[path]
+	s.mu.Unlock()
+
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+		return
+	}
+
+	w.Header().Set("Content-Type", object.ContentType)
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		w.Write(object.Body)
+	}
+}
+
+func (s *MockS3Server) put(w http.ResponseWriter, r *http.Request, path string) {
+	body, err := io.ReadAll(r.Body)
+
+	if err != nil {
+		writeMockError(w, http.StatusBadRequest, "IncompleteBody", "The request body could not be read.")
+		return
+	}
+
+	if !s.authorized(w, r, body) {
+		return
+	}
+
+	s.mu.Lock()
+	s.objects[path] = mockObject{Body: body, ContentType: r.Header.Get("Content-Type")}
+	s.mu.Unlock()
+
+	hash := sha256.Sum256(body)
+	w.Header().Set("ETag", `"`+hex.EncodeToString(hash[:16])+`"`)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *MockS3Server) delete(w http.ResponseWriter, r *http.Request, path string) {
+	if !s.authorized(w, r, nil) {
+		return
+	}
+
+	s.mu.Lock()
+	delete(s.objects, path)
+	s.mu.Unlock()
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *MockS3Server) Object(bucket string, key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.objects[bucket+"/"+key]
+	return bytes.Clone(object.Body), ok
+}
+
+func (s *MockS3Server) authorized(w http.ResponseWriter, r *http.Request, body []byte) bool {
+	authorization := strings.TrimPrefix(r.Header.Get("Authorization"), s3Algorithm+" ")
+	fields := map[string]string{}
+
+	for _, field := range strings.Split(authorization, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(field), "=")
+		if ok {
+			fields[name] = value
+		}
+	}
+
+	amzDate := r.Header.Get("X-Amz-Date")
+
+	if len(amzDate) != len(s3TimeFormat) || fields["Credential"] != s.accessKey+"/"+credentialScope(amzDate, s.region) {
+		writeMockError(w, http.StatusForbidden, "InvalidAccessKeyId", "The AWS Access Key Id you provided does not exist in our records.")
+		return false
+	}
+
+	hash := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(hash[:])
+
+	if r.Header.Get("X-Amz-Content-Sha256") != payloadHash {
+		writeMockError(w, http.StatusBadRequest, "XAmzContentSHA256Mismatch", "The provided 'x-amz-content-sha256' header does not match what was computed.")
+		return false
+	}
+
+	expected := signature(s.secretKey, s.region, amzDate, canonicalRequest(r, strings.Split(fields["SignedHeaders"], ";"), payloadHash))
+
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(fields["Signature"])) != 1 {
+		writeMockError(w, http.StatusForbidden, "SignatureDoesNotMatch", "The request signature we calculated does not match the signature you provided.")
+		return false
+	}
+
+	return true
+}
+
+func writeMockError(w http.ResponseWriter, status int, code string, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string   `xml:"Code"`
+		Message string   `xml:"Message"`
+	}{Code: code, Message: message})
+}
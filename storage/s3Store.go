@@ -0,0 +1,222 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	s3Algorithm  = "AWS4-HMAC-SHA256"
+	s3Service    = "s3"
+	s3Request    = "aws4_request"
+	s3TimeFormat = "20060102T150405Z"
+)
+
+type s3Store struct {
+	endpoint  string
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	publicURL string
+	client    *http.Client
+}
+
+func NewS3Store(endpoint string, region string, bucket string, accessKey string, secretKey string, publicURL string) *s3Store {
+	endpoint = strings.TrimRight(endpoint, "/")
+
+	if publicURL == "" {
+		publicURL = endpoint + "/" + bucket
+	}
+
+	return &s3Store{endpoint, region, bucket, accessKey, secretKey, strings.TrimRight(publicURL, "/"), &http.Client{Timeout: 30 * time.Second}}
+}
+
+func (s *s3Store) Put(key string, content io.Reader, contentType string) (Object, error) {
+	if !validKey(key) {
+		return Object{}, errors.New("Invalid storage key")
+	}
+
+	body, err := io.ReadAll(content)
+
+	if err != nil {
+		return Object{}, err
+	}
+
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+
+	request, err := http.NewRequest(http.MethodPut, s.objectURL(key), bytes.NewReader(body))
+
+	if err != nil {
+		return Object{}, err
+	}
+
+	request.Header.Set("Content-Type", contentType)
+
+	if err := s.do(request, body, http.StatusOK); err != nil {
+		return Object{}, err
+	}
+
+	return Object{Key: key, URL: s.URL(key)}, nil
+}
+
+func (s *s3Store) Delete(key string) error {
+	if !validKey(key) {
+		return errors.New("Invalid storage key")
+	}
+
+	request, err := http.NewRequest(http.MethodDelete, s.objectURL(key), nil)
+
+	if err != nil {
+		return err
+	}
+
+	return s.do(request, nil, http.StatusNoContent, http.StatusOK, http.StatusNotFound)
+}
+
+func (s *s3Store) URL(key string) string {
+	return s.publicURL + "/" + escapePath(key)
+}
+
+func (s *s3Store) objectURL(key string) string {
+	return s.endpoint + "/" + s.bucket + "/" + escapePath(key)
+}
+
+func (s *s3Store) do(request *http.Request, body []byte, expected ...int) error {
+	signRequest(request, body, s.region, s.accessKey, s.secretKey, time.Now())
+
+	response, err := s.client.Do(request)
+
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	for _, status := range expected {
+		if response.StatusCode == status {
+			return nil
+		}
+	}
+
+	message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+
+	return fmt.Errorf("S3 %s %s failed with status %d: %s", request.Method, request.URL.Path, response.StatusCode, strings.TrimSpace(string(message)))
+}
+
+func signRequest(request *http.Request, body []byte, region string, accessKey string, secretKey string, now time.Time) {
+	hash := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(hash[:])
+	amzDate := now.UTC().Format(s3TimeFormat)
+
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	if request.Header.Get("Content-Type") != "" {
+		signedHeaders = append(signedHeaders, "content-type")
+	}
+	sort.Strings(signedHeaders)
+
+	scope := credentialScope(amzDate, region)
+	canonical := canonicalRequest(request, signedHeaders, payloadHash)
+	signature := signature(secretKey, region, amzDate, canonical)
+
+	request.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s", s3Algorithm, accessKey, scope, strings.Join(signedHeaders, ";"), signature))
+}
+
+func canonicalRequest(request *http.Request, signedHeaders []string, payloadHash string) string {
+	var headers strings.Builder
+
+	for _, name := range signedHeaders {
+		value := request.Header.Get(name)
+		if name == "host" {
+			value = request.Host
+			if value == "" {
+				value = request.URL.Host
+			}
+		}
+		headers.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	return strings.Join([]string{
+		request.Method,
+		escapePath(request.URL.Path),
+		canonicalQuery(request),
+		headers.String(),
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+}
+
+func canonicalQuery(request *http.Request) string {
+	query := request.URL.Query()
+	pairs := []string{}
+
+	for name, values := range query {
+		for _, value := range values {
+			pairs = append(pairs, escape(name, true)+"="+escape(value, true))
+		}
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, "&")
+}
+
+func credentialScope(amzDate string, region string) string {
+	return amzDate[:8] + "/" + region + "/" + s3Service + "/" + s3Request
+}
+
+func signature(secretKey string, region string, amzDate string, canonicalRequest string) string {
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		amzDate,
+		credentialScope(amzDate, region),
+		hex.EncodeToString(hash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), amzDate[:8])
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, s3Request)
+
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func escapePath(path string) string {
+	return escape(path, false)
+}
+
+func escape(value string, encodeSlash bool) string {
+	var escaped strings.Builder
+
+	for _, b := range []byte(value) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9', b == '-', b == '_', b == '.', b == '~':
+			escaped.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			escaped.WriteByte(b)
+		default:
+			fmt.Fprintf(&escaped, "%%%02X", b)
+		}
+	}
+
+	return escaped.String()
+}
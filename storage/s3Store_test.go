@@ -0,0 +1,168 @@
+package storage
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const (
+	testRegion    = "ap-southeast-3"
+	testBucket    = "taman-pempek"
+	testAccessKey = "AKIATESTKEY"
+	testSecretKey = "test-secret"
+)
+
+func newTestS3(t *testing.T, secretKey string) (*s3Store, *MockS3Server, *httptest.Server) {
+	mock := NewMockS3Server(testRegion, testAccessKey, testSecretKey)
+	server := httptest.NewServer(mock)
+	t.Cleanup(server.Close)
+
+	return NewS3Store(server.URL+"/", testRegion, testBucket, testAccessKey, secretKey, ""), mock, server
+}
+
+func fileHeader(t *testing.T, name string, data []byte) *multipart.FileHeader {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	part, err := writer.CreateFormFile("image", name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(data)
+	writer.Close()
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(10 << 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { form.RemoveAll() })
+
+	return form.File["image"][0]
+}
+
+func pngImage(t *testing.T, width int, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, x%height, color.RGBA{R: 200, A: 255})
+	}
+
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, img); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+func TestS3PutAndDelete(t *testing.T) {
+	store, mock, server := newTestS3(t, testSecretKey)
+
+	object, err := store.Put("product/image/pempek kapal selam.txt", strings.NewReader("cuko"), "text/plain")
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	if want := server.URL + "/" + testBucket + "/product/image/pempek%20kapal%20selam.txt"; object.URL != want {
+		t.Errorf("url = %s, want %s", object.URL, want)
+	}
+
+	if body, ok := mock.Object(testBucket, "product/image/pempek kapal selam.txt"); !ok || string(body) != "cuko" {
+		t.Fatalf("stored object = %q, %v", body, ok)
+	}
+
+	response, err := http.Get(object.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(response.Body)
+	response.Body.Close()
+
+	if string(body) != "cuko" || response.Header.Get("Content-Type") != "text/plain" {
+		t.Errorf("public object = %q (%s)", body, response.Header.Get("Content-Type"))
+	}
+
+	if err := store.Delete(object.Key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if _, ok := mock.Object(testBucket, object.Key); ok {
+		t.Error("object still exists after delete")
+	}
+}
+
+func TestS3RejectsBadSignature(t *testing.T) {
+	store, mock, _ := newTestS3(t, "wrong-secret")
+
+	if _, err := store.Put("product/image/a.txt", strings.NewReader("cuko"), "text/plain"); err == nil || !strings.Contains(err.Error(), "SignatureDoesNotMatch") {
+		t.Fatalf("Put with a wrong secret = %v", err)
+	}
+
+	if _, ok := mock.Object(testBucket, "product/image/a.txt"); ok {
+		t.Error("object was stored with a bad signature")
+	}
+}
+
+func TestS3RejectsInvalidKeys(t *testing.T) {
+	store, _, _ := newTestS3(t, testSecretKey)
+
+	for _, key := range []string{"", "../secret", "/etc/passwd", "product/../../x"} {
+		if _, err := store.Put(key, strings.NewReader("x"), "text/plain"); err == nil {
+			t.Errorf("Put(%q) succeeded", key)
+		}
+	}
+}
+
+func TestUploadStoresVariantsOnS3(t *testing.T) {
+	store, mock, _ := newTestS3(t, testSecretKey)
+
+	media, err := Upload(store, "product/image", fileHeader(t, "pempek.png", pngImage(t, 1600, 900)), ProductImage)
+	if err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+
+	if len(media.Keys) != 3 || media.URL != media.Variants[Large.Name] {
+		t.Fatalf("media = %+v", media)
+	}
+
+	for _, variant := range ProductImage.Variants {
+		key := ""
+		for _, k := range media.Keys {
+			if strings.HasSuffix(k, "-"+variant.Name+".png") {
+				key = k
+			}
+		}
+
+		body, ok := mock.Object(testBucket, key)
+		if !ok {
+			t.Fatalf("%s variant was not stored", variant.Name)
+		}
+
+		config, err := png.DecodeConfig(bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("%s variant: %v", variant.Name, err)
+		}
+
+		if uint(config.Width) > variant.Width || uint(config.Height) > variant.Height {
+			t.Errorf("%s variant is %dx%d", variant.Name, config.Width, config.Height)
+		}
+	}
+}
+
+func TestUploadRejectsDisallowedContent(t *testing.T) {
+	store, _, _ := newTestS3(t, testSecretKey)
+
+	if _, err := Upload(store, "product/image", fileHeader(t, "pempek.png", []byte("%PDF-1.4 not an image")), ProductImage); err == nil {
+		t.Error("pdf was accepted as a product image")
+	}
+
+	if _, err := Upload(store, "setting/image", fileHeader(t, "big.png", make([]byte, SettingImage.MaxSize+1)), SettingImage); err == nil {
+		t.Error("oversized file was accepted")
+	}
+}
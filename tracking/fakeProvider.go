@@ -0,0 +1,55 @@
+package tracking
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+type FakeProvider struct {
+	mu      sync.Mutex
+	results map[string]Result
+	calls   []string
+}
+
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{results: map[string]Result{}}
+}
+
+func (p *FakeProvider) Name() string {
+	return "fake"
+}
+
+func (p *FakeProvider) Set(resi string, result Result) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.results[resi] = result
+}
+
+func (p *FakeProvider) Track(courier string, resi string) (Result, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.calls = append(p.calls, courier+":"+resi)
+
+	if result, ok := p.results[resi]; ok {
+		return result, nil
+	}
+
+	return Result{
+		Status: StatusInTransit,
+		Events: []ProviderEvent{{
+			Status:      StatusInTransit,
+			Description: "Shipment received by " + strings.ToUpper(courier),
+			OccurredAt:  time.Now().Truncate(time.Hour),
+		}},
+	}, nil
+}
+
+func (p *FakeProvider) Calls() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]string{}, p.calls...)
+}
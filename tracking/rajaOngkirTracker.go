@@ -0,0 +1,124 @@
+package tracking
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type rajaOngkirTracker struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+func NewRajaOngkirTracker(baseURL string, apiKey string) *rajaOngkirTracker {
+	return &rajaOngkirTracker{strings.TrimRight(baseURL, "/"), apiKey, &http.Client{Timeout: 15 * time.Second}}
+}
+
+type rajaOngkirWaybillResponse struct {
+	RajaOngkir struct {
+		Status struct {
+			Code        int    `json:"code"`
+			Description string `json:"description"`
+		} `json:"status"`
+		Result struct {
+			Delivered bool `json:"delivered"`
+			Summary   struct {
+				Status string `json:"status"`
+			} `json:"summary"`
+			Manifest []struct {
+				Description string `json:"manifest_description"`
+				Date        string `json:"manifest_date"`
+				Time        string `json:"manifest_time"`
+				City        string `json:"city_name"`
+			} `json:"manifest"`
+			DeliveryStatus struct {
+				Status      string `json:"status"`
+				PodReceiver string `json:"pod_receiver"`
+				PodDate     string `json:"pod_date"`
+				PodTime     string `json:"pod_time"`
+			} `json:"delivery_status"`
+		} `json:"result"`
+	} `json:"rajaongkir"`
+}
+
+func (t *rajaOngkirTracker) Name() string {
+	return "rajaongkir"
+}
+
+func (t *rajaOngkirTracker) Track(courier string, resi string) (Result, error) {
+	form := url.Values{}
+	form.Set("waybill", resi)
+	form.Set("courier", strings.ToLower(courier))
+
+	request, err := http.NewRequest(http.MethodPost, t.baseURL+"/waybill", strings.NewReader(form.Encode()))
+
+	if err != nil {
+		return Result{}, err
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("key", t.apiKey)
+
+	response, err := t.client.Do(request)
+
+	if err != nil {
+		return Result{}, err
+	}
+	defer response.Body.Close()
+
+	var waybill rajaOngkirWaybillResponse
+
+	if err := json.NewDecoder(response.Body).Decode(&waybill); err != nil {
+		return Result{}, fmt.Errorf("Invalid response from rajaongkir: %s", err.Error())
+	}
+
+	if waybill.RajaOngkir.Status.Code != http.StatusOK {
+		return Result{}, fmt.Errorf("Rajaongkir error: %s", waybill.RajaOngkir.Status.Description)
+	}
+
+	data := waybill.RajaOngkir.Result
+	result := Result{Status: StatusInTransit}
+
+	if strings.Contains(strings.ToUpper(data.Summary.Status), "RETURN") {
+		result.Status = StatusReturned
+	}
+
+	for _, manifest := range data.Manifest {
+		result.Events = append(result.Events, ProviderEvent{
+			Status:      StatusInTransit,
+			Description: manifest.Description,
+			Location:    manifest.City,
+			OccurredAt:  parseManifestTime(manifest.Date, manifest.Time),
+		})
+	}
+
+	if data.Delivered {
+		result.Status = StatusDelivered
+		result.Events = append(result.Events, ProviderEvent{
+			Status:      StatusDelivered,
+			Description: strings.TrimSpace("Delivered " + data.DeliveryStatus.PodReceiver),
+			OccurredAt:  parseManifestTime(data.DeliveryStatus.PodDate, data.DeliveryStatus.PodTime),
+		})
+	}
+
+	return result, nil
+}
+
+func parseManifestTime(date string, clock string) time.Time {
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02 15:04"} {
+		if parsed, err := time.ParseInLocation(layout, date+" "+clock, time.Local); err == nil {
+			return parsed
+		}
+	}
+
+	if parsed, err := time.ParseInLocation("2006-01-02", date, time.Local); err == nil {
+		return parsed
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,84 @@
+package tracking
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type controller struct {
+	trackingService TrackingService
+}
+
+func NewController(trackingService TrackingService) *controller {
+	return &controller{trackingService}
+}
+
+func (cn *controller) GetPaymentTracking(c *gin.Context) {
+	idString := c.Param("id")
+	id, err := strconv.Atoi(idString)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid payment ID",
+		})
+		return
+	}
+
+	trackings, err := cn.trackingService.FindTrackingsByPayment(id)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "Payment not found" {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	trackingsResponse := []TrackingResponse{}
+
+	for _, tracking := range trackings {
+		trackingsResponse = append(trackingsResponse, convertToTrackingResponse(tracking))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data": PaymentTrackingResponse{
+			PaymentID: id,
+			Trackings: trackingsResponse,
+		},
+	})
+}
+
+func convertToTrackingResponse(tracking Tracking) TrackingResponse {
+	eventsResponse := []EventResponse{}
+
+	for _, event := range tracking.Events {
+		eventsResponse = append(eventsResponse, EventResponse{
+			Status:      event.Status,
+			Description: event.Description,
+			Location:    event.Location,
+			OccurredAt:  event.OccurredAt,
+		})
+	}
+
+	return TrackingResponse{
+		ShipmentID:  tracking.ShipmentID,
+		Courier:     tracking.Courier,
+		Resi:        tracking.Resi,
+		Status:      tracking.Status,
+		LastError:   tracking.LastError,
+		PolledAt:    tracking.PolledAt,
+		DeliveredAt: tracking.DeliveredAt,
+		Events:      eventsResponse,
+	}
+}
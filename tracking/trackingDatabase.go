@@ -0,0 +1,33 @@
+package tracking
+
+import "time"
+
+type Tracking struct {
+	ID          uint64     `gorm:"column:id;primaryKey;autoIncrement"`
+	PaymentID   uint64     `gorm:"column:payment_id;uniqueIndex:idx_tracking_target"`
+	ShipmentID  uint64     `gorm:"column:shipment_id;uniqueIndex:idx_tracking_target"`
+	Resi        string     `gorm:"column:resi;type:varchar(191);uniqueIndex:idx_tracking_target"`
+	Courier     string     `gorm:"column:courier;type:varchar(255)"`
+	Status      string     `gorm:"column:status;type:varchar(255)"`
+	LastError   string     `gorm:"column:last_error;type:varchar(255)"`
+	PolledAt    *time.Time `gorm:"column:polled_at"`
+	DeliveredAt *time.Time `gorm:"column:delivered_at"`
+	Events      []Event    `gorm:"foreignKey:TrackingID"`
+	CreatedAt   time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+type Event struct {
+	ID          uint64    `gorm:"column:id;primaryKey;autoIncrement"`
+	TrackingID  uint64    `gorm:"column:tracking_id;index"`
+	Status      string    `gorm:"column:status;type:varchar(255)"`
+	Description string    `gorm:"column:description;type:varchar(255)"`
+	Location    string    `gorm:"column:location;type:varchar(255)"`
+	OccurredAt  time.Time `gorm:"column:occurred_at"`
+	Fingerprint string    `gorm:"column:fingerprint;type:varchar(64);uniqueIndex"`
+	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (Event) TableName() string {
+	return "tracking_events"
+}
@@ -0,0 +1,27 @@
+package tracking
+
+import "time"
+
+const (
+	StatusPending   = "pending"
+	StatusInTransit = "in_transit"
+	StatusDelivered = "delivered"
+	StatusReturned  = "returned"
+)
+
+type ProviderEvent struct {
+	Status      string
+	Description string
+	Location    string
+	OccurredAt  time.Time
+}
+
+type Result struct {
+	Status string
+	Events []ProviderEvent
+}
+
+type TrackingProvider interface {
+	Name() string
+	Track(courier string, resi string) (Result, error)
+}
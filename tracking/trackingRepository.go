@@ -0,0 +1,62 @@
+package tracking
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type TrackingRepository interface {
+	FindTracking(paymentID uint64, shipmentID uint64, resi string) (Tracking, error)
+	FindTrackingsByPayment(paymentID int) ([]Tracking, error)
+	CreateTracking(tracking Tracking) (Tracking, error)
+	UpdateTracking(tracking Tracking) (Tracking, error)
+	EventExists(fingerprint string) (bool, error)
+	CreateEvent(event Event) (Event, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) FindTracking(paymentID uint64, shipmentID uint64, resi string) (Tracking, error) {
+	var tracking Tracking
+	err := r.db.Where("payment_id = ? AND shipment_id = ? AND resi = ?", paymentID, shipmentID, resi).First(&tracking).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Tracking{}, errors.New("Tracking not found")
+	}
+	return tracking, err
+}
+
+func (r *repository) FindTrackingsByPayment(paymentID int) ([]Tracking, error) {
+	var trackings []Tracking
+	err := r.db.Preload("Events", func(db *gorm.DB) *gorm.DB {
+		return db.Order("occurred_at DESC, id DESC")
+	}).Where("payment_id = ?", paymentID).Order("id").Find(&trackings).Error
+	return trackings, err
+}
+
+func (r *repository) CreateTracking(tracking Tracking) (Tracking, error) {
+	err := r.db.Create(&tracking).Error
+	return tracking, err
+}
+
+func (r *repository) UpdateTracking(tracking Tracking) (Tracking, error) {
+	err := r.db.Omit("Events").Save(&tracking).Error
+	return tracking, err
+}
+
+func (r *repository) EventExists(fingerprint string) (bool, error) {
+	var count int64
+	err := r.db.Model(&Event{}).Where("fingerprint = ?", fingerprint).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repository) CreateEvent(event Event) (Event, error) {
+	err := r.db.Create(&event).Error
+	return event, err
+}
@@ -0,0 +1,26 @@
+package tracking
+
+import "time"
+
+type EventResponse struct {
+	Status      string    `json:"status"`
+	Description string    `json:"description"`
+	Location    string    `json:"location"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+type TrackingResponse struct {
+	ShipmentID  uint64          `json:"shipment_id"`
+	Courier     string          `json:"courier"`
+	Resi        string          `json:"resi"`
+	Status      string          `json:"status"`
+	LastError   string          `json:"last_error"`
+	PolledAt    *time.Time      `json:"polled_at"`
+	DeliveredAt *time.Time      `json:"delivered_at"`
+	Events      []EventResponse `json:"events"`
+}
+
+type PaymentTrackingResponse struct {
+	PaymentID int                `json:"payment_id"`
+	Trackings []TrackingResponse `json:"trackings"`
+}
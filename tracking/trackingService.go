@@ -0,0 +1,235 @@
+package tracking
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"taman-pempek/dispatch"
+	"taman-pempek/payment"
+	"taman-pempek/shipment"
+	"time"
+)
+
+type target struct {
+	PaymentID  uint64
+	ShipmentID uint64
+	Courier    string
+	Resi       string
+	Cash       bool
+}
+
+type TrackingService interface {
+	PollInTransit() (int, error)
+	FindTrackingsByPayment(paymentID int) ([]Tracking, error)
+}
+
+type service struct {
+	trackingRepository TrackingRepository
+	shipmentRepository shipment.ShipmentRepository
+	shipmentService    shipment.ShipmentService
+	paymentService     payment.PaymentService
+	provider           TrackingProvider
+}
+
+func NewService(trackingRepository TrackingRepository, shipmentRepository shipment.ShipmentRepository, shipmentService shipment.ShipmentService, paymentService payment.PaymentService, provider TrackingProvider) *service {
+	return &service{trackingRepository, shipmentRepository, shipmentService, paymentService, provider}
+}
+
+func (s *service) FindTrackingsByPayment(paymentID int) ([]Tracking, error) {
+	if _, err := s.paymentService.FindPaymentByID(paymentID); err != nil {
+		return nil, err
+	}
+
+	return s.trackingRepository.FindTrackingsByPayment(paymentID)
+}
+
+func (s *service) PollInTransit() (int, error) {
+	targets, err := s.inTransit()
+
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	errs := []error{}
+
+	for _, t := range targets {
+		ok, err := s.poll(t)
+
+		if err != nil {
+			log.Printf("Failed to poll tracking %s for payment %d: %v", t.Resi, t.PaymentID, err)
+			errs = append(errs, fmt.Errorf("resi %s: %w", t.Resi, err))
+			continue
+		}
+
+		if ok {
+			delivered++
+		}
+	}
+
+	return delivered, errors.Join(errs...)
+}
+
+func (s *service) inTransit() ([]target, error) {
+	targets := []target{}
+
+	shipments, err := s.shipmentRepository.FindShipmentsByStatus(shipment.StatusShipped)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sh := range shipments {
+		if sh.Resi == "" || strings.HasPrefix(sh.Resi, dispatch.ReferencePrefix) {
+			continue
+		}
+
+		p, err := s.paymentService.FindPaymentByID(int(sh.PaymentID))
+
+		if err != nil {
+			continue
+		}
+
+		targets = append(targets, target{
+			PaymentID:  p.ID,
+			ShipmentID: sh.ID,
+			Courier:    courierCode(p.ShippingCourier, sh.DeliveryName),
+			Resi:       sh.Resi,
+			Cash:       payment.IsCashMethod(p.PaymentMethod),
+		})
+	}
+
+	payments, err := s.paymentService.FindPaymentByStatus(payment.StatusShipped)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range payments {
+		if p.Resi == "" {
+			continue
+		}
+
+		shipments, err := s.shipmentRepository.FindShipmentsByPayment(int(p.ID))
+
+		if err != nil || len(shipments) > 0 {
+			continue
+		}
+
+		targets = append(targets, target{
+			PaymentID: p.ID,
+			Courier:   courierCode(p.ShippingCourier, p.DeliveryName),
+			Resi:      p.Resi,
+			Cash:      payment.IsCashMethod(p.PaymentMethod),
+		})
+	}
+
+	return targets, nil
+}
+
+func (s *service) poll(t target) (bool, error) {
+	tracking, err := s.trackingRepository.FindTracking(t.PaymentID, t.ShipmentID, t.Resi)
+
+	if err != nil {
+		tracking, err = s.trackingRepository.CreateTracking(Tracking{
+			PaymentID:  t.PaymentID,
+			ShipmentID: t.ShipmentID,
+			Resi:       t.Resi,
+			Courier:    t.Courier,
+			Status:     StatusPending,
+		})
+
+		if err != nil {
+			return false, err
+		}
+	}
+
+	now := time.Now()
+	tracking.PolledAt = &now
+	tracking.Courier = t.Courier
+
+	result, err := s.provider.Track(t.Courier, t.Resi)
+
+	if err != nil {
+		tracking.LastError = err.Error()
+		_, err = s.trackingRepository.UpdateTracking(tracking)
+		return false, err
+	}
+
+	tracking.LastError = ""
+
+	for _, event := range result.Events {
+		fingerprint := eventFingerprint(tracking.ID, event)
+
+		exists, err := s.trackingRepository.EventExists(fingerprint)
+
+		if err != nil {
+			return false, err
+		}
+
+		if exists {
+			continue
+		}
+
+		_, err = s.trackingRepository.CreateEvent(Event{
+			TrackingID:  tracking.ID,
+			Status:      event.Status,
+			Description: event.Description,
+			Location:    event.Location,
+			OccurredAt:  event.OccurredAt,
+			Fingerprint: fingerprint,
+		})
+
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if result.Status != "" {
+		tracking.Status = result.Status
+	}
+
+	advanced := false
+
+	if result.Status == StatusDelivered && tracking.DeliveredAt == nil {
+		if t.Cash {
+			tracking.DeliveredAt = &now
+		} else if err := s.advance(t); err != nil {
+			tracking.LastError = err.Error()
+		} else {
+			tracking.DeliveredAt = &now
+			advanced = true
+		}
+	}
+
+	if _, err := s.trackingRepository.UpdateTracking(tracking); err != nil {
+		return false, err
+	}
+
+	return advanced, nil
+}
+
+func (s *service) advance(t target) error {
+	if t.ShipmentID != 0 {
+		_, err := s.shipmentService.UpdateStatus(int(t.ShipmentID), shipment.StatusDelivered, payment.SystemActor)
+		return err
+	}
+
+	_, err := s.paymentService.TransitionStatus(int(t.PaymentID), payment.StatusDelivered, payment.SystemActor, "Delivered according to courier tracking")
+	return err
+}
+
+func courierCode(courier string, deliveryName string) string {
+	if courier != "" && courier != "local" {
+		return strings.ToLower(courier)
+	}
+	return strings.ToLower(strings.TrimSpace(deliveryName))
+}
+
+func eventFingerprint(trackingID uint64, event ProviderEvent) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d|%s|%s|%s|%d", trackingID, event.Status, event.Description, event.Location, event.OccurredAt.Unix())))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,228 @@
+package tracking
+
+import (
+	"errors"
+	"taman-pempek/payment"
+	"taman-pempek/shipment"
+	"testing"
+	"time"
+)
+
+type memoryRepository struct {
+	trackings []Tracking
+	events    []Event
+}
+
+func (r *memoryRepository) FindTracking(paymentID uint64, shipmentID uint64, resi string) (Tracking, error) {
+	for _, tracking := range r.trackings {
+		if tracking.PaymentID == paymentID && tracking.ShipmentID == shipmentID && tracking.Resi == resi {
+			return tracking, nil
+		}
+	}
+	return Tracking{}, errors.New("Tracking not found")
+}
+
+func (r *memoryRepository) FindTrackingsByPayment(paymentID int) ([]Tracking, error) {
+	trackings := []Tracking{}
+	for _, tracking := range r.trackings {
+		if tracking.PaymentID == uint64(paymentID) {
+			trackings = append(trackings, tracking)
+		}
+	}
+	return trackings, nil
+}
+
+func (r *memoryRepository) CreateTracking(tracking Tracking) (Tracking, error) {
+	tracking.ID = uint64(len(r.trackings) + 1)
+	r.trackings = append(r.trackings, tracking)
+	return tracking, nil
+}
+
+func (r *memoryRepository) UpdateTracking(tracking Tracking) (Tracking, error) {
+	r.trackings[tracking.ID-1] = tracking
+	return tracking, nil
+}
+
+func (r *memoryRepository) EventExists(fingerprint string) (bool, error) {
+	for _, event := range r.events {
+		if event.Fingerprint == fingerprint {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryRepository) CreateEvent(event Event) (Event, error) {
+	event.ID = uint64(len(r.events) + 1)
+	r.events = append(r.events, event)
+	return event, nil
+}
+
+type recordingShipments struct {
+	shipment.ShipmentService
+	delivered []int
+}
+
+func (s *recordingShipments) UpdateStatus(ID int, status string, actor payment.Actor) (shipment.Shipment, error) {
+	if status != shipment.StatusDelivered || actor.Role != payment.RoleSystem {
+		return shipment.Shipment{}, errors.New("unexpected shipment update")
+	}
+	s.delivered = append(s.delivered, ID)
+	return shipment.Shipment{ID: uint64(ID), Status: status}, nil
+}
+
+func newTestService() (*service, *memoryRepository, *recordingShipments, *FakeProvider) {
+	repository := &memoryRepository{}
+	shipments := &recordingShipments{}
+	provider := NewFakeProvider()
+	return NewService(repository, nil, shipments, nil, provider), repository, shipments, provider
+}
+
+func TestPollRecordsFakeProviderEvents(t *testing.T) {
+	s, repository, shipments, provider := newTestService()
+	target := target{PaymentID: 1, ShipmentID: 2, Courier: "jne", Resi: "JNE123"}
+
+	for i := 0; i < 2; i++ {
+		advanced, err := s.poll(target)
+		if err != nil {
+			t.Fatalf("poll: %v", err)
+		}
+		if advanced {
+			t.Fatal("in transit shipment was advanced")
+		}
+	}
+
+	if calls := provider.Calls(); len(calls) != 2 || calls[0] != "jne:JNE123" {
+		t.Errorf("calls = %v", calls)
+	}
+	if len(repository.trackings) != 1 || repository.trackings[0].Status != StatusInTransit || repository.trackings[0].PolledAt == nil {
+		t.Errorf("trackings = %+v", repository.trackings)
+	}
+	if len(repository.events) != 1 || repository.events[0].Description != "Shipment received by JNE" {
+		t.Errorf("events = %+v", repository.events)
+	}
+	if len(shipments.delivered) != 0 {
+		t.Errorf("delivered = %v", shipments.delivered)
+	}
+}
+
+func TestPollAdvancesDeliveredShipmentOnce(t *testing.T) {
+	s, repository, shipments, provider := newTestService()
+	target := target{PaymentID: 1, ShipmentID: 2, Courier: "jne", Resi: "JNE123"}
+	received := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+
+	provider.Set("JNE123", Result{
+		Status: StatusDelivered,
+		Events: []ProviderEvent{
+			{Status: StatusInTransit, Description: "Received at origin", Location: "Palembang", OccurredAt: received},
+			{Status: StatusDelivered, Description: "Delivered to recipient", Location: "Jakarta", OccurredAt: received.Add(48 * time.Hour)},
+		},
+	})
+
+	advanced, err := s.poll(target)
+	if err != nil || !advanced {
+		t.Fatalf("poll = %v, %v", advanced, err)
+	}
+
+	advanced, err = s.poll(target)
+	if err != nil || advanced {
+		t.Fatalf("second poll = %v, %v", advanced, err)
+	}
+
+	if len(shipments.delivered) != 1 || shipments.delivered[0] != 2 {
+		t.Errorf("delivered = %v", shipments.delivered)
+	}
+	if len(repository.events) != 2 {
+		t.Errorf("got %d events, want 2", len(repository.events))
+	}
+	if tracking := repository.trackings[0]; tracking.Status != StatusDelivered || tracking.DeliveredAt == nil {
+		t.Errorf("tracking = %+v", tracking)
+	}
+}
+
+func TestPollLeavesCashOrdersToCollection(t *testing.T) {
+	s, repository, shipments, provider := newTestService()
+
+	provider.Set("COD1", Result{Status: StatusDelivered})
+
+	advanced, err := s.poll(target{PaymentID: 1, ShipmentID: 2, Courier: "jne", Resi: "COD1", Cash: true})
+	if err != nil || advanced {
+		t.Fatalf("poll = %v, %v", advanced, err)
+	}
+
+	if len(shipments.delivered) != 0 {
+		t.Errorf("delivered = %v", shipments.delivered)
+	}
+	if repository.trackings[0].DeliveredAt == nil {
+		t.Error("delivery time was not recorded")
+	}
+}
+
+type failingRepository struct {
+	*memoryRepository
+	failResi string
+}
+
+func (r *failingRepository) CreateTracking(tracking Tracking) (Tracking, error) {
+	if tracking.Resi == r.failResi {
+		return Tracking{}, errors.New("database is unavailable")
+	}
+	return r.memoryRepository.CreateTracking(tracking)
+}
+
+type shippedShipments struct {
+	shipment.ShipmentRepository
+	shipments []shipment.Shipment
+}
+
+func (r *shippedShipments) FindShipmentsByStatus(status string) ([]shipment.Shipment, error) {
+	return r.shipments, nil
+}
+
+type shippedPayments struct {
+	payment.PaymentService
+}
+
+func (s *shippedPayments) FindPaymentByID(ID int) (payment.Payment, error) {
+	return payment.Payment{ID: uint64(ID), PaymentMethod: payment.MethodTransfer, ShippingCourier: "jne"}, nil
+}
+
+func (s *shippedPayments) FindPaymentByStatus(paymentStatus string) ([]payment.Payment, error) {
+	return []payment.Payment{}, nil
+}
+
+func TestPollInTransitContinuesAfterFailure(t *testing.T) {
+	repository := &failingRepository{memoryRepository: &memoryRepository{}, failResi: "BAD1"}
+	shipments := &recordingShipments{}
+	provider := NewFakeProvider()
+	s := NewService(repository, &shippedShipments{shipments: []shipment.Shipment{
+		{ID: 1, PaymentID: 1, Resi: "BAD1"},
+		{ID: 2, PaymentID: 2, Resi: "GOOD1"},
+	}}, shipments, &shippedPayments{}, provider)
+
+	provider.Set("BAD1", Result{Status: StatusDelivered})
+	provider.Set("GOOD1", Result{Status: StatusDelivered})
+
+	delivered, err := s.PollInTransit()
+	if err == nil {
+		t.Error("failure was not reported")
+	}
+
+	if delivered != 1 || len(shipments.delivered) != 1 || shipments.delivered[0] != 2 {
+		t.Errorf("delivered = %d, %v", delivered, shipments.delivered)
+	}
+}
+
+func TestCourierCode(t *testing.T) {
+	cases := map[[2]string]string{
+		{"JNE", "Kurir Toko"}:  "jne",
+		{"local", " SiCepat "}: "sicepat",
+		{"", "TIKI"}:           "tiki",
+	}
+
+	for input, want := range cases {
+		if got := courierCode(input[0], input[1]); got != want {
+			t.Errorf("courierCode(%q, %q) = %q, want %q", input[0], input[1], got, want)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package user
+
+type ForgotPasswordRequest struct {
+	Email   string `json:"email" binding:"required,email"`
+	Channel string `json:"channel" binding:"required,oneof=email whatsapp"`
+}
+
+type ResetPasswordRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Code     string `json:"code" binding:"required,numeric"`
+	Password string `json:"password" binding:"required,strongpassword"`
+}
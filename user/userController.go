@@ -2,22 +2,26 @@ package user
 
 import (
 	"fmt"
+	"log"
 	"net/http"
-	"os"
 	"strconv"
-	"time"
+	"strings"
+	"taman-pempek/notification"
+	"taman-pempek/otp"
+	"taman-pempek/session"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
-	"github.com/golang-jwt/jwt/v5"
 )
 
 type controller struct {
-	userService UserService
+	userService    UserService
+	sessionService session.SessionService
+	otpService     otp.OTPService
 }
 
-func NewController(userService UserService) *controller {
-	return &controller{userService}
+func NewController(userService UserService, sessionService session.SessionService, otpService otp.OTPService) *controller {
+	return &controller{userService, sessionService, otpService}
 }
 
 func (cn *controller) GetUsers(c *gin.Context) {
@@ -166,6 +170,15 @@ func (cn *controller) UpdateUser(c *gin.Context) {
 		return
 	}
 
+	if userRequest.Role != "" && c.GetString("UserRole") != RoleAdmin {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Only admin can change user role",
+		})
+		return
+	}
+
 	idString := c.Param("id")
 	id, err := strconv.Atoi(idString)
 
@@ -193,6 +206,10 @@ func (cn *controller) UpdateUser(c *gin.Context) {
 		return
 	}
 
+	if userRequest.Password != "" {
+		cn.sessionService.RevokeSessionsByUser(user.ID)
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"error": false,
 		"msg":   "Success!",
@@ -248,9 +265,9 @@ func (ch *controller) Login(c *gin.Context) {
 		return
 	}
 
-	user, _ := ch.userService.FindUserByEmail(request.Email)
+	user, err := ch.userService.Login(request)
 
-	if user.ID == 0 || user.Password != request.Password {
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": true,
 			"data":  nil,
@@ -260,13 +277,7 @@ func (ch *controller) Login(c *gin.Context) {
 		return
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"foo": user.ID,
-		"exp": time.Now().Add(time.Hour * 24 * 7).Unix(),
-	})
-
-	tokenString, err := token.SignedString([]byte(os.Getenv("SECRET")))
-	fmt.Println(tokenString)
+	tokens, err := ch.sessionService.CreateSession(user.ID, c.Request.UserAgent(), c.ClientIP())
 
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -278,19 +289,30 @@ func (ch *controller) Login(c *gin.Context) {
 		return
 	}
 
-	c.SetSameSite(http.SameSiteLaxMode)
-	c.SetCookie("Authorization", tokenString, 3600*24*7, "", "", false, true)
+	session.SetAuthCookies(c, tokens)
 
 	c.JSON(http.StatusOK, gin.H{
-		"error": false,
-		"msg":   "Success!",
-		"token": tokenString,
-		"data":  convertToUserResponse(user),
+		"error":         false,
+		"msg":           "Success!",
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"data":          convertToUserResponse(user),
 	})
 }
 
 func (cn *controller) Logout(c *gin.Context) {
-	c.SetCookie("Authorization", "", -1, "", "", false, true)
+	err := cn.sessionService.RevokeSession(c.GetUint64("SessionID"))
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	session.ClearAuthCookies(c)
 	c.Set("UserID", nil)
 	c.Set("UserName", nil)
 	c.Set("UserEmail", nil)
@@ -301,14 +323,262 @@ func (cn *controller) Logout(c *gin.Context) {
 	})
 }
 
+func (cn *controller) LogoutAll(c *gin.Context) {
+	err := cn.sessionService.RevokeSessionsByUser(c.GetUint64("UserID"))
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	session.ClearAuthCookies(c)
+	c.Set("UserID", nil)
+	c.Set("UserName", nil)
+	c.Set("UserEmail", nil)
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Logged out from all devices successfully!",
+	})
+}
+
+func (cn *controller) ForgotPassword(c *gin.Context) {
+	var request ForgotPasswordRequest
+
+	err := c.ShouldBindJSON(&request)
+
+	if err != nil {
+		errorMessages := []string{}
+		for _, e := range err.(validator.ValidationErrors) {
+			errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+			errorMessages = append(errorMessages, errorMessage)
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	user, err := cn.userService.FindUserByEmail(request.Email)
+
+	if err == nil && user.ID != 0 {
+		err = cn.otpService.Issue(user.ID, otp.PurposeResetPassword, request.Channel, contactDestination(user, request.Channel))
+
+		if err != nil {
+			log.Printf("Failed to issue reset code for user %d: %v", user.ID, err)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"data":  nil,
+		"msg":   "If the account exists, a reset code has been sent",
+	})
+}
+
+func (cn *controller) ResetPassword(c *gin.Context) {
+	var request ResetPasswordRequest
+
+	err := c.ShouldBindJSON(&request)
+
+	if err != nil {
+		errorMessages := []string{}
+		for _, e := range err.(validator.ValidationErrors) {
+			errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+			errorMessages = append(errorMessages, errorMessage)
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	user, err := cn.userService.FindUserByEmail(request.Email)
+
+	if err != nil || user.ID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   "Invalid or expired code",
+		})
+		return
+	}
+
+	err = cn.otpService.Verify(user.ID, otp.PurposeResetPassword, user.Email, request.Code)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	user, err = cn.userService.UpdateUser(int(user.ID), UserUpdateRequest{Password: request.Password})
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	cn.sessionService.RevokeSessionsByUser(user.ID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Password has been reset",
+		"data":  convertToUserResponse(user),
+	})
+}
+
+func (cn *controller) RequestVerification(c *gin.Context) {
+	var request VerificationRequest
+
+	err := c.ShouldBindJSON(&request)
+
+	if err != nil {
+		errorMessages := []string{}
+		for _, e := range err.(validator.ValidationErrors) {
+			errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+			errorMessages = append(errorMessages, errorMessage)
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	user, err := cn.userService.FindUserByID(c.GetUint64("UserID"))
+
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	err = cn.otpService.Issue(user.ID, verificationPurpose(request.Channel), request.Channel, contactDestination(user, request.Channel))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"data":  nil,
+		"msg":   "Verification code has been sent",
+	})
+}
+
+func (cn *controller) ConfirmVerification(c *gin.Context) {
+	var request VerificationConfirmRequest
+
+	err := c.ShouldBindJSON(&request)
+
+	if err != nil {
+		errorMessages := []string{}
+		for _, e := range err.(validator.ValidationErrors) {
+			errorMessage := fmt.Sprintf("Error on %s field, condition %s", e.Field(), e.ActualTag())
+			errorMessages = append(errorMessages, errorMessage)
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   errorMessages,
+		})
+		return
+	}
+
+	user, err := cn.userService.FindUserByID(c.GetUint64("UserID"))
+
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	destination := contactDestination(user, request.Channel)
+
+	err = cn.otpService.Verify(user.ID, verificationPurpose(request.Channel), destination, request.Code)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	user, err = cn.userService.VerifyContact(user.ID, request.Channel, destination)
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.HasPrefix(err.Error(), "Cannot") {
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, gin.H{
+			"error": true,
+			"data":  nil,
+			"msg":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"error": false,
+		"msg":   "Success!",
+		"data":  convertToUserResponse(user),
+	})
+}
+
+func contactDestination(user User, channel string) string {
+	if channel == notification.ChannelWhatsapp {
+		return user.Whatsapp
+	}
+	return user.Email
+}
+
+func verificationPurpose(channel string) string {
+	if channel == notification.ChannelWhatsapp {
+		return otp.PurposeVerifyWhatsapp
+	}
+	return otp.PurposeVerifyEmail
+}
+
 func convertToUserResponse(user User) UserResponse {
 	return UserResponse{
-		ID:       user.ID,
-		Name:     user.Name,
-		Email:    user.Email,
-		Password: user.Password,
-		Whatsapp: user.Whatsapp,
-		Gender:   user.Gender,
-		Role:     user.Role,
+		ID:               user.ID,
+		Name:             user.Name,
+		Email:            user.Email,
+		Whatsapp:         user.Whatsapp,
+		Gender:           user.Gender,
+		Role:             user.Role,
+		EmailVerified:    user.EmailVerifiedAt != nil,
+		WhatsappVerified: user.WhatsappVerifiedAt != nil,
 	}
 }
@@ -0,0 +1,56 @@
+package user
+
+import (
+	"crypto/subtle"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const minPasswordLength = 8
+
+func hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return string(hash), err
+}
+
+func isHashedPassword(password string) bool {
+	return strings.HasPrefix(password, "$2a$") || strings.HasPrefix(password, "$2b$") || strings.HasPrefix(password, "$2y$")
+}
+
+func checkPassword(stored string, password string) bool {
+	if isHashedPassword(stored) {
+		return bcrypt.CompareHashAndPassword([]byte(stored), []byte(password)) == nil
+	}
+	return subtle.ConstantTimeCompare([]byte(stored), []byte(password)) == 1
+}
+
+func isStrongPassword(password string) bool {
+	if len(password) < minPasswordLength {
+		return false
+	}
+
+	var hasLetter, hasDigit bool
+
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+
+	return hasLetter && hasDigit
+}
+
+func RegisterValidations() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterValidation("strongpassword", func(fl validator.FieldLevel) bool {
+			return isStrongPassword(fl.Field().String())
+		})
+	}
+}
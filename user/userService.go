@@ -2,6 +2,8 @@ package user
 
 import (
 	"errors"
+	"taman-pempek/notification"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -14,6 +16,8 @@ type UserService interface {
 	CreateUser(user UserCreateRequest) (User, error)
 	UpdateUser(ID int, user UserUpdateRequest) (User, error)
 	DeleteUser(ID int) (User, error)
+	Login(request LoginRequest) (User, error)
+	VerifyContact(ID uint64, channel string, destination string) (User, error)
 }
 
 type service struct {
@@ -41,10 +45,16 @@ func (s *service) FindUserByEmail(email string) (User, error) {
 }
 
 func (s *service) CreateUser(userRequest UserCreateRequest) (User, error) {
+	password, err := hashPassword(userRequest.Password)
+
+	if err != nil {
+		return User{}, err
+	}
+
 	userData := User{
 		Name:     userRequest.Name,
 		Email:    userRequest.Email,
-		Password: userRequest.Password,
+		Password: password,
 		Whatsapp: userRequest.Whatsapp,
 		Gender:   userRequest.Gender,
 		Role:     userRequest.Role,
@@ -69,14 +79,22 @@ func (s *service) UpdateUser(ID int, userRequest UserUpdateRequest) (User, error
 	if userRequest.Name != "" {
 		user.Name = userRequest.Name
 	}
-	if userRequest.Email != "" {
+	if userRequest.Email != "" && userRequest.Email != user.Email {
 		user.Email = userRequest.Email
+		user.EmailVerifiedAt = nil
 	}
 	if userRequest.Password != "" {
-		user.Password = userRequest.Password
+		password, err := hashPassword(userRequest.Password)
+
+		if err != nil {
+			return User{}, err
+		}
+
+		user.Password = password
 	}
-	if userRequest.Whatsapp != "" {
+	if userRequest.Whatsapp != "" && userRequest.Whatsapp != user.Whatsapp {
 		user.Whatsapp = userRequest.Whatsapp
+		user.WhatsappVerifiedAt = nil
 	}
 	if userRequest.Gender != "" {
 		user.Gender = userRequest.Gender
@@ -101,3 +119,52 @@ func (s *service) DeleteUser(ID int) (User, error) {
 
 	return s.userRepository.DeleteUser(user)
 }
+
+func (s *service) Login(request LoginRequest) (User, error) {
+	user, _ := s.userRepository.FindUserByEmail(request.Email)
+
+	if user.ID == 0 || !checkPassword(user.Password, request.Password) {
+		return User{}, errors.New("Invalid email or password")
+	}
+
+	if !isHashedPassword(user.Password) {
+		password, err := hashPassword(request.Password)
+
+		if err != nil {
+			return User{}, err
+		}
+
+		user.Password = password
+
+		return s.userRepository.UpdateUser(user)
+	}
+
+	return user, nil
+}
+
+func (s *service) VerifyContact(ID uint64, channel string, destination string) (User, error) {
+	user, err := s.userRepository.FindUserByID(ID)
+
+	if err != nil {
+		return User{}, err
+	}
+
+	now := time.Now()
+
+	switch channel {
+	case notification.ChannelEmail:
+		if user.Email != destination {
+			return User{}, errors.New("Cannot verify an email that has changed, please request a new code")
+		}
+		user.EmailVerifiedAt = &now
+	case notification.ChannelWhatsapp:
+		if user.Whatsapp != destination {
+			return User{}, errors.New("Cannot verify a WhatsApp number that has changed, please request a new code")
+		}
+		user.WhatsappVerifiedAt = &now
+	default:
+		return User{}, errors.New("Unsupported channel")
+	}
+
+	return s.userRepository.UpdateUser(user)
+}
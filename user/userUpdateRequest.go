@@ -3,8 +3,8 @@ package user
 type UserUpdateRequest struct {
 	Name     string `json:"name,omitempty"`
 	Email    string `json:"email,omitempty"`
-	Password string `json:"password,omitempty"`
+	Password string `json:"password,omitempty" binding:"omitempty,strongpassword"`
 	Whatsapp string `json:"whatsapp,omitempty"`
 	Gender   string `json:"gender,omitempty"`
-	Role     string `json:"role,omitempty"`
+	Role     string `json:"role,omitempty" binding:"omitempty,oneof=buyer seller admin courier"`
 }
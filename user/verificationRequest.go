@@ -0,0 +1,10 @@
+package user
+
+type VerificationRequest struct {
+	Channel string `json:"channel" binding:"required,oneof=email whatsapp"`
+}
+
+type VerificationConfirmRequest struct {
+	Channel string `json:"channel" binding:"required,oneof=email whatsapp"`
+	Code    string `json:"code" binding:"required,numeric"`
+}